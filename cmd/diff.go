@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/danvergara/dblab/internal/profiles"
+	"github.com/danvergara/dblab/internal/schemadiff"
+	"github.com/danvergara/dblab/pkg/app"
+	"github.com/danvergara/dblab/pkg/bubbletui"
+	"github.com/danvergara/dblab/pkg/client"
+	"github.com/danvergara/dblab/pkg/command"
+	"github.com/danvergara/dblab/pkg/config"
+	"github.com/danvergara/dblab/pkg/connection"
+)
+
+// diff command flags.
+var (
+	diffFrom      string
+	diffTo        string
+	diffSchema    string
+	diffMigration bool
+	diffOutput    string
+	diffTUI       bool
+)
+
+// diffCmd represents the diff command.
+var diffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Compare the structure of two databases",
+	Long: `dblab diff is a command to compare the tables, columns, indexes, constraints and views
+of two databases, given the names of their connection profiles.
+The profiles are looked up in $XDG_CONFIG_HOME/dblab/dblab.json first, then in the database section of the config file.
+It reports the objects missing in the target, the extra ones and the ones with a different type or nullability,
+and it can write a migration script, in the SQL dialect of the target, that brings the target in line with the source.`,
+	Example: `  dblab diff --from prod --to staging
+  dblab diff --from prod --to staging --schema billing --migration -o migration.sql`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		fromOpts, err := profileOptions(diffFrom)
+		if err != nil {
+			return err
+		}
+
+		toOpts, err := profileOptions(diffTo)
+		if err != nil {
+			return err
+		}
+
+		if fromOpts.SSHHost != "" && toOpts.SSHHost != "" {
+			return errors.New("only one of the profiles can connect through an SSH tunnel")
+		}
+
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+
+		from, err := readSnapshot(ctx, fromOpts, diffSchema)
+		if err != nil {
+			return fmt.Errorf("couldn't read the structure of %s: %w", diffFrom, err)
+		}
+
+		to, err := readSnapshot(ctx, toOpts, diffSchema)
+		if err != nil {
+			return fmt.Errorf("couldn't read the structure of %s: %w", diffTo, err)
+		}
+
+		report := schemadiff.Compare(from, to)
+
+		if diffTUI {
+			return bubbletui.RunDiff(fmt.Sprintf("%s -> %s", diffFrom, diffTo), report)
+		}
+
+		out := cmd.OutOrStdout()
+		if diffOutput != "" {
+			f, err := os.Create(diffOutput)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				err = errors.Join(err, f.Close())
+			}()
+
+			out = f
+		}
+
+		if diffMigration {
+			script, err := report.Migration()
+			if err != nil {
+				return fmt.Errorf("couldn't write the migration script: %w", err)
+			}
+
+			_, err = fmt.Fprint(out, script)
+			return err
+		}
+
+		return report.Write(out)
+	},
+}
+
+// diffProfile returns the connection options of a profile saved by dblab,
+// or of a database section of the config file with the given name.
+func profileOptions(name string) (command.Options, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return command.Options{}, err
+	}
+
+	opts, err := profiles.LoadProfile(configDir, name)
+	if err == nil {
+		return opts, nil
+	}
+
+	if !errors.Is(err, profiles.ErrProfileNotFound) {
+		return command.Options{}, err
+	}
+
+	opts, err = config.Init(name)
+	if err != nil || opts.Profile != name {
+		return command.Options{}, fmt.Errorf("profile %q not found in the saved profiles nor in the config file", name)
+	}
+
+	return opts, nil
+}
+
+// readSnapshot connects to a database and returns the structure of the given schema.
+// The connection is read-only, since the database is only inspected.
+func readSnapshot(ctx context.Context, opts command.Options, schema string) (*client.Snapshot, error) {
+	opts.ReadOnly = true
+	if opts.Limit == 0 {
+		opts.Limit = 100
+	}
+
+	if err := connection.ValidateOpts(opts); err != nil {
+		return nil, err
+	}
+
+	c, sc, err := app.Connect(opts)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = c.DB().Close()
+
+		if sc != nil {
+			_ = sc.Close()
+		}
+	}()
+
+	snapshot, err := c.Snapshot(ctx, schema)
+	if err != nil && sc != nil {
+		return nil, errors.Join(err, sc.Err())
+	}
+
+	return snapshot, err
+}
+
+func init() {
+	diffCmd.Flags().StringVar(&diffFrom, "from", "", "Profile of the source database, the reference")
+	diffCmd.Flags().StringVar(&diffTo, "to", "", "Profile of the target database, the one brought in line with the source")
+	diffCmd.Flags().
+		StringVar(&diffSchema, "schema", "", "Schema to compare on both databases (default is the schema of each profile, or the current one)")
+	diffCmd.Flags().
+		BoolVar(&diffMigration, "migration", false, "Write the migration script that brings the target in line with the source, instead of the report")
+	diffCmd.Flags().StringVarP(&diffOutput, "output", "o", "", "File to write the report or the script to (default is the standard output)")
+	diffCmd.Flags().BoolVar(&diffTUI, "tui", false, "Show the report and the migration script on an interactive view")
+
+	_ = diffCmd.MarkFlagRequired("from")
+	_ = diffCmd.MarkFlagRequired("to")
+
+	rootCmd.AddCommand(diffCmd)
+}
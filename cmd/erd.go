@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/danvergara/dblab/internal/erd"
+)
+
+// erd command flags.
+var (
+	erdProfile string
+	erdSchema  string
+	erdTables  []string
+	erdFormat  string
+	erdOutput  string
+)
+
+// erdCmd represents the erd command.
+var erdCmd = &cobra.Command{
+	Use:   "erd",
+	Short: "Generate an entity-relationship diagram of a schema",
+	Long: `dblab erd is a command to generate the entity-relationship diagram of a schema, or of some of its tables,
+given the name of a connection profile.
+The profile is looked up in $XDG_CONFIG_HOME/dblab/dblab.json first, then in the database section of the config file.
+The relationships are drawn from the foreign keys between the tables of the diagram.
+The diagram is written as a Mermaid erDiagram, a Graphviz DOT graph or a PlantUML diagram.`,
+	Example: `  dblab erd --profile prod > schema.mmd
+  dblab erd --profile prod --schema billing --tables invoices,customers --format dot -o billing.dot
+  dblab erd --profile prod -o schema.puml`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		// the format is taken from the extension of the output file, unless it's set explicitly.
+		if !cmd.Flags().Changed("format") && erdOutput != "" {
+			for _, format := range erd.Formats {
+				if filepath.Ext(erdOutput) == format.Extension() {
+					erdFormat = string(format)
+				}
+			}
+		}
+
+		format, err := erd.ParseFormat(erdFormat)
+		if err != nil {
+			return err
+		}
+
+		opts, err := profileOptions(erdProfile)
+		if err != nil {
+			return err
+		}
+
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+
+		snapshot, err := readSnapshot(ctx, opts, erdSchema)
+		if err != nil {
+			return fmt.Errorf("couldn't read the structure of %s: %w", erdProfile, err)
+		}
+
+		diagram, err := erd.New(snapshot, erdTables...)
+		if err != nil {
+			return err
+		}
+
+		text, err := diagram.Render(format)
+		if err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+		if erdOutput != "" {
+			f, err := os.Create(erdOutput)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				err = errors.Join(err, f.Close())
+			}()
+
+			out = f
+		}
+
+		_, err = fmt.Fprint(out, text)
+		return err
+	},
+}
+
+func init() {
+	erdCmd.Flags().StringVar(&erdProfile, "profile", "", "Profile of the database")
+	erdCmd.Flags().StringVar(&erdSchema, "schema", "", "Schema to draw (default is the schema of the profile, or the current one)")
+	erdCmd.Flags().
+		StringSliceVar(&erdTables, "tables", nil, "Comma-separated list of the tables to draw (default is every table of the schema)")
+	erdCmd.Flags().StringVarP(&erdFormat, "format", "f", string(erd.Mermaid), "Format of the diagram: mermaid, dot or plantuml")
+	erdCmd.Flags().
+		StringVarP(&erdOutput, "output", "o", "", "File to write the diagram to, its extension sets the format if --format is not given (default is the standard output)")
+
+	_ = erdCmd.MarkFlagRequired("profile")
+
+	rootCmd.AddCommand(erdCmd)
+}
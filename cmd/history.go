@@ -0,0 +1,333 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/danvergara/dblab/internal/history"
+)
+
+// maxQueryWidth is the number of characters of a query shown by the list and stats commands.
+const maxQueryWidth = 80
+
+// history command flags.
+// The filter flags are read from every sub-command through historyFilter,
+// since they have different default values depending on the sub-command.
+var (
+	historyFormat    string
+	historyOutput    string
+	historyOlderThan string
+	historyAll       bool
+	historyTop       int
+)
+
+// historyCmd represents the history command.
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "List, search, export and prune the query history",
+	Long: `dblab history is a command to manage the queries executed from the TUI,
+without the need to start a session with a database.
+The history is stored in $XDG_CONFIG_HOME/dblab/dblab.gob.
+Queries are identified by the ID shown by the list and search sub-commands.`,
+}
+
+var historyListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the most recent queries",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		entries, err := findHistory(cmd, "")
+		if err != nil {
+			return err
+		}
+
+		return printHistory(cmd.OutOrStdout(), entries)
+	},
+}
+
+var historySearchCmd = &cobra.Command{
+	Use:   "search <text>",
+	Short: "Search the queries containing every word of the given text",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		entries, err := findHistory(cmd, strings.Join(args, " "))
+		if err != nil {
+			return err
+		}
+
+		return printHistory(cmd.OutOrStdout(), entries)
+	},
+}
+
+var historyExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the queries to JSON, CSV or SQL",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		entries, err := findHistory(cmd, "")
+		if err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+		if historyOutput != "" {
+			f, err := os.Create(historyOutput)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				err = errors.Join(err, f.Close())
+			}()
+
+			out = f
+		}
+
+		return history.Export(out, entries, historyFormat)
+	},
+}
+
+var historyDeleteCmd = &cobra.Command{
+	Use:   "delete <id>...",
+	Short: "Delete queries from the history given their IDs",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ids := make([]int, 0, len(args))
+		for _, arg := range args {
+			id, err := strconv.Atoi(strings.TrimPrefix(arg, "#"))
+			if err != nil {
+				return fmt.Errorf("invalid query id %q", arg)
+			}
+			ids = append(ids, id)
+		}
+
+		configDir, err := os.UserConfigDir()
+		if err != nil {
+			return err
+		}
+
+		deleted, err := history.DeleteHistory(configDir, ids...)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "%d queries deleted from the history\n", deleted)
+		return nil
+	},
+}
+
+var historyClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove the queries older than a given age",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var olderThan time.Time
+
+		switch {
+		case historyAll && historyOlderThan != "":
+			return errors.New("--all and --older-than are mutually exclusive")
+		case historyOlderThan != "":
+			age, err := history.ParseAge(historyOlderThan)
+			if err != nil {
+				return err
+			}
+			olderThan = time.Now().Add(-age)
+		case !historyAll:
+			return errors.New("either --older-than or --all is required")
+		}
+
+		configDir, err := os.UserConfigDir()
+		if err != nil {
+			return err
+		}
+
+		cleared, err := history.ClearHistory(configDir, olderThan)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "%d queries removed from the history\n", cleared)
+		return nil
+	},
+}
+
+var historyStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show the most frequent and the slowest queries",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		entries, err := findHistory(cmd, "")
+		if err != nil {
+			return err
+		}
+
+		report := history.Stats(entries, historyTop)
+		out := cmd.OutOrStdout()
+
+		fmt.Fprintf(out, "Total queries:  %d\n", report.Total)
+		fmt.Fprintf(out, "Failed queries: %d\n", report.Failed)
+		fmt.Fprintf(out, "Total duration: %s\n\n", report.TotalDuration)
+
+		fmt.Fprintln(out, "Most frequent queries:")
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "COUNT\tFAILURES\tAVG\tMAX\tQUERY")
+		for _, qs := range report.MostFrequent {
+			fmt.Fprintf(w, "%d\t%d\t%s\t%s\t%s\n", qs.Count, qs.Failures, qs.AvgDuration, qs.MaxDuration, truncateQuery(qs.Query))
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+
+		fmt.Fprintln(out, "\nSlowest queries:")
+		return printHistory(out, report.Slowest)
+	},
+}
+
+// findHistory reads the history file and applies the filters passed as flags.
+// A missing history file is treated as an empty history.
+func findHistory(cmd *cobra.Command, search string) ([]history.Entry, error) {
+	f, err := historyFilter(cmd)
+	if err != nil {
+		return nil, err
+	}
+	f.Search = search
+
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return nil, err
+	}
+
+	queries, err := history.ReadHistory(configDir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return history.Find(queries, f), nil
+}
+
+// printHistory writes the entries as a table.
+func printHistory(out io.Writer, entries []history.Entry) error {
+	if len(entries) == 0 {
+		fmt.Fprintln(out, "no queries found in the history")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tEXECUTED AT\tDURATION\tSTATUS\tPROFILE\tQUERY")
+	for _, e := range entries {
+		status := fmt.Sprintf("%d rows", e.RowCount)
+		if !e.Success {
+			status = "error"
+		}
+
+		fmt.Fprintf(
+			w,
+			"%d\t%s\t%s\t%s\t%s\t%s\n",
+			e.ID,
+			e.Timestamp.Format(time.DateTime),
+			e.Duration,
+			status,
+			e.Profile,
+			truncateQuery(e.QueryText),
+		)
+	}
+
+	return w.Flush()
+}
+
+// truncateQuery puts the query in a single line and cuts it to maxQueryWidth characters.
+func truncateQuery(q string) string {
+	q = strings.Join(strings.Fields(q), " ")
+
+	runes := []rune(q)
+	if len(runes) > maxQueryWidth {
+		return string(runes[:maxQueryWidth-1]) + "…"
+	}
+
+	return q
+}
+
+// historyFilter builds a history.Filter out of the filter flags of the given command.
+func historyFilter(cmd *cobra.Command) (history.Filter, error) {
+	var (
+		f   history.Filter
+		err error
+	)
+
+	if f.Profile, err = cmd.Flags().GetString("profile"); err != nil {
+		return f, err
+	}
+
+	if f.FailedOnly, err = cmd.Flags().GetBool("failed"); err != nil {
+		return f, err
+	}
+
+	if f.MinDuration, err = cmd.Flags().GetDuration("min-duration"); err != nil {
+		return f, err
+	}
+
+	if f.Limit, err = cmd.Flags().GetInt("limit"); err != nil {
+		return f, err
+	}
+
+	since, err := cmd.Flags().GetString("since")
+	if err != nil {
+		return f, err
+	}
+
+	if since != "" {
+		if f.Since, err = history.ParseSince(since, time.Now()); err != nil {
+			return f, err
+		}
+	}
+
+	return f, nil
+}
+
+// addHistoryFilterFlags adds the flags used to filter the history to the given command.
+func addHistoryFilterFlags(cmd *cobra.Command, defaultLimit int) {
+	cmd.Flags().
+		String("since", "", "Only queries executed since a date (2006-01-02) or an age (e.g. 12h, 7d, 2w)")
+	cmd.Flags().String("profile", "", "Only queries executed against the given connection profile")
+	cmd.Flags().Bool("failed", false, "Only queries that failed")
+	cmd.Flags().
+		Duration("min-duration", 0, "Only queries that took at least the given duration (e.g. 500ms, 2s)")
+	cmd.Flags().Int("limit", defaultLimit, "Maximum number of queries, the most recent ones first (0 means no limit)")
+}
+
+func init() {
+	addHistoryFilterFlags(historyListCmd, 20)
+	addHistoryFilterFlags(historySearchCmd, 20)
+	addHistoryFilterFlags(historyExportCmd, 0)
+	addHistoryFilterFlags(historyStatsCmd, 0)
+
+	historyExportCmd.Flags().StringVar(&historyFormat, "format", history.FormatJSON, "Export format [json|csv|sql]")
+	historyExportCmd.Flags().StringVarP(&historyOutput, "output", "o", "", "File to write the export to (default is the standard output)")
+
+	historyClearCmd.Flags().
+		StringVar(&historyOlderThan, "older-than", "", "Remove the queries older than the given age (e.g. 12h, 30d, 2w)")
+	historyClearCmd.Flags().BoolVar(&historyAll, "all", false, "Remove the whole history")
+
+	historyStatsCmd.Flags().IntVar(&historyTop, "top", 10, "Number of queries listed on every section of the report")
+
+	historyCmd.AddCommand(
+		historyListCmd,
+		historySearchCmd,
+		historyExportCmd,
+		historyDeleteCmd,
+		historyClearCmd,
+		historyStatsCmd,
+	)
+	rootCmd.AddCommand(historyCmd)
+}
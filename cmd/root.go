@@ -41,6 +41,9 @@ var (
 	sshPass          string
 	sshKey           string
 	sshKeyPassphrase string
+	sshKnownHosts    string
+	sshForward       bool
+	sshJump          string
 
 	// oracle specific.
 	traceFile string
@@ -52,6 +55,9 @@ var (
 	trustServerCertificate string
 	connectionTimeout      string
 
+	// postgres specific.
+	pgDriver string
+
 	// keybindings.
 	keybindings bool
 	// save-as.
@@ -79,6 +85,11 @@ func NewRootCmd() *cobra.Command {
 					return err
 				}
 			} else {
+				jumps, err := command.ParseSSHJumps(sshJump)
+				if err != nil {
+					return err
+				}
+
 				opts = command.Options{
 					Driver:                 driver,
 					URL:                    url,
@@ -107,7 +118,11 @@ func NewRootCmd() *cobra.Command {
 					SSHPass:                sshPass,
 					SSHKeyFile:             sshKey,
 					SSHKeyPassphrase:       sshKeyPassphrase,
+					SSHKnownHosts:          sshKnownHosts,
+					SSHForward:             sshForward,
+					SSHJumps:               jumps,
 					ReadOnly:               readOnly,
+					PGDriver:               pgDriver,
 				}
 
 				if form.IsEmpty(opts) {
@@ -127,6 +142,10 @@ func NewRootCmd() *cobra.Command {
 				}
 			}
 
+			if saveAs != "" {
+				opts.Profile = saveAs
+			}
+
 			if err := connection.ValidateOpts(opts); err != nil {
 				return err
 			}
@@ -237,6 +256,8 @@ func init() {
 		StringVarP(&trustServerCertificate, "trust-server-certificate", "", "", "[false|true] server certificate is checked or not")
 	rootCmd.Flags().
 		StringVarP(&connectionTimeout, "timeout", "", "", "in seconds (default is 0 for no timeout), set to 0 for no timeout. Recommended to set to 0 and use context to manage query and connection timeouts")
+	rootCmd.Flags().
+		StringVarP(&pgDriver, "pg-driver", "", "", "[pgx|pq] database/sql driver postgres is connected with (default is pgx)")
 	rootCmd.Flags().StringVarP(&sshHost, "ssh-host", "", "", "SSH Server Hostname/IP")
 	rootCmd.Flags().StringVarP(&sshPort, "ssh-port", "", "", "SSH Port")
 	rootCmd.Flags().StringVarP(&sshUser, "ssh-user", "", "", "SSH User")
@@ -246,6 +267,12 @@ func init() {
 		StringVarP(&sshKey, "ssh-key", "", "", "File with private key for SSH authentication")
 	rootCmd.Flags().
 		StringVarP(&sshKeyPassphrase, "ssh-key-pass", "", "", "Supports connections with protected private keys with passphrase")
+	rootCmd.Flags().
+		StringVarP(&sshKnownHosts, "ssh-known-hosts", "", "", "known_hosts file the key of the SSH host is checked against (default is ~/.ssh/known_hosts)")
+	rootCmd.Flags().
+		StringVarP(&sshJump, "ssh-jump", "", "", "SSH servers to jump through, in order, before the SSH host, as user@host:port,...")
+	rootCmd.Flags().
+		BoolVarP(&sshForward, "ssh-forward", "", false, "Reach the database through a local port forwarded over SSH, which works for every driver")
 
 	// read only mode flag.
 	rootCmd.PersistentFlags().
@@ -0,0 +1,127 @@
+package bookmarks
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// MaxRecent is the number of recently opened tables kept per profile.
+const MaxRecent = 10
+
+// Bookmark points to a table or a view of the database catalog.
+// ID is the ID of the catalog node, used to find the node in the sidebar tree.
+type Bookmark struct {
+	ID     string `json:"id"`
+	Schema string `json:"schema,omitempty"`
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+}
+
+// Bookmarks struct stores the favorite and the recently opened tables of a connection profile.
+type Bookmarks struct {
+	Favorites []Bookmark `json:"favorites,omitempty"`
+	Recent    []Bookmark `json:"recent,omitempty"`
+}
+
+// config struct represents the content of the bookmarks file, the bookmarks of every profile.
+type config struct {
+	Profiles map[string]Bookmarks `json:"profiles"`
+}
+
+// Touch method moves the bookmark to the top of the recently opened tables,
+// dropping the oldest one if there are more than MaxRecent.
+func (b *Bookmarks) Touch(bookmark Bookmark) {
+	recent := slices.DeleteFunc(b.Recent, func(r Bookmark) bool { return r.ID == bookmark.ID })
+	recent = slices.Insert(recent, 0, bookmark)
+
+	if len(recent) > MaxRecent {
+		recent = recent[:MaxRecent]
+	}
+
+	b.Recent = recent
+}
+
+// ToggleFavorite method adds the bookmark to the favorites, or removes it if it was one already.
+// It returns whether the bookmark is a favorite after the change.
+func (b *Bookmarks) ToggleFavorite(bookmark Bookmark) bool {
+	if b.IsFavorite(bookmark.ID) {
+		b.Favorites = slices.DeleteFunc(b.Favorites, func(f Bookmark) bool { return f.ID == bookmark.ID })
+		return false
+	}
+
+	b.Favorites = append(b.Favorites, bookmark)
+	return true
+}
+
+// IsFavorite method tells whether the catalog node with the given ID is a favorite.
+func (b Bookmarks) IsFavorite(id string) bool {
+	return slices.ContainsFunc(b.Favorites, func(f Bookmark) bool { return f.ID == id })
+}
+
+// Read function returns the bookmarks of the given profile.
+// A missing bookmarks file is not an error, the profile has no bookmarks yet.
+func Read(baseDir, profile string) (Bookmarks, error) {
+	cfg, err := readConfig(filepath.Join(baseDir, "dblab", "bookmarks.json"))
+	if err != nil {
+		return Bookmarks{}, err
+	}
+
+	return cfg.Profiles[profile], nil
+}
+
+// Save function stores the bookmarks of the given profile, leaving the ones of other profiles untouched.
+func Save(baseDir, profile string, bookmarks Bookmarks) error {
+	filePath := filepath.Join(baseDir, "dblab", "bookmarks.json")
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return fmt.Errorf("error at creating the dblab app-specific subdirectory, if it does not exist: %w", err)
+	}
+
+	cfg, err := readConfig(filePath)
+	if err != nil {
+		return err
+	}
+
+	if cfg.Profiles == nil {
+		cfg.Profiles = make(map[string]Bookmarks)
+	}
+	cfg.Profiles[profile] = bookmarks
+
+	out, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// Writes a temporary file first and renames it, so the file is never left half written.
+	tempFile := filePath + ".tmp"
+	if err := os.WriteFile(tempFile, out, 0644); err != nil {
+		return fmt.Errorf("failed to write to the file: %w", err)
+	}
+
+	return os.Rename(tempFile, filePath)
+}
+
+// readConfig function reads the bookmarks file, if it exists.
+func readConfig(filePath string) (config, error) {
+	var cfg config
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return cfg, nil
+		}
+		return cfg, fmt.Errorf("the file could not be opened: %w", err)
+	}
+
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &cfg); err != nil {
+			return cfg, err
+		}
+	}
+
+	return cfg, nil
+}
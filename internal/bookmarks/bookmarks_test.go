@@ -0,0 +1,64 @@
+package bookmarks
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveAndReadBookmarks(t *testing.T) {
+	sandboxDir := t.TempDir()
+
+	users := Bookmark{ID: "db:shop.s:public.t:users", Schema: "public", Name: "users", Type: "table"}
+	orders := Bookmark{ID: "db:shop.s:public.t:orders", Schema: "public", Name: "orders", Type: "table"}
+
+	// no bookmarks file yet.
+	b, err := Read(sandboxDir, "local")
+	require.NoError(t, err)
+	require.Empty(t, b.Favorites)
+	require.Empty(t, b.Recent)
+
+	require.NoError(t, Save(sandboxDir, "local", Bookmarks{Favorites: []Bookmark{users}}))
+	require.NoError(t, Save(sandboxDir, "staging", Bookmarks{Recent: []Bookmark{orders}}))
+
+	b, err = Read(sandboxDir, "local")
+	require.NoError(t, err)
+	require.Equal(t, []Bookmark{users}, b.Favorites)
+	require.Empty(t, b.Recent)
+
+	b, err = Read(sandboxDir, "staging")
+	require.NoError(t, err)
+	require.Empty(t, b.Favorites)
+	require.Equal(t, []Bookmark{orders}, b.Recent)
+}
+
+func TestTouch(t *testing.T) {
+	var b Bookmarks
+
+	for i := range MaxRecent + 2 {
+		b.Touch(Bookmark{ID: fmt.Sprintf("t:%d", i), Name: fmt.Sprintf("t%d", i), Type: "table"})
+	}
+
+	require.Len(t, b.Recent, MaxRecent)
+	require.Equal(t, "t:11", b.Recent[0].ID)
+	require.Equal(t, "t:2", b.Recent[MaxRecent-1].ID)
+
+	// opening a table again moves it to the top, without duplicates.
+	b.Touch(Bookmark{ID: "t:5", Name: "t5", Type: "table"})
+	require.Len(t, b.Recent, MaxRecent)
+	require.Equal(t, "t:5", b.Recent[0].ID)
+	require.Equal(t, "t:11", b.Recent[1].ID)
+}
+
+func TestToggleFavorite(t *testing.T) {
+	var b Bookmarks
+	users := Bookmark{ID: "t:users", Name: "users", Type: "table"}
+
+	require.True(t, b.ToggleFavorite(users))
+	require.True(t, b.IsFavorite("t:users"))
+
+	require.False(t, b.ToggleFavorite(users))
+	require.False(t, b.IsFavorite("t:users"))
+	require.Empty(t, b.Favorites)
+}
@@ -0,0 +1,72 @@
+package catalogcache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/danvergara/dblab/pkg/client"
+)
+
+// Cache struct stores the database catalog of a connection profile,
+// so the sidebar shows up right away the next time dblab connects to it.
+// The columns and indexes of the tables loaded so far are cached along with the tree.
+type Cache struct {
+	SavedAt time.Time      `json:"saved_at"`
+	Root    *client.DBNode `json:"root"`
+}
+
+// Read function returns the cached catalog of the given profile.
+// It returns false if there's no cache for the profile yet.
+func Read(baseDir, profile string) (Cache, bool, error) {
+	var cache Cache
+
+	data, err := os.ReadFile(cachePath(baseDir, profile))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return cache, false, nil
+		}
+		return cache, false, fmt.Errorf("the file could not be opened: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return cache, false, err
+	}
+
+	return cache, cache.Root != nil, nil
+}
+
+// Save function stores the catalog of the given profile, replacing the previous one.
+func Save(baseDir, profile string, root *client.DBNode) error {
+	filePath := cachePath(baseDir, profile)
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return fmt.Errorf("error at creating the dblab catalog cache directory, if it does not exist: %w", err)
+	}
+
+	out, err := json.Marshal(Cache{SavedAt: time.Now(), Root: root})
+	if err != nil {
+		return err
+	}
+
+	// Writes a temporary file first and renames it, so the cache is never left half written.
+	tempFile := filePath + ".tmp"
+	if err := os.WriteFile(tempFile, out, 0644); err != nil {
+		return fmt.Errorf("failed to write to the file: %w", err)
+	}
+
+	return os.Rename(tempFile, filePath)
+}
+
+// cachePath function returns the path to the cache file of a profile.
+// The profile may be a connection URL, whose colons and slashes are not allowed in a file name on every system,
+// e.g. Windows, so the file is named after the sha256 of the profile.
+func cachePath(baseDir, profile string) string {
+	sum := sha256.Sum256([]byte(profile))
+	return filepath.Join(baseDir, "dblab", "catalog", hex.EncodeToString(sum[:])+".json")
+}
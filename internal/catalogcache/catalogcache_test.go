@@ -0,0 +1,46 @@
+package catalogcache
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/danvergara/dblab/pkg/client"
+)
+
+func TestSaveAndReadCache(t *testing.T) {
+	sandboxDir := t.TempDir()
+
+	column := &client.DBNode{ID: "db:shop.t:users.c:id", Name: "id integer [PK]", EntityName: "id", Type: "column", ParentID: "db:shop.t:users"}
+	users := &client.DBNode{ID: "db:shop.t:users", Name: "users", EntityName: "users", Type: "table", ParentID: "db:shop", Loaded: true, Children: []*client.DBNode{column}}
+	root := &client.DBNode{ID: "db:shop", Name: "shop", Type: "database", Loaded: true, Children: []*client.DBNode{users}}
+
+	// no cache yet.
+	_, ok, err := Read(sandboxDir, "postgres://localhost/shop")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	require.NoError(t, Save(sandboxDir, "postgres://localhost/shop", root))
+	require.NoError(t, Save(sandboxDir, "staging", &client.DBNode{ID: "db:staging", Type: "database"}))
+
+	cache, ok, err := Read(sandboxDir, "postgres://localhost/shop")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, root, cache.Root)
+	require.False(t, cache.SavedAt.IsZero())
+
+	cache, ok, err = Read(sandboxDir, "staging")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, "db:staging", cache.Root.ID)
+}
+
+func TestCachePath(t *testing.T) {
+	path := cachePath("config", "postgres://db.prod:5432/shop")
+	require.Equal(t, filepath.Join("config", "dblab", "catalog"), filepath.Dir(path))
+	require.NotContains(t, filepath.Base(path), ":")
+	require.Regexp(t, `^[0-9a-f]{64}\.json$`, filepath.Base(path))
+
+	require.NotEqual(t, path, cachePath("config", "postgres://db.staging:5432/shop"))
+}
@@ -0,0 +1,68 @@
+package erd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/danvergara/dblab/pkg/client"
+)
+
+// ASCII function draws the direct neighbors of a table as a tree:
+// the tables it references and the tables referencing it, along with the columns of each foreign key.
+// The foreign keys not involving the table are ignored.
+func ASCII(table client.TableRef, foreignKeys []client.ForeignKey) string {
+	var references, referencedBy []string
+	for _, fk := range foreignKeys {
+		if fk.Table == table.Name {
+			refTable := fk.RefTable
+			if fk.RefSchema != "" && !strings.EqualFold(fk.RefSchema, table.Schema) {
+				refTable = fk.RefSchema + "." + refTable
+			}
+			references = append(references, fmt.Sprintf("%s (%s)", refTable, columnPairs(fk, "→")))
+			continue
+		}
+
+		if fk.RefTable == table.Name {
+			referencedBy = append(referencedBy, fmt.Sprintf("%s (%s)", fk.Table, columnPairs(fk, "→")))
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(table.Name + "\n")
+
+	if len(references) == 0 && len(referencedBy) == 0 {
+		b.WriteString("└── no relations\n")
+		return b.String()
+	}
+
+	type section struct {
+		title   string
+		entries []string
+	}
+
+	var sections []section
+	if len(references) > 0 {
+		sections = append(sections, section{"references", references})
+	}
+	if len(referencedBy) > 0 {
+		sections = append(sections, section{"referenced by", referencedBy})
+	}
+
+	for i, s := range sections {
+		branch, indent := "├── ", "│   "
+		if i == len(sections)-1 {
+			branch, indent = "└── ", "    "
+		}
+		b.WriteString(branch + s.title + "\n")
+
+		for j, entry := range s.entries {
+			leaf := "├── "
+			if j == len(s.entries)-1 {
+				leaf = "└── "
+			}
+			b.WriteString(indent + leaf + entry + "\n")
+		}
+	}
+
+	return b.String()
+}
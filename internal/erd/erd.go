@@ -0,0 +1,173 @@
+// Package erd builds entity-relationship diagrams out of the structure of a schema,
+// using its foreign keys as the relationships between the tables.
+package erd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/danvergara/dblab/pkg/client"
+)
+
+// Format is a text format a diagram is written in.
+type Format string
+
+const (
+	// Mermaid is the erDiagram syntax of Mermaid.
+	Mermaid Format = "mermaid"
+	// DOT is the language of Graphviz.
+	DOT Format = "dot"
+	// PlantUML is the entity-relationship syntax of PlantUML.
+	PlantUML Format = "plantuml"
+)
+
+// Formats lists the supported formats.
+var Formats = []Format{Mermaid, DOT, PlantUML}
+
+// ParseFormat function returns the format with the given name.
+func ParseFormat(name string) (Format, error) {
+	format := Format(strings.ToLower(strings.TrimSpace(name)))
+	if !slices.Contains(Formats, format) {
+		return "", fmt.Errorf("unsupported diagram format %q, use one of mermaid, dot or plantuml", name)
+	}
+
+	return format, nil
+}
+
+// Extension method returns the usual extension of the files written in the format.
+func (f Format) Extension() string {
+	switch f {
+	case DOT:
+		return ".dot"
+	case PlantUML:
+		return ".puml"
+	default:
+		return ".mmd"
+	}
+}
+
+// Diagram holds the tables drawn and the foreign keys between them.
+type Diagram struct {
+	// Name is the name of the diagram, usually the schema of the tables.
+	Name   string
+	Tables []client.TableSnapshot
+}
+
+// New function builds the diagram of the tables of a snapshot.
+// If tables are given, only those are drawn, and the foreign keys to the tables left out are dropped.
+// It fails if any of the given tables is not in the snapshot.
+func New(snapshot *client.Snapshot, tables ...string) (Diagram, error) {
+	name := snapshot.Schema
+	if name == "" {
+		name = "dblab"
+	}
+
+	d := Diagram{Name: name}
+
+	selected := make(map[string]bool, len(tables))
+	for _, table := range tables {
+		selected[table] = true
+	}
+
+	for _, table := range snapshot.Tables {
+		if len(tables) == 0 || selected[table.Name] {
+			d.Tables = append(d.Tables, table)
+			delete(selected, table.Name)
+		}
+	}
+
+	if len(selected) > 0 {
+		missing := make([]string, 0, len(selected))
+		for table := range selected {
+			missing = append(missing, table)
+		}
+		slices.Sort(missing)
+
+		return Diagram{}, fmt.Errorf("tables not found in %s: %s", name, strings.Join(missing, ", "))
+	}
+
+	// the foreign keys are drawn only if both ends are in the diagram.
+	for i, table := range d.Tables {
+		d.Tables[i].ForeignKeys = slices.DeleteFunc(slices.Clone(table.ForeignKeys), func(fk client.ForeignKey) bool {
+			return !d.has(fk.RefTable) || (fk.RefSchema != "" && !strings.EqualFold(fk.RefSchema, snapshot.Schema))
+		})
+	}
+
+	return d, nil
+}
+
+// Neighbors function returns the given table along with the tables it references and the ones referencing it.
+func Neighbors(snapshot *client.Snapshot, table string) []string {
+	neighbors := []string{table}
+	for _, t := range snapshot.Tables {
+		for _, fk := range t.ForeignKeys {
+			switch {
+			case fk.Table == table && !slices.Contains(neighbors, fk.RefTable):
+				neighbors = append(neighbors, fk.RefTable)
+			case fk.RefTable == table && !slices.Contains(neighbors, fk.Table):
+				neighbors = append(neighbors, fk.Table)
+			}
+		}
+	}
+
+	return neighbors
+}
+
+// Render method writes the diagram in the given format.
+func (d Diagram) Render(format Format) (string, error) {
+	switch format {
+	case Mermaid:
+		return d.Mermaid(), nil
+	case DOT:
+		return d.DOT(), nil
+	case PlantUML:
+		return d.PlantUML(), nil
+	default:
+		return "", fmt.Errorf("unsupported diagram format %q", format)
+	}
+}
+
+func (d Diagram) has(table string) bool {
+	return slices.ContainsFunc(d.Tables, func(t client.TableSnapshot) bool { return t.Name == table })
+}
+
+// relationship describes the foreign key from a child table to a parent table.
+type relationship struct {
+	client.ForeignKey
+	// optional tells whether a child row may have no parent, because a column of the foreign key is nullable.
+	optional bool
+}
+
+// relationships method returns the foreign keys drawn, sorted by child table and name.
+func (d Diagram) relationships() []relationship {
+	var relationships []relationship
+	for _, table := range d.Tables {
+		for _, fk := range table.ForeignKeys {
+			optional := slices.ContainsFunc(table.Columns, func(c client.Column) bool {
+				return c.Nullable && slices.Contains(fk.Columns, c.Name)
+			})
+			relationships = append(relationships, relationship{ForeignKey: fk, optional: optional})
+		}
+	}
+
+	return relationships
+}
+
+// columnKeys function returns the key markers of a column, e.g. "PK, FK".
+func columnKeys(column client.Column) []string {
+	var keys []string
+	if column.PrimaryKey {
+		keys = append(keys, "PK")
+	}
+	if column.ForeignKey {
+		keys = append(keys, "FK")
+	}
+
+	return keys
+}
+
+// columnPairs function describes the columns of a foreign key, e.g. "customer_id -> id".
+func columnPairs(fk client.ForeignKey, arrow string) string {
+	return strings.Join(fk.Columns, ", ") + " " + arrow + " " + strings.Join(fk.RefColumns, ", ")
+}
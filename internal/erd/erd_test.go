@@ -0,0 +1,167 @@
+package erd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/danvergara/dblab/pkg/client"
+)
+
+func testSnapshot() *client.Snapshot {
+	return &client.Snapshot{
+		Schema: "public",
+		Tables: []client.TableSnapshot{
+			{
+				Name:    "customers",
+				Columns: []client.Column{{Name: "id", DataType: "integer", PrimaryKey: true}},
+			},
+			{
+				Name: "order_items",
+				Columns: []client.Column{
+					{Name: "order_id", DataType: "integer", PrimaryKey: true, ForeignKey: true},
+					{Name: "line", DataType: "integer", PrimaryKey: true},
+				},
+				ForeignKeys: []client.ForeignKey{
+					{Name: "order_items_order_fk", Table: "order_items", Columns: []string{"order_id"}, RefSchema: "public", RefTable: "orders", RefColumns: []string{"id"}},
+				},
+			},
+			{
+				Name: "orders",
+				Columns: []client.Column{
+					{Name: "id", DataType: "integer", PrimaryKey: true},
+					{Name: "customer_id", DataType: "integer", Nullable: true, ForeignKey: true},
+					{Name: "total", DataType: "numeric(10,2)"},
+				},
+				ForeignKeys: []client.ForeignKey{
+					{Name: "orders_customer_fk", Table: "orders", Columns: []string{"customer_id"}, RefSchema: "public", RefTable: "customers", RefColumns: []string{"id"}},
+				},
+			},
+		},
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	format, err := ParseFormat(" DOT ")
+	require.NoError(t, err)
+	require.Equal(t, DOT, format)
+	require.Equal(t, ".dot", format.Extension())
+
+	_, err = ParseFormat("svg")
+	require.Error(t, err)
+}
+
+func TestNew(t *testing.T) {
+	d, err := New(testSnapshot(), "orders", "order_items")
+	require.NoError(t, err)
+	require.Equal(t, "public", d.Name)
+	require.Len(t, d.Tables, 2)
+
+	// the foreign key to customers is dropped, since customers is not in the diagram.
+	require.Len(t, d.relationships(), 1)
+	require.Equal(t, "order_items_order_fk", d.relationships()[0].Name)
+
+	_, err = New(testSnapshot(), "orders", "missing")
+	require.EqualError(t, err, "tables not found in public: missing")
+}
+
+func TestNeighbors(t *testing.T) {
+	require.Equal(t, []string{"orders", "order_items", "customers"}, Neighbors(testSnapshot(), "orders"))
+	require.Equal(t, []string{"customers", "orders"}, Neighbors(testSnapshot(), "customers"))
+}
+
+func TestMermaid(t *testing.T) {
+	d, err := New(testSnapshot(), "customers", "orders")
+	require.NoError(t, err)
+
+	require.Equal(t, `erDiagram
+    customers {
+        integer id PK
+    }
+    orders {
+        integer id PK
+        integer customer_id FK
+        numeric(10_2) total
+    }
+    customers |o--o{ orders : "orders_customer_fk"
+`, d.Mermaid())
+}
+
+func TestDOT(t *testing.T) {
+	d, err := New(testSnapshot(), "orders", "order_items")
+	require.NoError(t, err)
+
+	require.Equal(t, `digraph "public" {
+    graph [rankdir=LR];
+    node [shape=plaintext, fontname="Helvetica"];
+    edge [fontname="Helvetica", fontsize=10];
+
+    "order_items" [label=<
+        <TABLE BORDER="0" CELLBORDER="1" CELLSPACING="0" CELLPADDING="4">
+            <TR><TD BGCOLOR="lightgrey"><B>order_items</B></TD></TR>
+            <TR><TD ALIGN="LEFT">order_id integer [PK, FK]</TD></TR>
+            <TR><TD ALIGN="LEFT">line integer [PK]</TD></TR>
+        </TABLE>
+    >];
+
+    "orders" [label=<
+        <TABLE BORDER="0" CELLBORDER="1" CELLSPACING="0" CELLPADDING="4">
+            <TR><TD BGCOLOR="lightgrey"><B>orders</B></TD></TR>
+            <TR><TD ALIGN="LEFT">id integer [PK]</TD></TR>
+            <TR><TD ALIGN="LEFT">customer_id integer [FK]</TD></TR>
+            <TR><TD ALIGN="LEFT">total numeric(10,2)</TD></TR>
+        </TABLE>
+    >];
+
+    "order_items" -> "orders" [label="order_id -> id"];
+}
+`, d.DOT())
+}
+
+func TestPlantUML(t *testing.T) {
+	d, err := New(testSnapshot(), "customers", "orders")
+	require.NoError(t, err)
+
+	require.Equal(t, `@startuml
+hide circle
+skinparam linetype ortho
+
+entity "customers" as customers {
+  * id : integer
+  --
+}
+
+entity "orders" as orders {
+  * id : integer
+  --
+  customer_id : integer <<FK>>
+  * total : numeric(10,2)
+}
+
+customers |o--o{ orders : orders_customer_fk
+@enduml
+`, d.PlantUML())
+}
+
+func TestASCII(t *testing.T) {
+	foreignKeys := []client.ForeignKey{
+		{Name: "order_items_order_fk", Table: "order_items", Columns: []string{"order_id"}, RefSchema: "public", RefTable: "orders", RefColumns: []string{"id"}},
+		{Name: "orders_customer_fk", Table: "orders", Columns: []string{"customer_id"}, RefSchema: "public", RefTable: "customers", RefColumns: []string{"id"}},
+		{Name: "orders_region_fk", Table: "orders", Columns: []string{"country", "region"}, RefSchema: "geo", RefTable: "regions", RefColumns: []string{"country", "code"}},
+	}
+
+	require.Equal(t, `orders
+├── references
+│   ├── customers (customer_id → id)
+│   └── geo.regions (country, region → country, code)
+└── referenced by
+    └── order_items (order_id → id)
+`, ASCII(client.TableRef{Schema: "public", Name: "orders"}, foreignKeys))
+
+	require.Equal(t, `order_items
+└── references
+    └── orders (order_id → id)
+`, ASCII(client.TableRef{Schema: "public", Name: "order_items"}, foreignKeys))
+
+	require.Equal(t, "lonely\n└── no relations\n", ASCII(client.TableRef{Name: "lonely"}, foreignKeys))
+}
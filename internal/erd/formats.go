@@ -0,0 +1,169 @@
+package erd
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// Mermaid method writes the diagram as a Mermaid erDiagram.
+// Mermaid names can't have spaces nor punctuation, so they are replaced by underscores.
+func (d Diagram) Mermaid() string {
+	var b strings.Builder
+
+	b.WriteString("erDiagram\n")
+	for _, table := range d.Tables {
+		fmt.Fprintf(&b, "    %s {\n", mermaidName(table.Name))
+		for _, column := range table.Columns {
+			line := mermaidType(column.DataType) + " " + mermaidName(column.Name)
+			if keys := columnKeys(column); len(keys) > 0 {
+				line += " " + strings.Join(keys, ", ")
+			}
+			fmt.Fprintf(&b, "        %s\n", line)
+		}
+		b.WriteString("    }\n")
+	}
+
+	for _, r := range d.relationships() {
+		parent := "||"
+		if r.optional {
+			parent = "|o"
+		}
+		fmt.Fprintf(&b, "    %s %s--o{ %s : %q\n", mermaidName(r.RefTable), parent, mermaidName(r.Table), r.Name)
+	}
+
+	return b.String()
+}
+
+// DOT method writes the diagram as a Graphviz digraph, with a node per table whose label lists its columns.
+// The edges go from the tables holding a foreign key to the tables they reference.
+func (d Diagram) DOT() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "digraph %q {\n", d.Name)
+	b.WriteString("    graph [rankdir=LR];\n")
+	b.WriteString("    node [shape=plaintext, fontname=\"Helvetica\"];\n")
+	b.WriteString("    edge [fontname=\"Helvetica\", fontsize=10];\n")
+
+	for _, table := range d.Tables {
+		fmt.Fprintf(&b, "\n    %q [label=<\n", table.Name)
+		b.WriteString("        <TABLE BORDER=\"0\" CELLBORDER=\"1\" CELLSPACING=\"0\" CELLPADDING=\"4\">\n")
+		fmt.Fprintf(&b, "            <TR><TD BGCOLOR=\"lightgrey\"><B>%s</B></TD></TR>\n", html.EscapeString(table.Name))
+		for _, column := range table.Columns {
+			text := column.Name + " " + column.DataType
+			if keys := columnKeys(column); len(keys) > 0 {
+				text += " [" + strings.Join(keys, ", ") + "]"
+			}
+			fmt.Fprintf(&b, "            <TR><TD ALIGN=\"LEFT\">%s</TD></TR>\n", html.EscapeString(text))
+		}
+		b.WriteString("        </TABLE>\n    >];\n")
+	}
+
+	if relationships := d.relationships(); len(relationships) > 0 {
+		b.WriteString("\n")
+		for _, r := range relationships {
+			style := ""
+			if r.optional {
+				style = ", style=dashed"
+			}
+			fmt.Fprintf(&b, "    %q -> %q [label=%q%s];\n", r.Table, r.RefTable, columnPairs(r.ForeignKey, "->"), style)
+		}
+	}
+
+	b.WriteString("}\n")
+
+	return b.String()
+}
+
+// PlantUML method writes the diagram with the entity-relationship syntax of PlantUML.
+// The primary key columns are listed first, the mandatory columns are marked with an asterisk.
+func (d Diagram) PlantUML() string {
+	var b strings.Builder
+
+	b.WriteString("@startuml\n")
+	b.WriteString("hide circle\n")
+	b.WriteString("skinparam linetype ortho\n")
+
+	for _, table := range d.Tables {
+		fmt.Fprintf(&b, "\nentity %q as %s {\n", table.Name, plantUMLName(table.Name))
+
+		var keys, others []string
+		for _, column := range table.Columns {
+			line := column.Name + " : " + column.DataType
+			if !column.Nullable {
+				line = "* " + line
+			}
+			if column.ForeignKey {
+				line += " <<FK>>"
+			}
+
+			if column.PrimaryKey {
+				keys = append(keys, line)
+			} else {
+				others = append(others, line)
+			}
+		}
+
+		for _, line := range keys {
+			fmt.Fprintf(&b, "  %s\n", line)
+		}
+		b.WriteString("  --\n")
+		for _, line := range others {
+			fmt.Fprintf(&b, "  %s\n", line)
+		}
+		b.WriteString("}\n")
+	}
+
+	if relationships := d.relationships(); len(relationships) > 0 {
+		b.WriteString("\n")
+		for _, r := range relationships {
+			parent := "||"
+			if r.optional {
+				parent = "|o"
+			}
+			fmt.Fprintf(&b, "%s %s--o{ %s : %s\n", plantUMLName(r.RefTable), parent, plantUMLName(r.Table), r.Name)
+		}
+	}
+
+	b.WriteString("@enduml\n")
+
+	return b.String()
+}
+
+// mermaidName function replaces the characters Mermaid doesn't allow in the names of entities and attributes.
+func mermaidName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if isNameRune(r) || r == '-' {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// mermaidType function turns a data type into a single word, e.g. "character varying(255)" into "character_varying(255)".
+func mermaidType(dataType string) string {
+	if dataType == "" {
+		return "unknown"
+	}
+
+	return strings.Map(func(r rune) rune {
+		if isNameRune(r) || r == '(' || r == ')' || r == '[' || r == ']' || r == '-' {
+			return r
+		}
+		return '_'
+	}, dataType)
+}
+
+// plantUMLName function returns the alias of an entity, the name of the table with its punctuation replaced.
+func plantUMLName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if isNameRune(r) {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+func isNameRune(r rune) bool {
+	return r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+}
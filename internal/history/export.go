@@ -0,0 +1,116 @@
+package history
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Supported export formats.
+const (
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+	FormatSQL  = "sql"
+)
+
+// ErrUnsupportedFormat is returned when the export format is not one of json, csv or sql.
+var ErrUnsupportedFormat = errors.New("unsupported export format - valid formats: json, csv, sql")
+
+// exportRecord is the representation of a query used by the json export.
+type exportRecord struct {
+	ID         int       `json:"id"`
+	Query      string    `json:"query"`
+	Timestamp  time.Time `json:"timestamp"`
+	Success    bool      `json:"success"`
+	RowCount   int       `json:"row_count"`
+	DurationMS int64     `json:"duration_ms"`
+	Profile    string    `json:"profile,omitempty"`
+}
+
+// Export function writes the given entries to w in the requested format.
+func Export(w io.Writer, entries []Entry, format string) error {
+	switch strings.ToLower(format) {
+	case FormatJSON:
+		return exportJSON(w, entries)
+	case FormatCSV:
+		return exportCSV(w, entries)
+	case FormatSQL:
+		return exportSQL(w, entries)
+	default:
+		return fmt.Errorf("%s: %w", format, ErrUnsupportedFormat)
+	}
+}
+
+func exportJSON(w io.Writer, entries []Entry) error {
+	records := make([]exportRecord, 0, len(entries))
+	for _, e := range entries {
+		records = append(records, exportRecord{
+			ID:         e.ID,
+			Query:      e.QueryText,
+			Timestamp:  e.Timestamp,
+			Success:    e.Success,
+			RowCount:   e.RowCount,
+			DurationMS: e.Duration.Milliseconds(),
+			Profile:    e.Profile,
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(records)
+}
+
+func exportCSV(w io.Writer, entries []Entry) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write([]string{"id", "query", "timestamp", "success", "row_count", "duration_ms", "profile"}); err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		record := []string{
+			strconv.Itoa(e.ID),
+			e.QueryText,
+			e.Timestamp.Format(time.RFC3339),
+			strconv.FormatBool(e.Success),
+			strconv.Itoa(e.RowCount),
+			strconv.FormatInt(e.Duration.Milliseconds(), 10),
+			e.Profile,
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// exportSQL writes a script with one statement per query,
+// preceded by a comment with the metadata of the execution.
+func exportSQL(w io.Writer, entries []Entry) error {
+	for _, e := range entries {
+		status := "ok"
+		if !e.Success {
+			status = "failed"
+		}
+
+		header := fmt.Sprintf("-- #%d %s %s %s", e.ID, e.Timestamp.Format(time.RFC3339), e.Duration, status)
+		if e.Profile != "" {
+			header += " profile=" + e.Profile
+		}
+
+		query := strings.TrimRight(strings.TrimSpace(e.QueryText), ";")
+		if _, err := fmt.Fprintf(w, "%s\n%s;\n\n", header, query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package history
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExport(t *testing.T) {
+	now := time.Date(2024, time.March, 10, 12, 0, 0, 0, time.UTC)
+	entries := Find(sampleHistory(now), Filter{})
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := Export(&buf, entries, FormatJSON)
+		require.NoError(t, err)
+
+		var records []exportRecord
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &records))
+		require.Len(t, records, 3)
+		require.Equal(t, 3, records[0].ID)
+		require.Equal(t, "select name from products", records[0].Query)
+		require.Equal(t, int64(500), records[0].DurationMS)
+		require.Equal(t, "prod", records[0].Profile)
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := Export(&buf, entries, FormatCSV)
+		require.NoError(t, err)
+
+		records, err := csv.NewReader(&buf).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 4)
+		require.Equal(t, "query", records[0][1])
+		require.Equal(t, "DELETE FROM users WHERE id = 1", records[2][1])
+		require.Equal(t, "false", records[2][3])
+	})
+
+	t.Run("sql", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := Export(&buf, entries[:1], FormatSQL)
+		require.NoError(t, err)
+
+		require.Equal(
+			t,
+			"-- #3 2024-03-10T11:00:00Z 500ms ok profile=prod\nselect name from products;\n\n",
+			buf.String(),
+		)
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := Export(&buf, entries, "xml")
+		require.ErrorIs(t, err, ErrUnsupportedFormat)
+	})
+}
@@ -0,0 +1,195 @@
+package history
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidAge is returned when an age or a date given by the user can't be parsed.
+var ErrInvalidAge = errors.New("invalid age - valid formats: 90m, 12h, 7d, 2w, 2006-01-02 or RFC3339")
+
+// Entry pairs a query from the history with its ID.
+// The ID is the 1-based position of the query in the history file,
+// so it can be used to reference the query from the command line.
+type Entry struct {
+	ID int
+	QueryHistory
+}
+
+// Filter struct holds the criteria used to narrow down the query history.
+// Zero values are ignored.
+type Filter struct {
+	// Since keeps the queries executed at or after the given time.
+	Since time.Time
+	// Profile keeps the queries executed against the given connection profile.
+	Profile string
+	// FailedOnly keeps the queries that returned an error.
+	FailedOnly bool
+	// MinDuration keeps the queries that took at least the given duration.
+	MinDuration time.Duration
+	// Search keeps the queries that contain every word of the search text, ignoring the case.
+	Search string
+	// Limit caps the number of entries returned, the most recent ones win.
+	Limit int
+}
+
+// Find function returns the entries of the history that match the given filter,
+// sorted in descending order based on the timestamp.
+func Find(history []QueryHistory, f Filter) []Entry {
+	terms := strings.Fields(strings.ToLower(f.Search))
+
+	entries := make([]Entry, 0, len(history))
+	for i, q := range history {
+		if !f.Since.IsZero() && q.Timestamp.Before(f.Since) {
+			continue
+		}
+
+		if f.Profile != "" && q.Profile != f.Profile {
+			continue
+		}
+
+		if f.FailedOnly && q.Success {
+			continue
+		}
+
+		if q.Duration < f.MinDuration {
+			continue
+		}
+
+		if !containsAll(q.QueryText, terms) {
+			continue
+		}
+
+		entries = append(entries, Entry{ID: i + 1, QueryHistory: q})
+	}
+
+	// The history file is written in chronological order,
+	// so reversing it shows the most recent queries first.
+	slices.Reverse(entries)
+
+	if f.Limit > 0 && len(entries) > f.Limit {
+		entries = entries[:f.Limit]
+	}
+
+	return entries
+}
+
+// containsAll checks if every term is found in the query text, ignoring the case.
+func containsAll(query string, terms []string) bool {
+	query = strings.ToLower(query)
+	for _, t := range terms {
+		if !strings.Contains(query, t) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// DeleteHistory function removes the queries with the given IDs from the dblab.gob file.
+// It returns the number of queries removed.
+func DeleteHistory(baseDir string, ids ...int) (int, error) {
+	history, err := ReadHistory(baseDir)
+	if err != nil {
+		return 0, err
+	}
+
+	toDelete := make(map[int]struct{}, len(ids))
+	for _, id := range ids {
+		if id < 1 || id > len(history) {
+			return 0, fmt.Errorf("query #%d not found in the history", id)
+		}
+		toDelete[id] = struct{}{}
+	}
+
+	kept := make([]QueryHistory, 0, len(history))
+	for i, q := range history {
+		if _, ok := toDelete[i+1]; ok {
+			continue
+		}
+		kept = append(kept, q)
+	}
+
+	if err := ReplaceHistory(baseDir, kept); err != nil {
+		return 0, err
+	}
+
+	return len(history) - len(kept), nil
+}
+
+// ClearHistory function removes the queries executed before the given time from the dblab.gob file.
+// If the time is zero, the whole history is removed.
+// It returns the number of queries removed.
+func ClearHistory(baseDir string, olderThan time.Time) (int, error) {
+	history, err := ReadHistory(baseDir)
+	if err != nil {
+		return 0, err
+	}
+
+	kept := make([]QueryHistory, 0, len(history))
+	if !olderThan.IsZero() {
+		for _, q := range history {
+			if q.Timestamp.Before(olderThan) {
+				continue
+			}
+			kept = append(kept, q)
+		}
+	}
+
+	if err := ReplaceHistory(baseDir, kept); err != nil {
+		return 0, err
+	}
+
+	return len(history) - len(kept), nil
+}
+
+// ParseAge function parses an age such as 7d or 2w.
+// On top of the units supported by time.ParseDuration, it accepts d for days and w for weeks.
+func ParseAge(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, ErrInvalidAge
+	}
+
+	var unit time.Duration
+	switch {
+	case strings.HasSuffix(s, "d"):
+		unit = 24 * time.Hour
+	case strings.HasSuffix(s, "w"):
+		unit = 7 * 24 * time.Hour
+	default:
+		d, err := time.ParseDuration(s)
+		if err != nil || d < 0 {
+			return 0, fmt.Errorf("%s: %w", s, ErrInvalidAge)
+		}
+		return d, nil
+	}
+
+	n, err := strconv.Atoi(s[:len(s)-1])
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("%s: %w", s, ErrInvalidAge)
+	}
+
+	return time.Duration(n) * unit, nil
+}
+
+// ParseSince function turns either an age (see ParseAge) or a date into a point in time.
+// Ages are subtracted from now, so 7d means seven days before now.
+func ParseSince(s string, now time.Time) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if t, err := time.ParseInLocation(layout, s, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+
+	age, err := ParseAge(s)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return now.Add(-age), nil
+}
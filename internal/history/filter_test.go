@@ -0,0 +1,192 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func sampleHistory(now time.Time) []QueryHistory {
+	return []QueryHistory{
+		{
+			QueryText: "SELECT * FROM users",
+			Timestamp: now.Add(-72 * time.Hour),
+			Success:   true,
+			Duration:  10 * time.Millisecond,
+			Profile:   "prod",
+		},
+		{
+			QueryText: "DELETE FROM users WHERE id = 1",
+			Timestamp: now.Add(-48 * time.Hour),
+			Success:   false,
+			Duration:  2 * time.Second,
+			Profile:   "staging",
+		},
+		{
+			QueryText: "select name from products",
+			Timestamp: now.Add(-time.Hour),
+			Success:   true,
+			Duration:  500 * time.Millisecond,
+			Profile:   "prod",
+		},
+	}
+}
+
+func TestFind(t *testing.T) {
+	now := time.Now()
+	queries := sampleHistory(now)
+
+	var tests = []struct {
+		name    string
+		filter  Filter
+		wantIDs []int
+	}{
+		{
+			name:    "No filter returns everything newest first",
+			filter:  Filter{},
+			wantIDs: []int{3, 2, 1},
+		},
+		{
+			name:    "Since",
+			filter:  Filter{Since: now.Add(-50 * time.Hour)},
+			wantIDs: []int{3, 2},
+		},
+		{
+			name:    "Profile",
+			filter:  Filter{Profile: "prod"},
+			wantIDs: []int{3, 1},
+		},
+		{
+			name:    "Failed only",
+			filter:  Filter{FailedOnly: true},
+			wantIDs: []int{2},
+		},
+		{
+			name:    "Min duration",
+			filter:  Filter{MinDuration: 100 * time.Millisecond},
+			wantIDs: []int{3, 2},
+		},
+		{
+			name:    "Search is case insensitive and requires every word",
+			filter:  Filter{Search: "FROM users"},
+			wantIDs: []int{2, 1},
+		},
+		{
+			name:    "Limit keeps the most recent",
+			filter:  Filter{Limit: 1},
+			wantIDs: []int{3},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			entries := Find(queries, test.filter)
+
+			ids := make([]int, 0, len(entries))
+			for _, e := range entries {
+				ids = append(ids, e.ID)
+			}
+
+			require.Equal(t, test.wantIDs, ids)
+		})
+	}
+}
+
+func TestDeleteHistory(t *testing.T) {
+	sandboxDir := t.TempDir()
+	now := time.Now().Truncate(time.Second)
+
+	err := SaveHistory(sandboxDir, sampleHistory(now)...)
+	require.NoError(t, err)
+
+	deleted, err := DeleteHistory(sandboxDir, 1, 3)
+	require.NoError(t, err)
+	require.Equal(t, 2, deleted)
+
+	history, err := ReadHistory(sandboxDir)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	require.Equal(t, "DELETE FROM users WHERE id = 1", history[0].QueryText)
+
+	_, err = DeleteHistory(sandboxDir, 5)
+	require.Error(t, err)
+}
+
+func TestClearHistory(t *testing.T) {
+	t.Run("Clear entries older than a given time", func(t *testing.T) {
+		sandboxDir := t.TempDir()
+		now := time.Now().Truncate(time.Second)
+
+		err := SaveHistory(sandboxDir, sampleHistory(now)...)
+		require.NoError(t, err)
+
+		cleared, err := ClearHistory(sandboxDir, now.Add(-24*time.Hour))
+		require.NoError(t, err)
+		require.Equal(t, 2, cleared)
+
+		history, err := ReadHistory(sandboxDir)
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+	})
+
+	t.Run("Clear everything", func(t *testing.T) {
+		sandboxDir := t.TempDir()
+
+		err := SaveHistory(sandboxDir, sampleHistory(time.Now())...)
+		require.NoError(t, err)
+
+		cleared, err := ClearHistory(sandboxDir, time.Time{})
+		require.NoError(t, err)
+		require.Equal(t, 3, cleared)
+
+		history, err := ReadHistory(sandboxDir)
+		require.NoError(t, err)
+		require.Empty(t, history)
+	})
+}
+
+func TestParseAge(t *testing.T) {
+	var tests = []struct {
+		input     string
+		want      time.Duration
+		expectErr bool
+	}{
+		{input: "90m", want: 90 * time.Minute},
+		{input: "12h", want: 12 * time.Hour},
+		{input: "7d", want: 7 * 24 * time.Hour},
+		{input: "2w", want: 14 * 24 * time.Hour},
+		{input: "", expectErr: true},
+		{input: "xd", expectErr: true},
+		{input: "-1h", expectErr: true},
+		{input: "yesterday", expectErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			got, err := ParseAge(test.input)
+			if test.expectErr {
+				require.ErrorIs(t, err, ErrInvalidAge)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2024, time.March, 10, 12, 0, 0, 0, time.UTC)
+
+	got, err := ParseSince("2024-03-01", now)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC), got)
+
+	got, err = ParseSince("1d", now)
+	require.NoError(t, err)
+	require.Equal(t, now.Add(-24*time.Hour), got)
+
+	_, err = ParseSince("last week", now)
+	require.Error(t, err)
+}
@@ -24,6 +24,8 @@ type QueryHistory struct {
 	Success   bool
 	RowCount  int
 	Duration  time.Duration
+	// Profile is the name of the connection profile the query was executed against, if any.
+	Profile string
 }
 
 // Title returns the query text.
@@ -91,6 +93,18 @@ func SaveHistory(baseDir string, newQuery ...QueryHistory) error {
 	return saveHistory(fullPath, history)
 }
 
+// ReplaceHistory function overwrites the dblab.gob file with the given queries.
+// It's used by the operations that remove entries from the history, such as delete and clear.
+func ReplaceHistory(baseDir string, history []QueryHistory) error {
+	fullPath := filepath.Join(baseDir, "dblab", "dblab.gob")
+	dirOnly := filepath.Dir(fullPath)
+	if err := os.MkdirAll(dirOnly, 0755); err != nil {
+		return fmt.Errorf("error at creating the dblab app-specific subdirectory, if it does not exist: %w", err)
+	}
+
+	return saveHistory(fullPath, history)
+}
+
 // saveHistory method does the heavy lifting by opening the config file or creating it if it does not exist.
 func saveHistory(filename string, history []QueryHistory) error {
 	file, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0666)
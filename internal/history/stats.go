@@ -0,0 +1,90 @@
+package history
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// QueryStats struct sums up the executions of the same query.
+type QueryStats struct {
+	Query       string
+	Count       int
+	Failures    int
+	AvgDuration time.Duration
+	MaxDuration time.Duration
+}
+
+// Report struct is the statistics report of the query history.
+type Report struct {
+	Total         int
+	Failed        int
+	TotalDuration time.Duration
+	// MostFrequent lists the queries executed the most, in descending order.
+	MostFrequent []QueryStats
+	// Slowest lists the slowest executions, in descending order.
+	Slowest []Entry
+}
+
+// Stats function builds a report with the most frequent and the slowest queries.
+// top caps the length of both lists.
+// Queries are grouped by their text, ignoring differences in whitespace and the trailing semicolon.
+func Stats(entries []Entry, top int) Report {
+	var r Report
+
+	byQuery := make(map[string]*QueryStats)
+	// order keeps the first time a query was seen, so ties are broken in a stable way.
+	var order []string
+
+	for _, e := range entries {
+		r.Total++
+		r.TotalDuration += e.Duration
+		if !e.Success {
+			r.Failed++
+		}
+
+		key := normalizeQuery(e.QueryText)
+		qs, ok := byQuery[key]
+		if !ok {
+			qs = &QueryStats{Query: key}
+			byQuery[key] = qs
+			order = append(order, key)
+		}
+
+		qs.Count++
+		qs.AvgDuration += e.Duration
+		qs.MaxDuration = max(qs.MaxDuration, e.Duration)
+		if !e.Success {
+			qs.Failures++
+		}
+	}
+
+	for _, key := range order {
+		qs := byQuery[key]
+		qs.AvgDuration /= time.Duration(qs.Count)
+		r.MostFrequent = append(r.MostFrequent, *qs)
+	}
+
+	slices.SortStableFunc(r.MostFrequent, func(a, b QueryStats) int {
+		return cmp.Compare(b.Count, a.Count)
+	})
+
+	r.Slowest = slices.Clone(entries)
+	slices.SortStableFunc(r.Slowest, func(a, b Entry) int {
+		return cmp.Compare(b.Duration, a.Duration)
+	})
+
+	if top > 0 {
+		r.MostFrequent = r.MostFrequent[:min(top, len(r.MostFrequent))]
+		r.Slowest = r.Slowest[:min(top, len(r.Slowest))]
+	}
+
+	return r
+}
+
+// normalizeQuery collapses the whitespace of a query and removes the trailing semicolon.
+func normalizeQuery(q string) string {
+	q = strings.Join(strings.Fields(q), " ")
+	return strings.TrimRight(q, "; ")
+}
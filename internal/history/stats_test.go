@@ -0,0 +1,37 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStats(t *testing.T) {
+	now := time.Now()
+
+	queries := append(sampleHistory(now),
+		QueryHistory{
+			QueryText: "SELECT *   FROM users;",
+			Timestamp: now,
+			Success:   true,
+			Duration:  30 * time.Millisecond,
+		},
+	)
+
+	report := Stats(Find(queries, Filter{}), 2)
+
+	require.Equal(t, 4, report.Total)
+	require.Equal(t, 1, report.Failed)
+	require.Equal(t, 2540*time.Millisecond, report.TotalDuration)
+
+	require.Len(t, report.MostFrequent, 2)
+	require.Equal(t, "SELECT * FROM users", report.MostFrequent[0].Query)
+	require.Equal(t, 2, report.MostFrequent[0].Count)
+	require.Equal(t, 20*time.Millisecond, report.MostFrequent[0].AvgDuration)
+	require.Equal(t, 30*time.Millisecond, report.MostFrequent[0].MaxDuration)
+
+	require.Len(t, report.Slowest, 2)
+	require.Equal(t, 2, report.Slowest[0].ID)
+	require.Equal(t, 3, report.Slowest[1].ID)
+}
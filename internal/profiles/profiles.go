@@ -7,6 +7,7 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"slices"
 
 	"github.com/danvergara/dblab/pkg/command"
 
@@ -130,6 +131,16 @@ func SaveProfile(baseDir, name string, profile command.Options) error {
 		}
 	}
 
+	// Save the passwords of the ssh jumps, if any, by their position in the chain and their host,
+	// since the user of a jump may be left to the ssh config.
+	for i, jump := range profile.SSHJumps {
+		if jump.Pass != "" {
+			if err := keyring.Set(sshJumpService(name, i), jump.Host, jump.Pass); err != nil {
+				return err
+			}
+		}
+	}
+
 	// Save the profile in the config file. It ignores the password contained in the profile object, which is an instance of command.Option.
 	if err := addProfileToConfig(fullPath, name, profile); err != nil {
 		return err
@@ -138,6 +149,76 @@ func SaveProfile(baseDir, name string, profile command.Options) error {
 	return nil
 }
 
+// LoadProfile function returns the profile with the given name, along with the passwords stored in the OS keyring,
+// ready to connect to the database.
+// It returns ErrProfileNotFound if there's no profile with that name.
+func LoadProfile(baseDir, name string) (command.Options, error) {
+	profiles, err := ReadProfiles(baseDir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return command.Options{}, ErrProfileNotFound
+		}
+		return command.Options{}, err
+	}
+
+	profile, ok := profiles[name]
+	if !ok {
+		return command.Options{}, ErrProfileNotFound
+	}
+
+	// Get the password from the OS keyring.
+	pass, err := keyring.Get(name, profile.User)
+	if err != nil {
+		return command.Options{}, err
+	}
+
+	profile.Pass = pass
+	profile.Profile = name
+
+	// Get the ssh password, if any.
+	if profile.SSHUser != "" {
+		sshPass, err := keyring.Get(name+"-ssh", profile.SSHUser)
+		if err != nil {
+			if !errors.Is(err, keyring.ErrNotFound) {
+				return command.Options{}, err
+			}
+		} else {
+			profile.SSHPass = sshPass
+		}
+	}
+
+	profile.SSHJumps, err = SSHJumpPasswords(name, profile.SSHJumps)
+	if err != nil {
+		return command.Options{}, err
+	}
+
+	return profile, nil
+}
+
+// SSHJumpPasswords function returns the ssh jumps of a profile along with their passwords stored in the OS keyring, if any.
+func SSHJumpPasswords(name string, jumps []command.SSHHop) ([]command.SSHHop, error) {
+	jumps = slices.Clone(jumps)
+
+	for i, jump := range jumps {
+		pass, err := keyring.Get(sshJumpService(name, i), jump.Host)
+		if err != nil {
+			if !errors.Is(err, keyring.ErrNotFound) {
+				return nil, err
+			}
+			continue
+		}
+
+		jumps[i].Pass = pass
+	}
+
+	return jumps, nil
+}
+
+// sshJumpService function returns the name the password of an ssh jump of a profile is stored under in the OS keyring.
+func sshJumpService(name string, i int) string {
+	return fmt.Sprintf("%s-ssh-jump-%d", name, i)
+}
+
 // DeleteProfile function deletes a profiles from the official config file, if it exists.
 // Then, removes the password from the OS keyring system.
 // Finally, saves updated profiles set in the dblab's config file, without the given profile.
@@ -181,6 +262,15 @@ func DeleteProfile(baseDir, name string) error {
 				}
 			}
 
+			// Delete the passwords of the ssh jumps, if any.
+			for i, jump := range profile.SSHJumps {
+				if err := keyring.Delete(sshJumpService(name, i), jump.Host); err != nil {
+					if !errors.Is(err, keyring.ErrNotFound) {
+						return err
+					}
+				}
+			}
+
 			// delete the profile from the profiles map.
 			delete(cfg.Profiles, name)
 
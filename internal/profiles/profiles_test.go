@@ -127,3 +127,71 @@ func TestDeleteProfile(t *testing.T) {
 	err = DeleteProfile(sandboxDir, "not-existing")
 	require.Error(t, err)
 }
+
+func TestLoadProfile(t *testing.T) {
+	keyring.MockInit()
+	sandboxDir := t.TempDir()
+
+	_, err := LoadProfile(sandboxDir, "staging")
+	require.ErrorIs(t, err, ErrProfileNotFound)
+
+	profile := command.Options{
+		Driver:  "postgres",
+		Host:    "localhost",
+		Port:    "5432",
+		User:    "postgres",
+		Pass:    "secret",
+		DBName:  "shop",
+		SSHHost: "bastion",
+		SSHUser: "deploy",
+		SSHPass: "ssh-secret",
+	}
+	require.NoError(t, SaveProfile(sandboxDir, "staging", profile))
+
+	loaded, err := LoadProfile(sandboxDir, "staging")
+	require.NoError(t, err)
+	require.Equal(t, "secret", loaded.Pass)
+	require.Equal(t, "ssh-secret", loaded.SSHPass)
+	require.Equal(t, "staging", loaded.Profile)
+	require.Equal(t, "shop", loaded.DBName)
+
+	_, err = LoadProfile(sandboxDir, "prod")
+	require.ErrorIs(t, err, ErrProfileNotFound)
+}
+
+func TestSSHJumpPasswords(t *testing.T) {
+	keyring.MockInit()
+	sandboxDir := t.TempDir()
+
+	profile := command.Options{
+		Driver:  "postgres",
+		Host:    "db.internal",
+		Port:    "5432",
+		User:    "postgres",
+		Pass:    "secret",
+		DBName:  "shop",
+		SSHHost: "bastion-db",
+		SSHJumps: []command.SSHHop{
+			{Host: "bastion-edge", Port: "2222", User: "edge", Pass: "edge-secret"},
+			{Host: "bastion-core", User: "core", KeyFile: "~/.ssh/core.pem"},
+			// the user is left to the ssh config.
+			{Host: "bastion-db-jump", Pass: "jump-secret"},
+		},
+	}
+	require.NoError(t, SaveProfile(sandboxDir, "two-bastions", profile))
+
+	profiles, err := ReadProfiles(sandboxDir)
+	require.NoError(t, err)
+	require.Empty(t, profiles["two-bastions"].SSHJumps[0].Pass)
+
+	loaded, err := LoadProfile(sandboxDir, "two-bastions")
+	require.NoError(t, err)
+	require.Equal(t, profile.SSHJumps, loaded.SSHJumps)
+
+	require.NoError(t, DeleteProfile(sandboxDir, "two-bastions"))
+
+	_, err = keyring.Get(sshJumpService("two-bastions", 0), "bastion-edge")
+	require.ErrorIs(t, err, keyring.ErrNotFound)
+	_, err = keyring.Get(sshJumpService("two-bastions", 2), "bastion-db-jump")
+	require.ErrorIs(t, err, keyring.ErrNotFound)
+}
@@ -0,0 +1,689 @@
+package schemadiff
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/danvergara/dblab/pkg/client"
+	"github.com/danvergara/dblab/pkg/drivers"
+	_ "github.com/danvergara/dblab/pkg/drivers/dialects"
+)
+
+// the phases of the migration script, in the order they run: an object is dropped before the ones it depends on,
+// and created after them, e.g. the views are dropped first and created last, the foreign keys added once every table exists.
+const (
+	phaseDropViews = iota
+	phaseDropForeignKeys
+	phaseDropConstraints
+	phaseDropIndexes
+	phaseDropColumns
+	phaseDropTables
+	phaseCreateTables
+	phaseAlterColumns
+	phaseAddConstraints
+	phaseCreateIndexes
+	phaseAddForeignKeys
+	phaseCreateViews
+	phaseCount
+)
+
+// script holds the statements of a migration script by phase, in groups written apart from each other.
+type script [phaseCount][][]string
+
+func (s *script) add(phase int, statements ...string) {
+	if len(statements) > 0 {
+		s[phase] = append(s[phase], statements)
+	}
+}
+
+// Migration method returns a script with the statements that bring the target in line with the source,
+// written in the SQL dialect of the target, with the names quoted as the target quotes them.
+// The statements run in phases, so none of them needs an object dropped before or not created yet.
+// The changes the database can't make in place, like altering a SQLite column, are left as comments.
+// It fails on the objects it can't write a statement for, e.g. a type the target has no equivalent for
+// or a constraint whose definition is not read from the catalog, rather than writing a script that doesn't apply.
+func (r Report) Migration() (string, error) {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "-- Migration script for %s, generated by dblab.\n", r.To.Driver)
+	fmt.Fprintln(&b, "-- Review it before running it, the extra objects of the target are dropped.")
+
+	if len(r.Differences) == 0 {
+		fmt.Fprintln(&b, "-- No differences found.")
+		return b.String(), nil
+	}
+
+	var (
+		s       script
+		altered = make(map[string]bool)
+		errs    []error
+	)
+
+	for _, d := range r.Differences {
+		var err error
+
+		switch d.Object {
+		case ObjectTable:
+			err = r.tableStatements(&s, d)
+		case ObjectColumn:
+			// the type and the nullability of a column are changed by a single statement on some databases.
+			if d.Kind == Changed && !r.alterColumnPerChange() {
+				if altered[d.QualifiedName()] {
+					continue
+				}
+				altered[d.QualifiedName()] = true
+			}
+			err = r.columnStatements(&s, d)
+		case ObjectIndex:
+			err = r.indexStatements(&s, d)
+		case ObjectConstraint:
+			err = r.constraintStatements(&s, d)
+		case ObjectView:
+			r.viewStatements(&s, d)
+		}
+
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	r.rebuildViews(&s)
+
+	if err := errors.Join(errs...); err != nil {
+		return "", err
+	}
+
+	for _, groups := range s {
+		for _, group := range groups {
+			b.WriteString("\n")
+			for _, statement := range group {
+				b.WriteString(statement)
+				b.WriteString("\n")
+			}
+		}
+	}
+
+	return b.String(), nil
+}
+
+// tableStatements method writes the statements creating a missing table along with its indexes, constraints
+// and foreign keys, which are not reported on their own, or dropping an extra table after its foreign keys,
+// since another extra table may reference it.
+func (r Report) tableStatements(s *script, d Difference) error {
+	if d.Kind == Extra {
+		if table, ok := tableOf(r.To, d.Name); ok && r.To.Driver != drivers.SQLite {
+			for _, fk := range table.ForeignKeys {
+				s.add(phaseDropForeignKeys, r.dropConstraint(d.Name, fk.Name, "FOREIGN KEY"))
+			}
+		}
+
+		s.add(phaseDropTables, fmt.Sprintf("DROP TABLE %s;", r.tableName(d.Name)))
+		return nil
+	}
+
+	table, ok := r.sourceTable(d.Name)
+	if !ok {
+		return nil
+	}
+
+	var (
+		lines      []string
+		primaryKey []string
+	)
+	for _, column := range table.Columns {
+		definition, err := r.columnDefinition(table.Name, column)
+		if err != nil {
+			return err
+		}
+
+		lines = append(lines, "  "+definition)
+		if column.PrimaryKey {
+			primaryKey = append(primaryKey, r.quote(column.Name))
+		}
+	}
+
+	// the tables of ClickHouse take an engine, whose sorting key is the primary key.
+	if r.To.Driver == drivers.ClickHouse {
+		orderBy := "tuple()"
+		if len(primaryKey) > 0 {
+			orderBy = fmt.Sprintf("(%s)", strings.Join(primaryKey, ", "))
+		}
+
+		s.add(phaseCreateTables, fmt.Sprintf("CREATE TABLE %s (\n%s\n) ENGINE = MergeTree ORDER BY %s;", r.tableName(d.Name), strings.Join(lines, ",\n"), orderBy))
+	} else {
+		if len(primaryKey) > 0 {
+			lines = append(lines, fmt.Sprintf("  PRIMARY KEY (%s)", strings.Join(primaryKey, ", ")))
+		}
+
+		// SQLite can't add constraints to a table, they're written along with its columns.
+		var constraints []string
+		for _, constraint := range table.Constraints {
+			if constraint.Type == "PRIMARY KEY" || constraint.Type == "FOREIGN KEY" {
+				continue
+			}
+
+			if r.To.Driver == drivers.SQLite {
+				definition, err := r.constraintDefinition(table, constraint)
+				if err != nil {
+					return err
+				}
+
+				lines = append(lines, fmt.Sprintf("  CONSTRAINT %s %s", r.quote(constraint.Name), definition))
+				continue
+			}
+
+			add, err := r.addConstraint(table, constraint)
+			if err != nil {
+				return err
+			}
+
+			constraints = append(constraints, add)
+		}
+
+		var foreignKeys []string
+		for _, fk := range table.ForeignKeys {
+			if r.To.Driver == drivers.SQLite {
+				lines = append(lines, "  "+r.foreignKeyDefinition(fk))
+				continue
+			}
+
+			add, err := r.addForeignKey(table.Name, fk)
+			if err != nil {
+				return err
+			}
+
+			foreignKeys = append(foreignKeys, add)
+		}
+
+		s.add(phaseCreateTables, fmt.Sprintf("CREATE TABLE %s (\n%s\n);", r.tableName(d.Name), strings.Join(lines, ",\n")))
+		s.add(phaseAddConstraints, constraints...)
+		s.add(phaseAddForeignKeys, foreignKeys...)
+	}
+
+	var indexes []string
+	for _, index := range table.Indexes {
+		if isConstraintIndex(table, index.Name) {
+			continue
+		}
+
+		create, err := r.createIndex(table, index)
+		if err != nil {
+			return err
+		}
+
+		indexes = append(indexes, create)
+	}
+	s.add(phaseCreateIndexes, indexes...)
+
+	return nil
+}
+
+func (r Report) columnStatements(s *script, d Difference) error {
+	table := r.tableName(d.Table)
+
+	if d.Kind == Extra {
+		s.add(phaseDropColumns, fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s;", table, r.quote(d.Name)))
+		return nil
+	}
+
+	column, ok := r.sourceColumn(d.Table, d.Name)
+	if !ok {
+		return nil
+	}
+
+	definition, err := r.columnDefinition(d.Table, column)
+	if err != nil {
+		return err
+	}
+
+	if d.Kind == Missing {
+		switch r.To.Driver {
+		case drivers.Oracle:
+			s.add(phaseAlterColumns, fmt.Sprintf("ALTER TABLE %s ADD (%s);", table, definition))
+		case drivers.SQLServer:
+			s.add(phaseAlterColumns, fmt.Sprintf("ALTER TABLE %s ADD %s;", table, definition))
+		default:
+			s.add(phaseAlterColumns, fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s;", table, definition))
+		}
+		return nil
+	}
+
+	dataType, err := r.columnType(d.Table, column)
+	if err != nil {
+		return err
+	}
+
+	typeChanged := strings.HasPrefix(d.Detail, "type:")
+	name := r.quote(column.Name)
+
+	var statement string
+	switch r.To.Driver {
+	case drivers.MySQL, drivers.MariaDB, drivers.ClickHouse:
+		statement = fmt.Sprintf("ALTER TABLE %s MODIFY COLUMN %s;", table, definition)
+	case drivers.SQLServer:
+		statement = fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s %s %s;", table, name, dataType, nullability(column))
+	case drivers.Oracle:
+		if typeChanged {
+			statement = fmt.Sprintf("ALTER TABLE %s MODIFY (%s %s);", table, name, dataType)
+		} else {
+			statement = fmt.Sprintf("ALTER TABLE %s MODIFY (%s %s);", table, name, nullability(column))
+		}
+	case drivers.SQLite:
+		statement = fmt.Sprintf("-- SQLite can't alter the column %s of %s (%s), the table has to be rebuilt.", name, table, d.Detail)
+	default:
+		switch {
+		case typeChanged:
+			statement = fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE %s;", table, name, dataType)
+		case column.Nullable:
+			statement = fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s DROP NOT NULL;", table, name)
+		default:
+			statement = fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s SET NOT NULL;", table, name)
+		}
+	}
+
+	s.add(phaseAlterColumns, statement)
+	return nil
+}
+
+func (r Report) indexStatements(s *script, d Difference) error {
+	table := r.tableName(d.Table)
+	name := r.quote(d.Name)
+
+	// the indexes backing a primary key or a unique constraint come and go with the constraint,
+	// as well as the ones SQLite builds for them.
+	snapshot, phase := r.From, phaseCreateIndexes
+	if d.Kind == Extra {
+		snapshot, phase = r.To, phaseDropIndexes
+	}
+
+	if t, ok := tableOf(snapshot, d.Table); ok && isConstraintIndex(t, d.Name) {
+		s.add(phase, fmt.Sprintf("-- the index %s of %s comes with the constraint %s.", name, table, name))
+		return nil
+	}
+
+	if d.Kind != Missing {
+		switch r.To.Driver {
+		case drivers.MySQL, drivers.MariaDB, drivers.SQLServer, drivers.ClickHouse:
+			s.add(phaseDropIndexes, fmt.Sprintf("DROP INDEX %s ON %s;", name, table))
+		default:
+			s.add(phaseDropIndexes, fmt.Sprintf("DROP INDEX %s;", r.tableName(d.Name)))
+		}
+	}
+
+	if d.Kind == Extra {
+		return nil
+	}
+
+	source, ok := r.sourceTable(d.Table)
+	if !ok {
+		return nil
+	}
+
+	i := slices.IndexFunc(source.Indexes, func(index client.Index) bool { return index.Name == d.Name })
+	if i < 0 {
+		return nil
+	}
+
+	create, err := r.createIndex(source, source.Indexes[i])
+	if err != nil {
+		return err
+	}
+
+	s.add(phaseCreateIndexes, create)
+	return nil
+}
+
+// createIndex method returns the statement creating an index of a table of the source on the target.
+// The index is created in the schema of its table.
+func (r Report) createIndex(table client.TableSnapshot, index client.Index) (string, error) {
+	// the data skipping indexes of ClickHouse take a type and a granularity, which are not read from the catalog.
+	if r.From.Driver == drivers.ClickHouse || r.To.Driver == drivers.ClickHouse {
+		return "", fmt.Errorf("the index %s of %s can't be written, the data skipping indexes of ClickHouse are not mapped", index.Name, table.Name)
+	}
+
+	if len(index.Columns) == 0 {
+		return "", fmt.Errorf("the index %s of %s can't be written, its columns are not read from the catalog", index.Name, table.Name)
+	}
+
+	columns := make([]string, 0, len(index.Columns))
+	for _, column := range index.Columns {
+		switch {
+		case column == "":
+			return "", fmt.Errorf("the index %s of %s can't be written, one of its expressions is not read from the catalog", index.Name, table.Name)
+		case slices.ContainsFunc(table.Columns, func(c client.Column) bool { return c.Name == column }):
+			columns = append(columns, r.quote(column))
+		case strings.Contains(column, "("):
+			// the expressions are written as they are, between parentheses.
+			if !strings.HasPrefix(column, "(") {
+				column = "(" + column + ")"
+			}
+			columns = append(columns, column)
+		default:
+			return "", fmt.Errorf("the index %s of %s can't be written, %s is not a column of the table", index.Name, table.Name, column)
+		}
+	}
+
+	unique := ""
+	if index.Unique {
+		unique = "UNIQUE "
+	}
+
+	return fmt.Sprintf("CREATE %sINDEX %s ON %s (%s);", unique, r.quote(index.Name), r.tableName(table.Name), strings.Join(columns, ", ")), nil
+}
+
+// isConstraintIndex function reports whether an index of a table is the one of a constraint of the same name,
+// which is created and dropped along with the constraint.
+func isConstraintIndex(table client.TableSnapshot, name string) bool {
+	if strings.HasPrefix(name, "sqlite_autoindex_") {
+		return true
+	}
+
+	return slices.ContainsFunc(table.Constraints, func(c client.Constraint) bool { return c.Name == name })
+}
+
+func (r Report) constraintStatements(s *script, d Difference) error {
+	// SQLite can't add nor drop the constraints of a table.
+	if r.To.Driver == drivers.SQLite {
+		s.add(phaseAddConstraints, fmt.Sprintf("-- SQLite can't change the constraint %s of %s, the table has to be rebuilt.", r.quote(d.Name), r.tableName(d.Table)))
+		return nil
+	}
+
+	if d.Kind != Missing {
+		var constraintType string
+		if table, ok := tableOf(r.To, d.Table); ok {
+			if i := slices.IndexFunc(table.Constraints, func(c client.Constraint) bool { return c.Name == d.Name }); i >= 0 {
+				constraintType = table.Constraints[i].Type
+			}
+		}
+
+		phase := phaseDropConstraints
+		if constraintType == "FOREIGN KEY" {
+			phase = phaseDropForeignKeys
+		}
+
+		s.add(phase, r.dropConstraint(d.Table, d.Name, constraintType))
+	}
+
+	if d.Kind == Extra {
+		return nil
+	}
+
+	table, ok := r.sourceTable(d.Table)
+	if !ok {
+		return nil
+	}
+
+	i := slices.IndexFunc(table.Constraints, func(c client.Constraint) bool { return c.Name == d.Name })
+	if i < 0 {
+		return nil
+	}
+	constraint := table.Constraints[i]
+
+	if constraint.Type == "FOREIGN KEY" {
+		i := slices.IndexFunc(table.ForeignKeys, func(fk client.ForeignKey) bool { return fk.Name == d.Name })
+		if i < 0 {
+			return fmt.Errorf("the FOREIGN KEY constraint %s of %s can't be written, its definition is not read from the catalog", d.Name, d.Table)
+		}
+
+		add, err := r.addForeignKey(d.Table, table.ForeignKeys[i])
+		if err != nil {
+			return err
+		}
+
+		s.add(phaseAddForeignKeys, add)
+		return nil
+	}
+
+	add, err := r.addConstraint(table, constraint)
+	if err != nil {
+		return err
+	}
+
+	s.add(phaseAddConstraints, add)
+	return nil
+}
+
+// dropConstraint method returns the statement dropping a constraint of a table of the target, given its type, if known.
+// MySQL drops its primary keys, foreign keys and unique constraints with statements of their own.
+func (r Report) dropConstraint(table, name, constraintType string) string {
+	if r.To.Driver == drivers.MySQL || r.To.Driver == drivers.MariaDB {
+		switch constraintType {
+		case "PRIMARY KEY":
+			return fmt.Sprintf("ALTER TABLE %s DROP PRIMARY KEY;", r.tableName(table))
+		case "FOREIGN KEY":
+			return fmt.Sprintf("ALTER TABLE %s DROP FOREIGN KEY %s;", r.tableName(table), r.quote(name))
+		case "UNIQUE":
+			return fmt.Sprintf("ALTER TABLE %s DROP INDEX %s;", r.tableName(table), r.quote(name))
+		}
+	}
+
+	return fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s;", r.tableName(table), r.quote(name))
+}
+
+// addConstraint method returns the statement adding a constraint of a table of the source to the target.
+func (r Report) addConstraint(table client.TableSnapshot, constraint client.Constraint) (string, error) {
+	if r.To.Driver == drivers.ClickHouse {
+		return "", fmt.Errorf("the %s constraint %s of %s can't be written, ClickHouse has no such constraints", constraint.Type, constraint.Name, table.Name)
+	}
+
+	definition, err := r.constraintDefinition(table, constraint)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s %s;", r.tableName(table.Name), r.quote(constraint.Name), definition), nil
+}
+
+// constraintDefinition method returns the definition of a constraint of a table of the source, e.g. UNIQUE (email).
+// The primary keys are built on the columns flagged as such and the unique constraints on the columns of their index,
+// the definition of the rest is not read from the catalog.
+func (r Report) constraintDefinition(table client.TableSnapshot, constraint client.Constraint) (string, error) {
+	switch constraint.Type {
+	case "PRIMARY KEY":
+		var columns []string
+		for _, column := range table.Columns {
+			if column.PrimaryKey {
+				columns = append(columns, r.quote(column.Name))
+			}
+		}
+
+		if len(columns) > 0 {
+			return fmt.Sprintf("PRIMARY KEY (%s)", strings.Join(columns, ", ")), nil
+		}
+	case "UNIQUE":
+		i := slices.IndexFunc(table.Indexes, func(index client.Index) bool { return index.Name == constraint.Name })
+		if i >= 0 && len(table.Indexes[i].Columns) > 0 && !slices.Contains(table.Indexes[i].Columns, "") {
+			return fmt.Sprintf("UNIQUE (%s)", strings.Join(r.quoteAll(table.Indexes[i].Columns), ", ")), nil
+		}
+	}
+
+	return "", fmt.Errorf("the %s constraint %s of %s can't be written, its definition is not read from the catalog", constraint.Type, constraint.Name, table.Name)
+}
+
+// addForeignKey method returns the statement adding a foreign key of a table of the source to the target.
+func (r Report) addForeignKey(table string, fk client.ForeignKey) (string, error) {
+	if r.To.Driver == drivers.ClickHouse {
+		return "", fmt.Errorf("the foreign key %s of %s can't be written, ClickHouse has no foreign keys", fk.Name, table)
+	}
+
+	return fmt.Sprintf("ALTER TABLE %s ADD %s;", r.tableName(table), r.foreignKeyDefinition(fk)), nil
+}
+
+// foreignKeyDefinition method returns the definition of a foreign key, e.g. CONSTRAINT fk FOREIGN KEY (a) REFERENCES t (b).
+// The referenced columns are left out when the database doesn't tell them, e.g. SQLite, so the primary key is referenced.
+func (r Report) foreignKeyDefinition(fk client.ForeignKey) string {
+	definition := fmt.Sprintf("FOREIGN KEY (%s) REFERENCES %s", strings.Join(r.quoteAll(fk.Columns), ", "), r.tableName(fk.RefTable))
+	if len(fk.RefColumns) > 0 && !slices.Contains(fk.RefColumns, "") {
+		definition += fmt.Sprintf(" (%s)", strings.Join(r.quoteAll(fk.RefColumns), ", "))
+	}
+
+	if fk.Name == "" {
+		return definition
+	}
+
+	return fmt.Sprintf("CONSTRAINT %s %s", r.quote(fk.Name), definition)
+}
+
+func (r Report) viewStatements(s *script, d Difference) {
+	if d.Kind != Missing {
+		s.add(phaseDropViews, fmt.Sprintf("DROP VIEW %s;", r.tableName(d.Name)))
+	}
+
+	if d.Kind == Extra {
+		return
+	}
+
+	view, ok := r.sourceView(d.Name)
+	if !ok {
+		return
+	}
+
+	// some databases return the whole CREATE VIEW statement as the definition, others just the query.
+	definition := strings.TrimSuffix(strings.TrimSpace(view.Definition), ";")
+	create := fmt.Sprintf("CREATE VIEW %s AS\n%s;", r.tableName(d.Name), definition)
+	if strings.HasPrefix(strings.ToUpper(definition), "CREATE") {
+		create = definition + ";"
+	}
+
+	s.add(phaseCreateViews, create)
+}
+
+// rebuildViews method drops and creates again the views of the target reading from a table whose columns
+// are altered or dropped, which some databases refuse while a view depends on them, e.g. Postgres.
+// A view is taken as reading from a table if its definition mentions the name of the table.
+func (r Report) rebuildViews(s *script) {
+	var (
+		tables   []string
+		reported = make(map[string]bool)
+	)
+	for _, d := range r.Differences {
+		switch {
+		case d.Object == ObjectView:
+			reported[d.Name] = true
+		case d.Object == ObjectColumn && d.Kind != Missing && !slices.Contains(tables, d.Table):
+			tables = append(tables, d.Table)
+		}
+	}
+
+	for _, view := range r.To.Views {
+		if reported[view.Name] {
+			continue
+		}
+
+		if slices.ContainsFunc(tables, func(table string) bool { return mentions(view.Definition, table) }) {
+			r.viewStatements(s, Difference{Kind: Changed, Object: ObjectView, Name: view.Name})
+		}
+	}
+}
+
+// mentions function reports whether a view definition mentions a name as a whole word, quoted or not.
+func mentions(definition, name string) bool {
+	return regexp.MustCompile(`(?i)(^|[^\w$])` + regexp.QuoteMeta(name) + `($|[^\w$])`).MatchString(definition)
+}
+
+// alterColumnPerChange method tells whether the type and the nullability of a column
+// are changed by different statements on the target database.
+func (r Report) alterColumnPerChange() bool {
+	switch r.To.Driver {
+	case drivers.MySQL, drivers.MariaDB, drivers.SQLServer, drivers.ClickHouse:
+		return false
+	default:
+		return true
+	}
+}
+
+// tableName method quotes the name of a table, a view or an index, qualified with the schema of the target, if any.
+func (r Report) tableName(name string) string {
+	if r.To.Schema == "" {
+		return r.quote(name)
+	}
+
+	return r.quote(r.To.Schema) + "." + r.quote(name)
+}
+
+// quote method quotes a name as the target database does, e.g. "name", `name` or [name].
+// The names are the ones read from the catalogs, so they're quoted as they are.
+func (r Report) quote(name string) string {
+	dialect, ok := drivers.Lookup(r.To.Driver)
+	if !ok {
+		return name
+	}
+
+	return dialect.QuoteIdentifier(name)
+}
+
+// quoteAll method quotes a list of names as the target database does.
+func (r Report) quoteAll(names []string) []string {
+	quoted := make([]string, 0, len(names))
+	for _, name := range names {
+		quoted = append(quoted, r.quote(name))
+	}
+
+	return quoted
+}
+
+func (r Report) sourceTable(name string) (client.TableSnapshot, bool) {
+	return tableOf(r.From, name)
+}
+
+func tableOf(snapshot *client.Snapshot, name string) (client.TableSnapshot, bool) {
+	for _, table := range snapshot.Tables {
+		if table.Name == name {
+			return table, true
+		}
+	}
+
+	return client.TableSnapshot{}, false
+}
+
+func (r Report) sourceColumn(table, name string) (client.Column, bool) {
+	t, ok := r.sourceTable(table)
+	if !ok {
+		return client.Column{}, false
+	}
+
+	for _, column := range t.Columns {
+		if column.Name == name {
+			return column, true
+		}
+	}
+
+	return client.Column{}, false
+}
+
+func (r Report) sourceView(name string) (client.ViewSnapshot, bool) {
+	for _, view := range r.From.Views {
+		if view.Name == name {
+			return view, true
+		}
+	}
+
+	return client.ViewSnapshot{}, false
+}
+
+// columnDefinition method returns the definition of a column used by CREATE TABLE and ADD COLUMN statements,
+// with its type written in the dialect of the target.
+func (r Report) columnDefinition(table string, column client.Column) (string, error) {
+	dataType, err := r.columnType(table, column)
+	if err != nil {
+		return "", err
+	}
+
+	definition := strings.TrimSpace(r.quote(column.Name) + " " + dataType)
+	if !column.Nullable {
+		definition += " NOT NULL"
+	}
+
+	return definition, nil
+}
+
+func nullability(column client.Column) string {
+	if column.Nullable {
+		return "NULL"
+	}
+
+	return "NOT NULL"
+}
@@ -0,0 +1,254 @@
+package schemadiff
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/danvergara/dblab/pkg/client"
+)
+
+// Kind tells how an object of the target differs from the source.
+type Kind string
+
+const (
+	// Missing objects exist in the source but not in the target.
+	Missing Kind = "missing"
+	// Extra objects exist in the target but not in the source.
+	Extra Kind = "extra"
+	// Changed objects exist in both, with a different type, nullability or definition.
+	Changed Kind = "changed"
+)
+
+// Types of the objects compared.
+const (
+	ObjectTable      = "table"
+	ObjectColumn     = "column"
+	ObjectIndex      = "index"
+	ObjectConstraint = "constraint"
+	ObjectView       = "view"
+)
+
+// Difference is an object that differs between the source and the target.
+type Difference struct {
+	Kind   Kind
+	Object string
+	// Table is the table the column, index or constraint belongs to.
+	Table string
+	Name  string
+	// Detail describes a change, e.g. "type: integer -> bigint".
+	Detail string
+}
+
+// Report holds the differences between two snapshots.
+// The source is the reference, the target is the database to bring in line with it.
+type Report struct {
+	From        *client.Snapshot
+	To          *client.Snapshot
+	Differences []Difference
+}
+
+// Compare function compares the target with the source, table by table and view by view.
+// The columns, indexes and constraints of the tables missing or extra are not reported on their own.
+func Compare(from, to *client.Snapshot) Report {
+	report := Report{From: from, To: to}
+
+	targetTables := make(map[string]client.TableSnapshot, len(to.Tables))
+	for _, table := range to.Tables {
+		targetTables[table.Name] = table
+	}
+
+	for _, table := range from.Tables {
+		target, ok := targetTables[table.Name]
+		if !ok {
+			report.add(Difference{Kind: Missing, Object: ObjectTable, Name: table.Name})
+			continue
+		}
+		delete(targetTables, table.Name)
+
+		report.compareTables(table, target)
+	}
+
+	for _, table := range to.Tables {
+		if _, ok := targetTables[table.Name]; ok {
+			report.add(Difference{Kind: Extra, Object: ObjectTable, Name: table.Name})
+		}
+	}
+
+	targetViews := make(map[string]client.ViewSnapshot, len(to.Views))
+	for _, view := range to.Views {
+		targetViews[view.Name] = view
+	}
+
+	for _, view := range from.Views {
+		target, ok := targetViews[view.Name]
+		switch {
+		case !ok:
+			report.add(Difference{Kind: Missing, Object: ObjectView, Name: view.Name})
+		case normalizeDefinition(view.Definition) != normalizeDefinition(target.Definition):
+			report.add(Difference{Kind: Changed, Object: ObjectView, Name: view.Name, Detail: "definition"})
+		}
+		delete(targetViews, view.Name)
+	}
+
+	for _, view := range to.Views {
+		if _, ok := targetViews[view.Name]; ok {
+			report.add(Difference{Kind: Extra, Object: ObjectView, Name: view.Name})
+		}
+	}
+
+	return report
+}
+
+// compareTables method compares the columns, indexes and constraints of a table found on both sides.
+func (r *Report) compareTables(from, to client.TableSnapshot) {
+	targetColumns := make(map[string]client.Column, len(to.Columns))
+	for _, column := range to.Columns {
+		targetColumns[column.Name] = column
+	}
+
+	for _, column := range from.Columns {
+		target, ok := targetColumns[column.Name]
+		if !ok {
+			r.add(Difference{Kind: Missing, Object: ObjectColumn, Table: from.Name, Name: column.Name})
+			continue
+		}
+		delete(targetColumns, column.Name)
+
+		if !strings.EqualFold(column.DataType, target.DataType) {
+			r.add(Difference{
+				Kind:   Changed,
+				Object: ObjectColumn,
+				Table:  from.Name,
+				Name:   column.Name,
+				Detail: fmt.Sprintf("type: %s -> %s", target.DataType, column.DataType),
+			})
+		}
+
+		if column.Nullable != target.Nullable {
+			r.add(Difference{
+				Kind:   Changed,
+				Object: ObjectColumn,
+				Table:  from.Name,
+				Name:   column.Name,
+				Detail: fmt.Sprintf("nullable: %s -> %s", yesNo(target.Nullable), yesNo(column.Nullable)),
+			})
+		}
+	}
+
+	for _, column := range to.Columns {
+		if _, ok := targetColumns[column.Name]; ok {
+			r.add(Difference{Kind: Extra, Object: ObjectColumn, Table: from.Name, Name: column.Name})
+		}
+	}
+
+	targetIndexes := make(map[string]client.Index, len(to.Indexes))
+	for _, index := range to.Indexes {
+		targetIndexes[index.Name] = index
+	}
+
+	for _, index := range from.Indexes {
+		target, ok := targetIndexes[index.Name]
+		switch {
+		case !ok:
+			r.add(Difference{Kind: Missing, Object: ObjectIndex, Table: from.Name, Name: index.Name})
+		case index.Unique != target.Unique:
+			r.add(Difference{
+				Kind:   Changed,
+				Object: ObjectIndex,
+				Table:  from.Name,
+				Name:   index.Name,
+				Detail: fmt.Sprintf("unique: %s -> %s", yesNo(target.Unique), yesNo(index.Unique)),
+			})
+		// the columns are only compared when both databases tell them, Redshift doesn't.
+		case len(index.Columns) > 0 && len(target.Columns) > 0 && !slices.Equal(index.Columns, target.Columns):
+			r.add(Difference{
+				Kind:   Changed,
+				Object: ObjectIndex,
+				Table:  from.Name,
+				Name:   index.Name,
+				Detail: fmt.Sprintf("columns: %s -> %s", strings.Join(target.Columns, ", "), strings.Join(index.Columns, ", ")),
+			})
+		}
+		delete(targetIndexes, index.Name)
+	}
+
+	for _, index := range to.Indexes {
+		if _, ok := targetIndexes[index.Name]; ok {
+			r.add(Difference{Kind: Extra, Object: ObjectIndex, Table: from.Name, Name: index.Name})
+		}
+	}
+
+	targetConstraints := make(map[string]client.Constraint, len(to.Constraints))
+	for _, constraint := range to.Constraints {
+		targetConstraints[constraint.Name] = constraint
+	}
+
+	for _, constraint := range from.Constraints {
+		target, ok := targetConstraints[constraint.Name]
+		switch {
+		case !ok:
+			r.add(Difference{Kind: Missing, Object: ObjectConstraint, Table: from.Name, Name: constraint.Name, Detail: constraint.Type})
+		case constraint.Type != target.Type:
+			r.add(Difference{
+				Kind:   Changed,
+				Object: ObjectConstraint,
+				Table:  from.Name,
+				Name:   constraint.Name,
+				Detail: fmt.Sprintf("type: %s -> %s", target.Type, constraint.Type),
+			})
+		}
+		delete(targetConstraints, constraint.Name)
+	}
+
+	for _, constraint := range to.Constraints {
+		if _, ok := targetConstraints[constraint.Name]; ok {
+			r.add(Difference{Kind: Extra, Object: ObjectConstraint, Table: from.Name, Name: constraint.Name, Detail: constraint.Type})
+		}
+	}
+}
+
+func (r *Report) add(d Difference) {
+	r.Differences = append(r.Differences, d)
+}
+
+// Write method writes the differences as a table.
+func (r Report) Write(out io.Writer) error {
+	if len(r.Differences) == 0 {
+		_, err := fmt.Fprintln(out, "no differences found")
+		return err
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "DIFF\tOBJECT\tNAME\tDETAIL")
+	for _, d := range r.Differences {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", d.Kind, d.Object, d.QualifiedName(), d.Detail)
+	}
+
+	return w.Flush()
+}
+
+// QualifiedName method returns the name of the object, prefixed by the name of its table.
+func (d Difference) QualifiedName() string {
+	if d.Table == "" {
+		return d.Name
+	}
+
+	return d.Table + "." + d.Name
+}
+
+// normalizeDefinition function puts a view definition in a single line,
+// so the differences in whitespace and the trailing semicolon are ignored.
+func normalizeDefinition(definition string) string {
+	return strings.TrimSuffix(strings.Join(strings.Fields(definition), " "), ";")
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "YES"
+	}
+
+	return "NO"
+}
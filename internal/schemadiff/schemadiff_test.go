@@ -0,0 +1,349 @@
+package schemadiff
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/danvergara/dblab/pkg/client"
+	"github.com/danvergara/dblab/pkg/drivers"
+)
+
+func testSnapshots(driver, schema string) (*client.Snapshot, *client.Snapshot) {
+	from := &client.Snapshot{
+		Driver: driver,
+		Schema: schema,
+		Tables: []client.TableSnapshot{
+			{
+				Name:        "orders",
+				Columns:     []client.Column{{Name: "id", DataType: "integer", PrimaryKey: true}, {Name: "total", DataType: "numeric", Nullable: true}},
+				Constraints: []client.Constraint{{Name: "orders_pkey", Type: "PRIMARY KEY"}},
+			},
+			{
+				Name: "users",
+				Columns: []client.Column{
+					{Name: "id", DataType: "bigint", PrimaryKey: true},
+					{Name: "email", DataType: "text"},
+					{Name: "name", DataType: "text", Nullable: true},
+				},
+				Indexes:     []client.Index{{Name: "users_email_idx", Unique: true, Columns: []string{"email"}}},
+				Constraints: []client.Constraint{{Name: "users_pkey", Type: "PRIMARY KEY"}},
+			},
+		},
+		Views: []client.ViewSnapshot{
+			{Name: "big_orders", Definition: "SELECT * FROM orders WHERE total > 100"},
+			{Name: "emails", Definition: " SELECT email\n   FROM users;"},
+		},
+	}
+
+	to := &client.Snapshot{
+		Driver: driver,
+		Schema: schema,
+		Tables: []client.TableSnapshot{
+			{Name: "legacy", Columns: []client.Column{{Name: "id", DataType: "integer"}}},
+			{
+				Name: "users",
+				Columns: []client.Column{
+					{Name: "id", DataType: "integer", PrimaryKey: true},
+					{Name: "email", DataType: "TEXT", Nullable: true},
+					{Name: "nickname", DataType: "text", Nullable: true},
+				},
+				Indexes:     []client.Index{{Name: "users_nickname_idx", Columns: []string{"nickname"}}},
+				Constraints: []client.Constraint{{Name: "users_pkey", Type: "PRIMARY KEY"}},
+			},
+		},
+		Views: []client.ViewSnapshot{
+			{Name: "emails", Definition: "SELECT email FROM users"},
+			{Name: "old_users", Definition: "SELECT id FROM legacy"},
+		},
+	}
+
+	return from, to
+}
+
+func TestCompare(t *testing.T) {
+	from, to := testSnapshots(drivers.Postgres, "public")
+
+	report := Compare(from, to)
+
+	require.Equal(t, []Difference{
+		{Kind: Missing, Object: ObjectTable, Name: "orders"},
+		{Kind: Changed, Object: ObjectColumn, Table: "users", Name: "id", Detail: "type: integer -> bigint"},
+		{Kind: Changed, Object: ObjectColumn, Table: "users", Name: "email", Detail: "nullable: YES -> NO"},
+		{Kind: Missing, Object: ObjectColumn, Table: "users", Name: "name"},
+		{Kind: Extra, Object: ObjectColumn, Table: "users", Name: "nickname"},
+		{Kind: Missing, Object: ObjectIndex, Table: "users", Name: "users_email_idx"},
+		{Kind: Extra, Object: ObjectIndex, Table: "users", Name: "users_nickname_idx"},
+		{Kind: Extra, Object: ObjectTable, Name: "legacy"},
+		{Kind: Missing, Object: ObjectView, Name: "big_orders"},
+		{Kind: Extra, Object: ObjectView, Name: "old_users"},
+	}, report.Differences)
+
+	require.Empty(t, Compare(from, from).Differences)
+}
+
+func TestReportWrite(t *testing.T) {
+	from, to := testSnapshots(drivers.Postgres, "public")
+
+	var out bytes.Buffer
+	require.NoError(t, Compare(from, to).Write(&out))
+	require.Contains(t, out.String(), "DIFF     OBJECT  NAME")
+	require.Contains(t, out.String(), "changed  column  users.id")
+
+	out.Reset()
+	require.NoError(t, Compare(from, from).Write(&out))
+	require.Equal(t, "no differences found\n", out.String())
+}
+
+func TestMigration(t *testing.T) {
+	t.Run("Postgres", func(t *testing.T) {
+		from, to := testSnapshots(drivers.Postgres, "public")
+		script, err := Compare(from, to).Migration()
+		require.NoError(t, err)
+
+		require.Contains(t, script, `CREATE TABLE "public"."orders" (`+"\n"+`  "id" integer NOT NULL,`+"\n"+`  "total" numeric,`+"\n"+`  PRIMARY KEY ("id")`+"\n);")
+		require.Contains(t, script, `ALTER TABLE "public"."users" ALTER COLUMN "id" TYPE bigint;`)
+		require.Contains(t, script, `ALTER TABLE "public"."users" ALTER COLUMN "email" SET NOT NULL;`)
+		require.Contains(t, script, `ALTER TABLE "public"."users" ADD COLUMN "name" text;`)
+		require.Contains(t, script, `ALTER TABLE "public"."users" DROP COLUMN "nickname";`)
+		require.Contains(t, script, `CREATE UNIQUE INDEX "users_email_idx" ON "public"."users" ("email");`)
+		require.Contains(t, script, `DROP INDEX "public"."users_nickname_idx";`)
+		require.Contains(t, script, `DROP TABLE "public"."legacy";`)
+		require.Contains(t, script, `CREATE VIEW "public"."big_orders" AS`+"\nSELECT * FROM orders WHERE total > 100;")
+		require.Contains(t, script, `DROP VIEW "public"."old_users";`)
+	})
+
+	t.Run("MySQL", func(t *testing.T) {
+		from, to := testSnapshots(drivers.MySQL, "")
+		to.Tables[1].Columns[1].DataType = "varchar(10)"
+		script, err := Compare(from, to).Migration()
+		require.NoError(t, err)
+
+		// the type and the nullability of email change in a single statement.
+		require.Equal(t, 1, bytes.Count([]byte(script), []byte("MODIFY COLUMN `email`")))
+		require.Contains(t, script, "ALTER TABLE `users` MODIFY COLUMN `email` text NOT NULL;")
+		require.Contains(t, script, "DROP INDEX `users_nickname_idx` ON `users`;")
+	})
+
+	t.Run("MariaDB", func(t *testing.T) {
+		from, to := testSnapshots(drivers.MariaDB, "")
+		to.Tables[1].Columns[1].DataType = "varchar(10)"
+		script, err := Compare(from, to).Migration()
+		require.NoError(t, err)
+
+		require.Equal(t, 1, bytes.Count([]byte(script), []byte("MODIFY COLUMN `email`")))
+		require.Contains(t, script, "ALTER TABLE `users` MODIFY COLUMN `id` bigint NOT NULL;")
+		require.Contains(t, script, "ALTER TABLE `users` MODIFY COLUMN `email` text NOT NULL;")
+		require.Contains(t, script, "DROP INDEX `users_nickname_idx` ON `users`;")
+		require.NotContains(t, script, "ALTER COLUMN")
+	})
+
+	t.Run("ClickHouse", func(t *testing.T) {
+		from, to := testSnapshots(drivers.ClickHouse, "analytics")
+		// the type of the data skipping indexes is not read from the catalog.
+		_, err := Compare(from, to).Migration()
+		require.ErrorContains(t, err, "the index users_email_idx of users can't be written")
+
+		from.Tables[1].Indexes = nil
+		script, err := Compare(from, to).Migration()
+		require.NoError(t, err)
+
+		require.Contains(t, script, "CREATE TABLE `analytics`.`orders` (\n  `id` integer NOT NULL,\n  `total` numeric\n) ENGINE = MergeTree ORDER BY (`id`);")
+		require.Contains(t, script, "ALTER TABLE `analytics`.`users` MODIFY COLUMN `id` bigint NOT NULL;")
+		require.Contains(t, script, "ALTER TABLE `analytics`.`users` MODIFY COLUMN `email` text NOT NULL;")
+		require.Contains(t, script, "DROP INDEX `users_nickname_idx` ON `analytics`.`users`;")
+		require.NotContains(t, script, "ALTER COLUMN")
+	})
+
+	t.Run("SQLite", func(t *testing.T) {
+		from, to := testSnapshots(drivers.SQLite, "")
+		from.Views[0].Definition = "CREATE VIEW big_orders AS SELECT * FROM orders WHERE total > 100"
+		script, err := Compare(from, to).Migration()
+		require.NoError(t, err)
+
+		require.Contains(t, script, `-- SQLite can't alter the column "id" of "users" (type: integer -> bigint), the table has to be rebuilt.`)
+		require.Contains(t, script, "\nCREATE VIEW big_orders AS SELECT * FROM orders WHERE total > 100;\n")
+	})
+
+	t.Run("Quoted names", func(t *testing.T) {
+		from, to := testSnapshots(drivers.SQLServer, "dbo")
+		from.Tables[0].Name = "order items"
+		from.Tables[0].Columns[0].Name = "Order]ID"
+		script, err := Compare(from, to).Migration()
+		require.NoError(t, err)
+
+		require.Contains(t, script, "CREATE TABLE [dbo].[order items] (\n  [Order]]ID] integer NOT NULL,")
+		require.Contains(t, script, "PRIMARY KEY ([Order]]ID])")
+		require.Contains(t, script, "ALTER TABLE [dbo].[users] DROP COLUMN [nickname];")
+	})
+
+	t.Run("Indexes", func(t *testing.T) {
+		from, to := testSnapshots(drivers.Postgres, "public")
+		from.Tables[1].Indexes = append(from.Tables[1].Indexes,
+			client.Index{Name: "users_lower_email_idx", Columns: []string{"lower(email)", "name"}},
+			client.Index{Name: "users_pkey", Unique: true, Columns: []string{"id"}},
+		)
+		from.Tables[1].Indexes[0].Columns = []string{"email", "name"}
+		to.Tables[1].Indexes = append(to.Tables[1].Indexes, client.Index{Name: "users_email_idx", Unique: true, Columns: []string{"email"}})
+
+		script, err := Compare(from, to).Migration()
+		require.NoError(t, err)
+
+		require.Contains(t, script, `DROP INDEX "public"."users_email_idx";`)
+		require.Contains(t, script, `CREATE UNIQUE INDEX "users_email_idx" ON "public"."users" ("email", "name");`)
+		require.Contains(t, script, `CREATE INDEX "users_lower_email_idx" ON "public"."users" ((lower(email)), "name");`)
+		require.Contains(t, script, `-- the index "users_pkey" of "public"."users" comes with the constraint "users_pkey".`)
+	})
+
+	t.Run("Order", func(t *testing.T) {
+		from, to := testSnapshots(drivers.Postgres, "public")
+		script, err := Compare(from, to).Migration()
+		require.NoError(t, err)
+
+		// the views are dropped before the tables and columns they read from, and created once they exist.
+		statements := []string{
+			`DROP VIEW "public"."old_users";`,
+			`DROP VIEW "public"."emails";`,
+			`DROP INDEX "public"."users_nickname_idx";`,
+			`ALTER TABLE "public"."users" DROP COLUMN "nickname";`,
+			`DROP TABLE "public"."legacy";`,
+			`CREATE TABLE "public"."orders" (`,
+			`ALTER TABLE "public"."users" ALTER COLUMN "id" TYPE bigint;`,
+			`ALTER TABLE "public"."users" ADD COLUMN "name" text;`,
+			`CREATE UNIQUE INDEX "users_email_idx" ON "public"."users" ("email");`,
+			`CREATE VIEW "public"."big_orders" AS`,
+			`CREATE VIEW "public"."emails" AS`,
+		}
+
+		last := -1
+		for _, statement := range statements {
+			i := strings.Index(script, statement)
+			require.Greater(t, i, last, statement)
+			last = i
+		}
+	})
+
+	t.Run("Missing tables", func(t *testing.T) {
+		from, to := testSnapshots(drivers.Postgres, "public")
+		from.Tables[0].Columns = append(from.Tables[0].Columns,
+			client.Column{Name: "user_id", DataType: "bigint", ForeignKey: true},
+			client.Column{Name: "number", DataType: "text"},
+		)
+		from.Tables[0].Indexes = []client.Index{
+			{Name: "orders_pkey", Unique: true, Columns: []string{"id"}},
+			{Name: "orders_number_key", Unique: true, Columns: []string{"number"}},
+			{Name: "orders_user_idx", Columns: []string{"user_id"}},
+		}
+		from.Tables[0].Constraints = append(from.Tables[0].Constraints,
+			client.Constraint{Name: "orders_number_key", Type: "UNIQUE"},
+			client.Constraint{Name: "orders_user_fkey", Type: "FOREIGN KEY"},
+		)
+		from.Tables[0].ForeignKeys = []client.ForeignKey{{Name: "orders_user_fkey", Table: "orders", Columns: []string{"user_id"}, RefTable: "users", RefColumns: []string{"id"}}}
+		to.Tables[0].ForeignKeys = []client.ForeignKey{{Name: "legacy_user_fkey", Table: "legacy", Columns: []string{"id"}, RefTable: "users", RefColumns: []string{"id"}}}
+
+		script, err := Compare(from, to).Migration()
+		require.NoError(t, err)
+
+		statements := []string{
+			`ALTER TABLE "public"."legacy" DROP CONSTRAINT "legacy_user_fkey";`,
+			`DROP TABLE "public"."legacy";`,
+			`CREATE TABLE "public"."orders" (`,
+			`ALTER TABLE "public"."orders" ADD CONSTRAINT "orders_number_key" UNIQUE ("number");`,
+			`CREATE INDEX "orders_user_idx" ON "public"."orders" ("user_id");`,
+			`ALTER TABLE "public"."orders" ADD CONSTRAINT "orders_user_fkey" FOREIGN KEY ("user_id") REFERENCES "public"."users" ("id");`,
+		}
+
+		last := -1
+		for _, statement := range statements {
+			i := strings.Index(script, statement)
+			require.Greater(t, i, last, statement)
+			last = i
+		}
+		require.NotContains(t, script, `CREATE UNIQUE INDEX "orders_pkey"`)
+		require.NotContains(t, script, `CREATE UNIQUE INDEX "orders_number_key"`)
+
+		// SQLite can't add constraints, they're written along with the columns.
+		from.Driver, to.Driver, to.Schema = drivers.SQLite, drivers.SQLite, ""
+		script, err = Compare(from, to).Migration()
+		require.NoError(t, err)
+		require.Contains(t, script, "  PRIMARY KEY (\"id\"),\n  CONSTRAINT \"orders_number_key\" UNIQUE (\"number\"),\n  CONSTRAINT \"orders_user_fkey\" FOREIGN KEY (\"user_id\") REFERENCES \"users\" (\"id\")\n);")
+	})
+
+	t.Run("Constraints", func(t *testing.T) {
+		from, to := testSnapshots(drivers.MySQL, "")
+		from.Tables[1].Columns = append(from.Tables[1].Columns, client.Column{Name: "order_id", DataType: "int", Nullable: true, ForeignKey: true})
+		from.Tables[1].Constraints = append(from.Tables[1].Constraints,
+			client.Constraint{Name: "users_email_key", Type: "UNIQUE"},
+			client.Constraint{Name: "users_order_fkey", Type: "FOREIGN KEY"},
+		)
+		from.Tables[1].Indexes = append(from.Tables[1].Indexes, client.Index{Name: "users_email_key", Unique: true, Columns: []string{"email"}})
+		from.Tables[1].ForeignKeys = []client.ForeignKey{{Name: "users_order_fkey", Table: "users", Columns: []string{"order_id"}, RefTable: "orders", RefColumns: []string{"id"}}}
+		to.Tables[1].Constraints = []client.Constraint{{Name: "PRIMARY", Type: "PRIMARY KEY"}}
+		to.Tables[1].Indexes = append(to.Tables[1].Indexes, client.Index{Name: "PRIMARY", Unique: true, Columns: []string{"id"}})
+
+		script, err := Compare(from, to).Migration()
+		require.NoError(t, err)
+
+		require.Contains(t, script, "ALTER TABLE `users` ADD CONSTRAINT `users_pkey` PRIMARY KEY (`id`);")
+		require.Contains(t, script, "ALTER TABLE `users` ADD CONSTRAINT `users_email_key` UNIQUE (`email`);")
+		require.Contains(t, script, "ALTER TABLE `users` ADD CONSTRAINT `users_order_fkey` FOREIGN KEY (`order_id`) REFERENCES `orders` (`id`);")
+		require.Contains(t, script, "ALTER TABLE `users` DROP PRIMARY KEY;")
+		require.NotContains(t, script, "CREATE UNIQUE INDEX `users_email_key`")
+		require.NotContains(t, script, "DROP INDEX `PRIMARY`")
+	})
+
+	t.Run("Types of another dialect", func(t *testing.T) {
+		from, to := testSnapshots(drivers.Postgres, "public")
+		from.Tables[0].Columns = []client.Column{
+			{Name: "id", DataType: "integer", PrimaryKey: true},
+			{Name: "total", DataType: "numeric(10,2)", Nullable: true},
+			{Name: "note", DataType: "character varying(255)", Nullable: true},
+			{Name: "created_at", DataType: "timestamp without time zone"},
+		}
+		to.Driver, to.Schema = drivers.SQLServer, "dbo"
+
+		script, err := Compare(from, to).Migration()
+		require.NoError(t, err)
+
+		require.Contains(t, script, "CREATE TABLE [dbo].[orders] (\n  [id] int NOT NULL,\n  [total] decimal(10,2),\n  [note] nvarchar(255),\n  [created_at] datetime2 NOT NULL,")
+		require.Contains(t, script, "ALTER TABLE [dbo].[users] ALTER COLUMN [id] bigint NOT NULL;")
+		require.Contains(t, script, "ALTER TABLE [dbo].[users] ADD [name] nvarchar(max);")
+	})
+
+	t.Run("Types mapped to ClickHouse", func(t *testing.T) {
+		from, to := testSnapshots(drivers.MySQL, "")
+		from.Tables[0].Columns[1].DataType = "decimal(10,2)"
+		from.Tables[1].Indexes = nil
+		to.Driver, to.Schema = drivers.ClickHouse, "analytics"
+
+		script, err := Compare(from, to).Migration()
+		require.NoError(t, err)
+
+		require.Contains(t, script, "CREATE TABLE `analytics`.`orders` (\n  `id` Int32 NOT NULL,\n  `total` Nullable(Decimal(10,2))\n)")
+	})
+
+	t.Run("Failures", func(t *testing.T) {
+		from, to := testSnapshots(drivers.Postgres, "public")
+		from.Tables[0].Columns[1].DataType = "tsvector"
+		from.Tables[1].Columns[2].DataType = "time"
+		from.Tables[1].Indexes[0].Columns = []string{"email", ""}
+		from.Tables[1].Constraints = append(from.Tables[1].Constraints, client.Constraint{Name: "users_email_check", Type: "CHECK"})
+		to.Driver, to.Schema = drivers.Oracle, "APP"
+
+		script, err := Compare(from, to).Migration()
+		require.Empty(t, script)
+		require.ErrorContains(t, err, "the type tsvector of the column total of orders can't be mapped to oracle")
+		require.ErrorContains(t, err, "the type time of the column name of users has no equivalent in oracle")
+		require.ErrorContains(t, err, "the index users_email_idx of users can't be written, one of its expressions is not read from the catalog")
+		require.ErrorContains(t, err, "the CHECK constraint users_email_check of users can't be written, its definition is not read from the catalog")
+	})
+
+	t.Run("No differences", func(t *testing.T) {
+		from, _ := testSnapshots(drivers.Postgres, "public")
+		script, err := Compare(from, from).Migration()
+		require.NoError(t, err)
+		require.Contains(t, script, "-- No differences found.")
+	})
+}
@@ -0,0 +1,353 @@
+package schemadiff
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/danvergara/dblab/pkg/client"
+	"github.com/danvergara/dblab/pkg/drivers"
+)
+
+// kinds of the column types, the types of the source are mapped through when the target speaks another dialect.
+const (
+	kindSmallInt    = "smallint"
+	kindInteger     = "integer"
+	kindBigInt      = "bigint"
+	kindDecimal     = "decimal"
+	kindReal        = "real"
+	kindDouble      = "double"
+	kindBoolean     = "boolean"
+	kindChar        = "char"
+	kindVarchar     = "varchar"
+	kindText        = "text"
+	kindDate        = "date"
+	kindTime        = "time"
+	kindTimestamp   = "timestamp"
+	kindTimestampTZ = "timestamptz"
+	kindBinary      = "binary"
+	kindUUID        = "uuid"
+	kindJSON        = "json"
+)
+
+// typeKinds maps the names of the column types, as the catalogs return them in lower case, to their kind.
+var typeKinds = map[string]string{
+	"smallint":                    kindSmallInt,
+	"int2":                        kindSmallInt,
+	"tinyint":                     kindSmallInt,
+	"smallserial":                 kindSmallInt,
+	"integer":                     kindInteger,
+	"int":                         kindInteger,
+	"int4":                        kindInteger,
+	"mediumint":                   kindInteger,
+	"serial":                      kindInteger,
+	"bigint":                      kindBigInt,
+	"int8":                        kindBigInt,
+	"bigserial":                   kindBigInt,
+	"numeric":                     kindDecimal,
+	"decimal":                     kindDecimal,
+	"number":                      kindDecimal,
+	"real":                        kindReal,
+	"float4":                      kindReal,
+	"binary_float":                kindReal,
+	"double":                      kindDouble,
+	"double precision":            kindDouble,
+	"float8":                      kindDouble,
+	"float":                       kindDouble,
+	"binary_double":               kindDouble,
+	"boolean":                     kindBoolean,
+	"bool":                        kindBoolean,
+	"char":                        kindChar,
+	"character":                   kindChar,
+	"nchar":                       kindChar,
+	"bpchar":                      kindChar,
+	"varchar":                     kindVarchar,
+	"character varying":           kindVarchar,
+	"nvarchar":                    kindVarchar,
+	"varchar2":                    kindVarchar,
+	"nvarchar2":                   kindVarchar,
+	"text":                        kindText,
+	"tinytext":                    kindText,
+	"mediumtext":                  kindText,
+	"longtext":                    kindText,
+	"ntext":                       kindText,
+	"clob":                        kindText,
+	"nclob":                       kindText,
+	"date":                        kindDate,
+	"time":                        kindTime,
+	"time without time zone":      kindTime,
+	"timestamp":                   kindTimestamp,
+	"timestamp without time zone": kindTimestamp,
+	"datetime":                    kindTimestamp,
+	"datetime2":                   kindTimestamp,
+	"smalldatetime":               kindTimestamp,
+	"timestamp with time zone":    kindTimestampTZ,
+	"timestamptz":                 kindTimestampTZ,
+	"datetimeoffset":              kindTimestampTZ,
+	"bytea":                       kindBinary,
+	"blob":                        kindBinary,
+	"tinyblob":                    kindBinary,
+	"mediumblob":                  kindBinary,
+	"longblob":                    kindBinary,
+	"binary":                      kindBinary,
+	"varbinary":                   kindBinary,
+	"image":                       kindBinary,
+	"raw":                         kindBinary,
+	"uuid":                        kindUUID,
+	"uniqueidentifier":            kindUUID,
+	"json":                        kindJSON,
+	"jsonb":                       kindJSON,
+}
+
+// driverTypeKinds overrides the kind of the types a database names differently from the rest,
+// e.g. the DATE of Oracle holds a time too, and the Int8 of ClickHouse is a single byte.
+var driverTypeKinds = map[string]map[string]string{
+	drivers.Oracle: {
+		"date":  kindTimestamp,
+		"float": kindDouble,
+	},
+	drivers.SQLServer: {
+		"bit": kindBoolean,
+	},
+	drivers.ClickHouse: {
+		"int8":        kindSmallInt,
+		"uint8":       kindSmallInt,
+		"int16":       kindSmallInt,
+		"uint16":      kindInteger,
+		"int32":       kindInteger,
+		"uint32":      kindBigInt,
+		"int64":       kindBigInt,
+		"float32":     kindReal,
+		"float64":     kindDouble,
+		"string":      kindText,
+		"fixedstring": kindChar,
+		"date32":      kindDate,
+		"datetime64":  kindTimestamp,
+	},
+}
+
+// typeName is the name of a type in the dialect of a database, written with the arguments of the source, if any,
+// or as it is. An empty name means the database has no equivalent for the type.
+type typeName struct {
+	withArgs string
+	plain    string
+}
+
+// typeNames maps the kinds of the column types to their name in the dialect of each database.
+var typeNames = map[string]map[string]typeName{
+	drivers.Postgres: {
+		kindSmallInt:    {plain: "smallint"},
+		kindInteger:     {plain: "integer"},
+		kindBigInt:      {plain: "bigint"},
+		kindDecimal:     {withArgs: "numeric(%s)", plain: "numeric"},
+		kindReal:        {plain: "real"},
+		kindDouble:      {plain: "double precision"},
+		kindBoolean:     {plain: "boolean"},
+		kindChar:        {withArgs: "char(%s)", plain: "char"},
+		kindVarchar:     {withArgs: "varchar(%s)", plain: "varchar"},
+		kindText:        {plain: "text"},
+		kindDate:        {plain: "date"},
+		kindTime:        {plain: "time"},
+		kindTimestamp:   {plain: "timestamp"},
+		kindTimestampTZ: {plain: "timestamptz"},
+		kindBinary:      {plain: "bytea"},
+		kindUUID:        {plain: "uuid"},
+		kindJSON:        {plain: "jsonb"},
+	},
+	drivers.MySQL: {
+		kindSmallInt: {plain: "smallint"},
+		kindInteger:  {plain: "int"},
+		kindBigInt:   {plain: "bigint"},
+		// a decimal with no precision is a decimal(10,0) on MySQL, which would round the values.
+		kindDecimal:   {withArgs: "decimal(%s)"},
+		kindReal:      {plain: "float"},
+		kindDouble:    {plain: "double"},
+		kindBoolean:   {plain: "boolean"},
+		kindChar:      {withArgs: "char(%s)", plain: "char"},
+		kindVarchar:   {withArgs: "varchar(%s)", plain: "longtext"},
+		kindText:      {plain: "longtext"},
+		kindDate:      {plain: "date"},
+		kindTime:      {plain: "time"},
+		kindTimestamp: {plain: "datetime"},
+		kindBinary:    {plain: "longblob"},
+		kindUUID:      {plain: "char(36)"},
+		kindJSON:      {plain: "json"},
+	},
+	drivers.SQLite: {
+		kindSmallInt:    {plain: "integer"},
+		kindInteger:     {plain: "integer"},
+		kindBigInt:      {plain: "integer"},
+		kindDecimal:     {plain: "numeric"},
+		kindReal:        {plain: "real"},
+		kindDouble:      {plain: "real"},
+		kindBoolean:     {plain: "boolean"},
+		kindChar:        {plain: "text"},
+		kindVarchar:     {plain: "text"},
+		kindText:        {plain: "text"},
+		kindDate:        {plain: "date"},
+		kindTime:        {plain: "time"},
+		kindTimestamp:   {plain: "timestamp"},
+		kindTimestampTZ: {plain: "timestamp"},
+		kindBinary:      {plain: "blob"},
+		kindUUID:        {plain: "text"},
+		kindJSON:        {plain: "text"},
+	},
+	drivers.SQLServer: {
+		kindSmallInt: {plain: "smallint"},
+		kindInteger:  {plain: "int"},
+		kindBigInt:   {plain: "bigint"},
+		// a decimal with no precision is a decimal(18,0) on SQL Server, which would round the values.
+		kindDecimal:     {withArgs: "decimal(%s)"},
+		kindReal:        {plain: "real"},
+		kindDouble:      {plain: "float"},
+		kindBoolean:     {plain: "bit"},
+		kindChar:        {withArgs: "nchar(%s)", plain: "nchar"},
+		kindVarchar:     {withArgs: "nvarchar(%s)", plain: "nvarchar(max)"},
+		kindText:        {plain: "nvarchar(max)"},
+		kindDate:        {plain: "date"},
+		kindTime:        {plain: "time"},
+		kindTimestamp:   {plain: "datetime2"},
+		kindTimestampTZ: {plain: "datetimeoffset"},
+		kindBinary:      {plain: "varbinary(max)"},
+		kindUUID:        {plain: "uniqueidentifier"},
+		kindJSON:        {plain: "nvarchar(max)"},
+	},
+	drivers.Oracle: {
+		kindSmallInt:    {plain: "number(5)"},
+		kindInteger:     {plain: "number(10)"},
+		kindBigInt:      {plain: "number(19)"},
+		kindDecimal:     {withArgs: "number(%s)", plain: "number"},
+		kindReal:        {plain: "binary_float"},
+		kindDouble:      {plain: "binary_double"},
+		kindBoolean:     {plain: "number(1)"},
+		kindChar:        {withArgs: "char(%s)", plain: "char"},
+		kindVarchar:     {withArgs: "varchar2(%s)", plain: "varchar2(4000)"},
+		kindText:        {plain: "clob"},
+		kindDate:        {plain: "date"},
+		kindTimestamp:   {plain: "timestamp"},
+		kindTimestampTZ: {plain: "timestamp with time zone"},
+		kindBinary:      {plain: "blob"},
+		kindUUID:        {plain: "varchar2(36)"},
+		kindJSON:        {plain: "clob"},
+	},
+	drivers.DuckDB: {
+		kindSmallInt: {plain: "smallint"},
+		kindInteger:  {plain: "integer"},
+		kindBigInt:   {plain: "bigint"},
+		// a decimal with no precision is a decimal(18,3) on DuckDB, which would round the values.
+		kindDecimal:     {withArgs: "decimal(%s)"},
+		kindReal:        {plain: "real"},
+		kindDouble:      {plain: "double"},
+		kindBoolean:     {plain: "boolean"},
+		kindChar:        {plain: "varchar"},
+		kindVarchar:     {plain: "varchar"},
+		kindText:        {plain: "varchar"},
+		kindDate:        {plain: "date"},
+		kindTime:        {plain: "time"},
+		kindTimestamp:   {plain: "timestamp"},
+		kindTimestampTZ: {plain: "timestamptz"},
+		kindBinary:      {plain: "blob"},
+		kindUUID:        {plain: "uuid"},
+		kindJSON:        {plain: "json"},
+	},
+	drivers.ClickHouse: {
+		kindSmallInt:    {plain: "Int16"},
+		kindInteger:     {plain: "Int32"},
+		kindBigInt:      {plain: "Int64"},
+		kindDecimal:     {withArgs: "Decimal(%s)"},
+		kindReal:        {plain: "Float32"},
+		kindDouble:      {plain: "Float64"},
+		kindBoolean:     {plain: "Bool"},
+		kindChar:        {withArgs: "FixedString(%s)", plain: "String"},
+		kindVarchar:     {plain: "String"},
+		kindText:        {plain: "String"},
+		kindDate:        {plain: "Date32"},
+		kindTimestamp:   {plain: "DateTime64(6)"},
+		kindTimestampTZ: {plain: "DateTime64(6, 'UTC')"},
+		kindBinary:      {plain: "String"},
+		kindUUID:        {plain: "UUID"},
+		kindJSON:        {plain: "String"},
+	},
+}
+
+// parseType function reads the kind and the arguments of a column type out of the catalog of a database,
+// e.g. "character varying(255)" is a varchar of 255 and the "Nullable(Int64)" of ClickHouse a bigint.
+func parseType(driver, dataType string) (kind, args string, ok bool) {
+	name := strings.ToLower(strings.TrimSpace(dataType))
+	for _, wrapper := range []string{"nullable(", "lowcardinality("} {
+		if strings.HasPrefix(name, wrapper) && strings.HasSuffix(name, ")") {
+			name = name[len(wrapper) : len(name)-1]
+		}
+	}
+
+	// the arguments may come before the rest of the name, e.g. "timestamp(6) with time zone" or "int(11) unsigned".
+	if open := strings.Index(name, "("); open >= 0 {
+		if end := strings.Index(name[open:], ")"); end >= 0 {
+			args = strings.ReplaceAll(name[open+1:open+end], " ", "")
+			name = name[:open] + " " + name[open+end+1:]
+		}
+	}
+
+	name = strings.TrimSuffix(strings.Join(strings.Fields(name), " "), " unsigned")
+
+	// MySQL writes its booleans as tinyint(1).
+	if (driver == drivers.MySQL || driver == drivers.MariaDB) && name == "tinyint" && args == "1" {
+		return kindBoolean, "", true
+	}
+
+	if kind, ok = driverTypeKinds[driver][name]; ok {
+		return kind, args, true
+	}
+
+	kind, ok = typeKinds[name]
+	return kind, args, ok
+}
+
+// sameDialect function reports whether two databases speak the same dialect, so the types are written as they are.
+func sameDialect(a, b string) bool {
+	family := func(driver string) string {
+		if driver == drivers.MariaDB {
+			return drivers.MySQL
+		}
+		return driver
+	}
+
+	return family(a) == family(b)
+}
+
+// columnType method returns the type of a column of the source written in the dialect of the target.
+// The types are mapped when the databases speak different dialects,
+// failing on the ones the target has no equivalent for rather than writing a type it doesn't know.
+func (r Report) columnType(table string, column client.Column) (string, error) {
+	if sameDialect(r.From.Driver, r.To.Driver) {
+		return column.DataType, nil
+	}
+
+	kind, args, ok := parseType(r.From.Driver, column.DataType)
+	if !ok {
+		return "", fmt.Errorf("the type %s of the column %s of %s can't be mapped to %s", column.DataType, column.Name, table, r.To.Driver)
+	}
+
+	target := r.To.Driver
+	if target == drivers.MariaDB {
+		target = drivers.MySQL
+	}
+
+	name := typeNames[target][kind]
+
+	var dataType string
+	switch {
+	case args != "" && name.withArgs != "":
+		dataType = fmt.Sprintf(name.withArgs, args)
+	case name.plain != "":
+		dataType = name.plain
+	default:
+		return "", fmt.Errorf("the type %s of the column %s of %s has no equivalent in %s", column.DataType, column.Name, table, r.To.Driver)
+	}
+
+	// the columns of ClickHouse are not nullable unless their type says so.
+	if r.To.Driver == drivers.ClickHouse && column.Nullable {
+		dataType = fmt.Sprintf("Nullable(%s)", dataType)
+	}
+
+	return dataType, nil
+}
@@ -1,6 +1,8 @@
 package app
 
 import (
+	"errors"
+
 	"github.com/danvergara/dblab/pkg/bubbletui"
 	"github.com/danvergara/dblab/pkg/client"
 	"github.com/danvergara/dblab/pkg/command"
@@ -16,6 +18,29 @@ type App struct {
 
 // New bootstrap a new application.
 func New(opts command.Options, tuiKeyBindings *command.TUIKeyMap) (*App, error) {
+	c, sc, err := Connect(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	app := App{
+		c:  c,
+		sc: sc,
+	}
+
+	app.m, err = bubbletui.NewModel(c, tuiKeyBindings)
+	if err != nil {
+		app.close()
+		return nil, err
+	}
+
+	return &app, nil
+}
+
+// Connect opens the SSH tunnel, if the options have SSH parameters, and then the connection to the database,
+// through the local port forwarded by the tunnel, if it's in local forwarding mode.
+// The SSH config is nil if there's no tunnel, otherwise it has to be closed along with the client.
+func Connect(opts command.Options) (*client.Client, *sshdb.SSHConfig, error) {
 	var sc *sshdb.SSHConfig
 
 	if opts.SSHHost != "" {
@@ -27,46 +52,54 @@ func New(opts command.Options, tuiKeyBindings *command.TUIKeyMap) (*App, error)
 			sshdb.WithPass(opts.SSHPass),
 			sshdb.WithSSHKeyFile(opts.SSHKeyFile),
 			sshdb.WithSSHKeyPass(opts.SSHKeyPassphrase),
+			sshdb.WithSSHKnownHosts(opts.SSHKnownHosts),
 			sshdb.WithDBDURL(opts.URL),
+			sshdb.WithLocalForwarding(opts.SSHForward),
+			sshdb.WithSSHJumps(opts.SSHJumps),
 		)
 
 		if err := sc.SSHTunnel(); err != nil {
-			return nil, err
+			return nil, nil, err
 		}
-	}
 
-	c, err := client.New(opts)
-	if err != nil {
-		return nil, err
+		var err error
+		opts, err = sc.ForwardOptions(opts)
+		if err != nil {
+			_ = sc.Close()
+			return nil, nil, err
+		}
 	}
 
-	m, err := bubbletui.NewModel(c, tuiKeyBindings)
+	c, err := client.New(opts)
 	if err != nil {
-		return nil, err
-	}
-
-	app := App{
-		c:  c,
-		m:  m,
-		sc: sc,
+		if sc != nil {
+			_ = sc.Close()
+		}
+		return nil, nil, err
 	}
 
-	return &app, nil
+	return c, sc, nil
 }
 
 // Run runs the application.
 func (a *App) Run() error {
-	defer func() {
-		if a.sc != nil {
-			_ = a.sc.Close()
-		}
-
-		_ = a.c.DB().Close()
-	}()
+	defer a.close()
 
 	if err := a.m.Run(); err != nil {
+		if a.sc != nil {
+			return errors.Join(err, a.sc.Err())
+		}
 		return err
 	}
 
 	return nil
 }
+
+// close closes the connection to the database and then the ssh tunnel, if any, so it's closed over the tunnel.
+func (a *App) close() {
+	_ = a.c.DB().Close()
+
+	if a.sc != nil {
+		_ = a.sc.Close()
+	}
+}
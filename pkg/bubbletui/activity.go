@@ -0,0 +1,464 @@
+package bubbletui
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/table"
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/danvergara/dblab/pkg/client"
+)
+
+// activityInterval is the time between two polls of the activity of the server.
+const activityInterval = 2 * time.Second
+
+// activitySort is the column the sessions are sorted by.
+type activitySort int
+
+const (
+	sortByDuration activitySort = iota
+	sortByID
+	sortByUser
+	sortByState
+)
+
+func (s activitySort) String() string {
+	switch s {
+	case sortByID:
+		return "id"
+	case sortByUser:
+		return "user"
+	case sortByState:
+		return "state"
+	default:
+		return "duration"
+	}
+}
+
+// sessionAction is an action on a session that waits for confirmation.
+type sessionAction struct {
+	terminate bool
+	session   client.Session
+}
+
+func (a sessionAction) String() string {
+	if a.terminate {
+		return fmt.Sprintf("terminate the session %s of %s", a.session.ID, a.session.User)
+	}
+	return fmt.Sprintf("cancel the query of the session %s of %s", a.session.ID, a.session.User)
+}
+
+// activityMsg carries the sessions of the server, polled by the activity monitor.
+// The generation tells the polls of the monitor opened last from the ones still running since it was opened before.
+type activityMsg struct {
+	generation int
+	sessions   []client.Session
+	err        error
+}
+
+// activityTickMsg asks the activity monitor to poll the server again.
+type activityTickMsg struct{ generation int }
+
+// sessionActionMsg reports the result of cancelling or terminating a session.
+type sessionActionMsg struct {
+	action sessionAction
+	err    error
+}
+
+// activity monitor custom keys.
+type activityKeyMap struct {
+	filter    key.Binding
+	sort      key.Binding
+	refresh   key.Binding
+	cancel    key.Binding
+	terminate key.Binding
+	confirm   key.Binding
+	back      key.Binding
+}
+
+func newActivityKeyMap() activityKeyMap {
+	return activityKeyMap{
+		filter: key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", "filter"),
+		),
+		sort: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "sort"),
+		),
+		refresh: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "refresh"),
+		),
+		cancel: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "cancel query"),
+		),
+		terminate: key.NewBinding(
+			key.WithKeys("x"),
+			key.WithHelp("x", "terminate session"),
+		),
+		confirm: key.NewBinding(
+			key.WithKeys("y"),
+			key.WithHelp("y", "confirm"),
+		),
+		back: key.NewBinding(
+			key.WithKeys("esc", "q"),
+			key.WithHelp("esc", "back"),
+		),
+	}
+}
+
+// ActivityModel is the activity monitor: it lists the sessions of the server, polled periodically,
+// and lets the query of a session be cancelled, or the session terminated, after confirmation.
+// The actions are disabled on read-only connections.
+type ActivityModel struct {
+	c    *client.Client
+	keys activityKeyMap
+
+	// generation is increased every time the monitor is opened, so the polls of a previous opening stop.
+	generation int
+	sessions   []client.Session
+	// shown are the sessions matching the filter, in the sort order.
+	shown  []client.Session
+	sortBy activitySort
+
+	table       table.Model
+	filterInput textinput.Model
+	filtering   bool
+
+	// pending is the action waiting for confirmation, if any.
+	pending *sessionAction
+	// status is the result of the last poll or action.
+	status string
+	err    error
+
+	width, height int
+}
+
+// NewActivityModel returns the model of the activity monitor of the server the client is connected to.
+func NewActivityModel(c *client.Client) *ActivityModel {
+	t := newTablePanel(0, 0).table
+
+	filterInput := textinput.New()
+	filterInput.Prompt = "/ "
+	filterInput.Placeholder = "filter by user, database, state or query"
+
+	return &ActivityModel{
+		c:           c,
+		keys:        newActivityKeyMap(),
+		table:       t,
+		filterInput: filterInput,
+	}
+}
+
+// SetSize method is used to set the model size when the main tui model routes the size from the tea.WindowSizeMsg message to this model.
+func (a *ActivityModel) SetSize(width, height int) {
+	a.width = width
+	a.height = height
+	a.table.SetWidth(max(width-6, 0))
+	// the title, the filter, the status and the help take 8 lines along with the borders.
+	a.table.SetHeight(max(height-8, 0))
+	a.refreshTable()
+}
+
+// Open method starts polling the activity of the server.
+func (a *ActivityModel) Open() tea.Cmd {
+	a.generation++
+	a.pending = nil
+	a.err = nil
+	a.status = "loading the activity of the server..."
+
+	return a.poll()
+}
+
+func (a *ActivityModel) Update(msg tea.Msg) (*ActivityModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case activityMsg:
+		if msg.generation != a.generation {
+			return a, nil
+		}
+
+		if msg.err != nil {
+			a.err = msg.err
+		} else {
+			a.err = nil
+			a.sessions = msg.sessions
+			a.status = fmt.Sprintf("%d sessions, refreshed at %s", len(msg.sessions), time.Now().Format(time.TimeOnly))
+			a.refreshTable()
+		}
+
+		generation := a.generation
+		return a, tea.Tick(activityInterval, func(time.Time) tea.Msg {
+			return activityTickMsg{generation: generation}
+		})
+	case activityTickMsg:
+		if msg.generation != a.generation {
+			return a, nil
+		}
+		return a, a.poll()
+	case sessionActionMsg:
+		if msg.err != nil {
+			a.err = fmt.Errorf("failed to %s: %w", msg.action, msg.err)
+			return a, nil
+		}
+
+		a.err = nil
+		a.status = fmt.Sprintf("done: %s", msg.action)
+		return a, a.poll()
+	case tea.KeyPressMsg:
+		return a.updateKeys(msg)
+	}
+
+	return a, nil
+}
+
+// updateKeys method handles the keys of the filter, the confirmation of an action and the list of sessions.
+func (a *ActivityModel) updateKeys(msg tea.KeyPressMsg) (*ActivityModel, tea.Cmd) {
+	if a.filtering {
+		switch msg.String() {
+		case "esc", "enter":
+			a.filtering = false
+			a.filterInput.Blur()
+			return a, nil
+		}
+
+		var cmd tea.Cmd
+		a.filterInput, cmd = a.filterInput.Update(msg)
+		a.refreshTable()
+		return a, cmd
+	}
+
+	if a.pending != nil {
+		action := *a.pending
+		a.pending = nil
+		if key.Matches(msg, a.keys.confirm) {
+			return a, a.runAction(action)
+		}
+		a.status = "cancelled"
+		return a, nil
+	}
+
+	switch {
+	case key.Matches(msg, a.keys.back):
+		return a, func() tea.Msg { return backToNormalMsg{} }
+	case key.Matches(msg, a.keys.filter):
+		a.filtering = true
+		return a, a.filterInput.Focus()
+	case key.Matches(msg, a.keys.sort):
+		a.sortBy = (a.sortBy + 1) % (sortByState + 1)
+		a.refreshTable()
+		return a, nil
+	case key.Matches(msg, a.keys.refresh):
+		return a, a.poll()
+	case key.Matches(msg, a.keys.cancel), key.Matches(msg, a.keys.terminate):
+		if a.c.ReadOnly() {
+			a.err = fmt.Errorf("cancelling and terminating sessions is disabled on read-only connections")
+			return a, nil
+		}
+
+		session, ok := a.selected()
+		if !ok {
+			return a, nil
+		}
+
+		a.err = nil
+		a.pending = &sessionAction{terminate: key.Matches(msg, a.keys.terminate), session: session}
+		return a, nil
+	}
+
+	var cmd tea.Cmd
+	a.table, cmd = a.table.Update(msg)
+	return a, cmd
+}
+
+// View method renders the list of sessions, along with the filter, the status and the keys.
+func (a *ActivityModel) View() tea.View {
+	var v tea.View
+	v.AltScreen = true
+
+	var b strings.Builder
+
+	b.WriteString(lipgloss.NewStyle().Background(darkPurple).Foreground(hiMagenta).Bold(true).Padding(0, 1).Render("Activity of the server"))
+	fmt.Fprintf(&b, "  sorted by %s\n\n", a.sortBy)
+	b.WriteString(a.table.View())
+	b.WriteString("\n\n")
+
+	if a.filtering || a.filterInput.Value() != "" {
+		b.WriteString(a.filterInput.View())
+		b.WriteString("\n")
+	}
+
+	switch {
+	case a.pending != nil:
+		b.WriteString(lipgloss.NewStyle().Foreground(hiMagenta).Bold(true).Render(fmt.Sprintf("%s? y/n", a.pending)))
+	case a.err != nil:
+		b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("#FF0000")).Bold(true).Render(a.err.Error()))
+	default:
+		b.WriteString(lipgloss.NewStyle().Foreground(mutedGreen).Render(a.status))
+	}
+	b.WriteString("\n")
+
+	help := []key.Binding{a.keys.filter, a.keys.sort, a.keys.refresh, a.keys.back}
+	if !a.c.ReadOnly() {
+		help = []key.Binding{a.keys.filter, a.keys.sort, a.keys.refresh, a.keys.cancel, a.keys.terminate, a.keys.back}
+	}
+
+	parts := make([]string, 0, len(help))
+	for _, binding := range help {
+		parts = append(parts, fmt.Sprintf("%s %s", binding.Help().Key, binding.Help().Desc))
+	}
+	b.WriteString(lipgloss.NewStyle().Foreground(whiteText).Render(strings.Join(parts, " • ")))
+
+	v.SetContent(lipgloss.NewStyle().Padding(1, 2).Render(b.String()))
+	return v
+}
+
+// poll method fetches the activity of the server asynchronously.
+func (a *ActivityModel) poll() tea.Cmd {
+	c, generation := a.c, a.generation
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), activityInterval*2)
+		defer cancel()
+
+		sessions, err := c.Activity(ctx)
+		return activityMsg{generation: generation, sessions: sessions, err: err}
+	}
+}
+
+// runAction method cancels the query of a session, or terminates the session, asynchronously.
+func (a *ActivityModel) runAction(action sessionAction) tea.Cmd {
+	c := a.c
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), activityInterval*2)
+		defer cancel()
+
+		var err error
+		if action.terminate {
+			err = c.TerminateSession(ctx, action.session.ID)
+		} else {
+			err = c.CancelSession(ctx, action.session.ID)
+		}
+
+		if errors.Is(err, client.ErrReadOnly) {
+			err = fmt.Errorf("the connection is read-only")
+		}
+
+		return sessionActionMsg{action: action, err: err}
+	}
+}
+
+// selected method returns the session of the selected row.
+func (a *ActivityModel) selected() (client.Session, bool) {
+	cursor := a.table.Cursor()
+	if cursor < 0 || cursor >= len(a.shown) {
+		return client.Session{}, false
+	}
+
+	return a.shown[cursor], true
+}
+
+// refreshTable method fills the table with the sessions matching the filter, in the sort order.
+// The selection stays on the same session, if it's still there.
+func (a *ActivityModel) refreshTable() {
+	selected, hadSelection := a.selected()
+
+	a.shown = sortSessions(filterSessions(a.sessions, a.filterInput.Value()), a.sortBy)
+
+	widths := []int{10, 12, 12, 12, 10, 20}
+	used := 0
+	for _, w := range widths {
+		used += w + 2
+	}
+	// the query takes the rest of the width.
+	queryWidth := max(a.table.Width()-used-2, 20)
+
+	titles := []string{"id", "user", "database", "state", "duration", "wait", "query"}
+	columns := make([]table.Column, len(titles))
+	for i, title := range titles {
+		width := queryWidth
+		if i < len(widths) {
+			width = widths[i]
+		}
+		columns[i] = table.Column{Title: title, Width: width}
+	}
+
+	rows := make([]table.Row, 0, len(a.shown))
+	cursor := 0
+	for i, s := range a.shown {
+		if hadSelection && s.ID == selected.ID {
+			cursor = i
+		}
+
+		rows = append(rows, table.Row{
+			s.ID,
+			s.User,
+			s.Database,
+			s.State,
+			formatDuration(s.Duration),
+			s.Wait,
+			strings.Join(strings.Fields(s.Query), " "),
+		})
+	}
+
+	// the rows are set before the columns, so the rows of the previous columns are never rendered with the new ones.
+	a.table.SetRows(nil)
+	a.table.SetColumns(columns)
+	a.table.SetRows(rows)
+	a.table.SetCursor(cursor)
+}
+
+// filterSessions function returns the sessions whose user, database, state, wait event or query
+// contain the given pattern, regardless of the case.
+func filterSessions(sessions []client.Session, pattern string) []client.Session {
+	pattern = strings.ToLower(strings.TrimSpace(pattern))
+	if pattern == "" {
+		return slices.Clone(sessions)
+	}
+
+	var matches []client.Session
+	for _, s := range sessions {
+		text := strings.ToLower(strings.Join([]string{s.ID, s.User, s.Database, s.State, s.Wait, s.Query}, "\x00"))
+		if strings.Contains(text, pattern) {
+			matches = append(matches, s)
+		}
+	}
+
+	return matches
+}
+
+// sortSessions function sorts the sessions by the given column, the longest running first when sorting by duration.
+func sortSessions(sessions []client.Session, sortBy activitySort) []client.Session {
+	slices.SortStableFunc(sessions, func(a, b client.Session) int {
+		switch sortBy {
+		case sortByID:
+			return cmp.Or(cmp.Compare(len(a.ID), len(b.ID)), cmp.Compare(a.ID, b.ID))
+		case sortByUser:
+			return cmp.Compare(a.User, b.User)
+		case sortByState:
+			return cmp.Compare(a.State, b.State)
+		default:
+			return cmp.Compare(b.Duration, a.Duration)
+		}
+	})
+
+	return sessions
+}
+
+// formatDuration function returns a duration rounded to the second, e.g. 1h2m3s.
+func formatDuration(d time.Duration) string {
+	if d < time.Second {
+		return "0s"
+	}
+
+	return d.Round(time.Second).String()
+}
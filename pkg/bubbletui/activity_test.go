@@ -0,0 +1,68 @@
+package bubbletui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/danvergara/dblab/pkg/client"
+)
+
+func testSessions() []client.Session {
+	return []client.Session{
+		{ID: "9", User: "app", Database: "shop", State: "idle", Duration: 3 * time.Second},
+		{ID: "10", User: "admin", Database: "shop", State: "active", Duration: time.Minute, Wait: "Lock: relation", Query: "ALTER TABLE orders ADD COLUMN note text"},
+		{ID: "11", User: "app", Database: "billing", State: "active", Duration: 10 * time.Second, Query: "SELECT * FROM invoices"},
+	}
+}
+
+func sessionIDs(sessions []client.Session) []string {
+	ids := make([]string, 0, len(sessions))
+	for _, s := range sessions {
+		ids = append(ids, s.ID)
+	}
+	return ids
+}
+
+func TestFilterSessions(t *testing.T) {
+	sessions := testSessions()
+
+	assert.Equal(t, []string{"9", "10", "11"}, sessionIDs(filterSessions(sessions, "")))
+	assert.Equal(t, []string{"10", "11"}, sessionIDs(filterSessions(sessions, "ACTIVE")))
+	assert.Equal(t, []string{"10"}, sessionIDs(filterSessions(sessions, "lock")))
+	assert.Equal(t, []string{"11"}, sessionIDs(filterSessions(sessions, "invoices")))
+	assert.Empty(t, filterSessions(sessions, "nothing"))
+}
+
+func TestSortSessions(t *testing.T) {
+	assert.Equal(t, []string{"10", "11", "9"}, sessionIDs(sortSessions(testSessions(), sortByDuration)))
+	assert.Equal(t, []string{"9", "10", "11"}, sessionIDs(sortSessions(testSessions(), sortByID)))
+	assert.Equal(t, []string{"10", "9", "11"}, sessionIDs(sortSessions(testSessions(), sortByUser)))
+	assert.Equal(t, []string{"10", "11", "9"}, sessionIDs(sortSessions(testSessions(), sortByState)))
+}
+
+func TestActivityModel_IgnoresStalePolls(t *testing.T) {
+	a := NewActivityModel(nil)
+	a.SetSize(120, 40)
+	a.generation = 2
+
+	a, cmd := a.Update(activityMsg{generation: 1, sessions: testSessions()})
+	assert.Nil(t, cmd)
+	assert.Empty(t, a.shown)
+
+	a, cmd = a.Update(activityMsg{generation: 2, sessions: testSessions()})
+	// the next poll is scheduled.
+	assert.NotNil(t, cmd)
+	assert.Equal(t, []string{"10", "11", "9"}, sessionIDs(a.shown))
+
+	selected, ok := a.selected()
+	assert.True(t, ok)
+	assert.Equal(t, "10", selected.ID)
+}
+
+func TestFormatDuration(t *testing.T) {
+	assert.Equal(t, "0s", formatDuration(300*time.Millisecond))
+	assert.Equal(t, "1m2s", formatDuration(62*time.Second+400*time.Millisecond))
+	assert.Equal(t, "1h0m0s", formatDuration(time.Hour))
+}
@@ -10,14 +10,13 @@ import (
 
 	"charm.land/bubbles/v2/help"
 	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/spinner"
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 
-	"github.com/Digital-Shane/treeview/v2"
 	"github.com/common-nighthawk/go-figure"
 	"github.com/danvergara/dblab/pkg/client"
 	"github.com/danvergara/dblab/pkg/command"
-	"github.com/danvergara/dblab/pkg/drivers"
 	"github.com/davecgh/go-spew/spew"
 )
 
@@ -46,6 +45,8 @@ const (
 	focusTable
 	focusHistory
 	focusHelp
+	focusActivity
+	focusLocks
 )
 
 var (
@@ -72,33 +73,42 @@ var (
 			Foreground(lipgloss.Color("#FF0000")).
 			Bold(true).
 			Padding(1, 2)
+
+	successStyle = lipgloss.NewStyle().
+			Foreground(mutedGreen).
+			Bold(true).
+			Padding(1, 2)
 )
 
 // metadataSucessMsg struct used to retrieve a given table's metadata asynchronously.
 type metadataSuccessMsg struct {
 	metadata *client.Metadata
 	isTable  bool
+	// isObject is set for routines, sequences, triggers and types, which only have a source definition.
+	isObject bool
+	// object is the name of the table, view or object, qualified by its schema, if any.
+	object string
+	// table is the table whose metadata was retrieved, and conditions the ones its rows were filtered by.
+	table      client.TableRef
+	conditions []client.Condition
 }
 
 // metadataErrMsg struct used to report error to user at the time to retrieve metadata.
 type metadataErrMsg struct{ err error }
 
 // querySuccessMsg struct used to get result sets from executed queries asynchronously.
-// Sometimes, tables can be created, altered of deleted, so the this returns a refreshed list of tables.
+// Sometimes, tables can be created, altered of deleted, so the this returns the parts of the catalog to fetch again.
 type querySuccessMsg struct {
-	reloadCatalog bool
+	// ddlTargets are the parts of the catalog changed by the DDL statements that succeeded.
+	ddlTargets    []client.DDLTarget
 	queriesResult []client.QueryResult
+	// profile is the name of the connection profile the queries were executed against.
+	profile string
 }
 
 // queryErrMsg struct used to report when the query execution fails.
 type queryErrMsg struct{ err error }
 
-// updateGraphMsg struct used to refresh the database graph from executed queries asynchronously.
-// It's triggered when the user either submits a DDL (Data Definition Language) query with a drop, create, alter, etc.
-type updateGraphMsg struct {
-	tree *treeview.TuiTreeModel[*client.DBNode]
-}
-
 // queryErrMsg struct used to report when the grap update fails.
 type updateGraphErrMsg struct{ err error }
 
@@ -111,6 +121,8 @@ type Model struct {
 	sidebarViewport SidebarViewport
 	resulstset      ResultSet
 	queryHistory    *HistoryModel
+	activity        *ActivityModel
+	locks           *LocksModel
 	help            help.Model
 
 	// Manages the focus on the app.
@@ -182,13 +194,15 @@ func NewModel(c *client.Client, kb *command.TUIKeyMap) (*Model, error) {
 		titleHeight:     lipgloss.Height(dblabTitle),
 		dump:            dump,
 		queryHistory:    NewHistoryModel(),
+		activity:        NewActivityModel(c),
+		locks:           NewLocksModel(c),
 	}
 
 	return m, nil
 }
 
 func (m Model) Init() tea.Cmd {
-	return nil
+	return m.sidebarViewport.Init()
 }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -227,10 +241,29 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.sidebarViewport.SetSize(m.sidebarViewportWidth, m.sidebarViewportHeight)
 		m.resulstset.SetSize(m.resultSetWidth, m.resultSetHeight)
 		m.queryHistory.SetSize(msg.Width, msg.Height)
+		m.activity.SetSize(msg.Width, msg.Height)
+		m.locks.SetSize(msg.Width, msg.Height)
 
 		return m, tea.Batch(cmds...)
 
 	case tea.KeyPressMsg:
+		// while the sidebar filter is open, the keys are typed into it.
+		if m.focus == focusList && m.sidebarViewport.filtering && !key.Matches(msg, m.keys.Quit) {
+			m.sidebarViewport, cmd = m.sidebarViewport.Update(msg)
+			return m, cmd
+		}
+
+		// the activity monitor takes every key, e.g. to type its filter, until it's closed.
+		if m.focus == focusActivity && !key.Matches(msg, m.keys.Quit) {
+			m.activity, cmd = m.activity.Update(msg)
+			return m, cmd
+		}
+
+		if m.focus == focusLocks && !key.Matches(msg, m.keys.Quit) {
+			m.locks, cmd = m.locks.Update(msg)
+			return m, cmd
+		}
+
 		switch msg.String() {
 		case "esc":
 			if m.focus == focusHelp {
@@ -247,6 +280,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		switch {
 		case key.Matches(msg, m.keys.Help):
 			m.focus = focusHelp
+		case key.Matches(msg, m.keys.Activity):
+			m.focus = focusActivity
+			m.editor.Blur()
+			return m, m.activity.Open()
+		case key.Matches(msg, m.keys.Locks):
+			m.focus = focusLocks
+			m.editor.Blur()
+			return m, m.locks.Open()
 		case key.Matches(msg, m.keys.Quit):
 			if m.cancelQuery != nil {
 				m.cancelQuery()
@@ -291,18 +332,22 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	case selectTableMsg:
 		tableRef := client.TableRef{Name: msg.Table}
-		switch m.c.Driver() {
-		case drivers.PostgreSQL, drivers.Postgres, drivers.PostgresSSH, drivers.Oracle:
+		if m.c.HasSchemas() {
 			tableRef.Schema = msg.Schema
 		}
 		return m, m.runTableMetadata(tableRef)
 	case selectViewMsg:
 		viewRef := client.ViewRef{Name: msg.View}
-		switch m.c.Driver() {
-		case drivers.PostgreSQL, drivers.Postgres, drivers.PostgresSSH, drivers.Oracle:
+		if m.c.HasSchemas() {
 			viewRef.Schema = msg.Schema
 		}
 		return m, m.runViewMetadata(viewRef)
+	case openRowsMsg:
+		return m, m.runTableMetadata(msg.location.table, msg.location.conditions...)
+	case loadTabMsg:
+		return m, m.runLoadTab(msg)
+	case selectObjectMsg:
+		return m, m.runObjectMetadata(client.ObjectRef{Schema: msg.Schema, Name: msg.Name, Type: msg.Type})
 	case executeQueryMsg:
 		ctx, cancel := context.WithCancel(context.Background())
 
@@ -314,7 +359,22 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmds = append(cmds, cmd)
 		m.sidebarViewport, cmd = m.sidebarViewport.Update(msg)
 		cmds = append(cmds, cmd)
-	case updateGraphMsg, updateGraphErrMsg:
+	case childrenMsg, catalogMsg, catalogErrMsg, rowCountsMsg, spinner.TickMsg:
+		m.sidebarViewport, cmd = m.sidebarViewport.Update(msg)
+		return m, cmd
+	case childrenErrMsg:
+		m.sidebarViewport, cmd = m.sidebarViewport.Update(msg)
+		cmds = append(cmds, cmd)
+		m.resulstset, cmd = m.resulstset.Update(msg)
+		cmds = append(cmds, cmd)
+		return m, tea.Batch(cmds...)
+	case textSavedMsg, textSaveErrMsg, diagramSavedMsg, diagramSaveErrMsg, tabLoadedMsg:
+		m.resulstset, cmd = m.resulstset.Update(msg)
+		return m, cmd
+	case insertTextMsg, catalogWordsMsg:
+		m.editor, cmd = m.editor.Update(msg)
+		return m, cmd
+	case updateGraphErrMsg:
 		m.sidebarViewport, cmd = m.sidebarViewport.Update(msg)
 		cmds = append(cmds, cmd)
 		m.resulstset, cmd = m.resulstset.Update(msg)
@@ -339,6 +399,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case focusHistory:
 		m.queryHistory, cmd = m.queryHistory.Update(msg)
 		cmds = append(cmds, cmd)
+	case focusActivity:
+		m.activity, cmd = m.activity.Update(msg)
+		cmds = append(cmds, cmd)
+	case focusLocks:
+		m.locks, cmd = m.locks.Update(msg)
+		cmds = append(cmds, cmd)
 	}
 
 	return m, tea.Batch(cmds...)
@@ -351,6 +417,10 @@ func (m Model) View() tea.View {
 	switch m.focus {
 	case focusHistory:
 		v.SetContent(m.queryHistory.View().Content)
+	case focusActivity:
+		v.SetContent(m.activity.View().Content)
+	case focusLocks:
+		v.SetContent(m.locks.View().Content)
 	case focusHelp:
 		v.SetContent(setModalContent(m.help.View(m.keys), m.width, m.height))
 	default:
@@ -407,14 +477,20 @@ func (m *Model) Run() error {
 // runTableMetadata gets the given table's metadata asynchronously.
 // If the query succeeds, it returns metadataSucessMsg with the metadata,
 // otherwise it returns metadataErrMsg with the error.
-func (m *Model) runTableMetadata(table client.TableRef) tea.Cmd {
+func (m *Model) runTableMetadata(table client.TableRef, conditions ...client.Condition) tea.Cmd {
 	return func() tea.Msg {
-		metadata, err := m.c.Metadata(table)
+		metadata, err := m.c.Metadata(table, conditions...)
 		if err != nil {
 			return metadataErrMsg{err}
 		}
 
-		return metadataSuccessMsg{metadata: metadata, isTable: true}
+		return metadataSuccessMsg{
+			metadata:   metadata,
+			isTable:    true,
+			object:     joinPath(table.Schema, table.Name),
+			table:      table,
+			conditions: conditions,
+		}
 	}
 }
 
@@ -428,30 +504,39 @@ func (m *Model) runViewMetadata(view client.ViewRef) tea.Cmd {
 			return metadataErrMsg{err}
 		}
 
-		return metadataSuccessMsg{metadata: metadata}
+		return metadataSuccessMsg{metadata: metadata, object: joinPath(view.Schema, view.Name)}
+	}
+}
+
+// runObjectMetadata gets the source definition of the given object asynchronously.
+// If the query succeeds, it returns metadataSucessMsg with the definition,
+// otherwise it returns metadataErrMsg with the error.
+func (m *Model) runObjectMetadata(obj client.ObjectRef) tea.Cmd {
+	return func() tea.Msg {
+		metadata, err := m.c.ObjectMetadata(obj)
+		if err != nil {
+			return metadataErrMsg{err}
+		}
+
+		return metadataSuccessMsg{metadata: metadata, isObject: true, object: joinPath(obj.Schema, obj.Name)}
 	}
 }
 
 // runConcurrentlyCmd runs multiple queries concurrently by calling AsyncQuery.
-// First off, it check if any query is about to alter the database graph shown in the UI.
-// If so, then sets reloadCatalog to true.
+// First off, it checks which queries are about to alter the database graph shown in the UI.
 // Then, it calls AsyncQuery to run multiple concurrently.
 // Finally, it reads results from the resultChan channel, in a blocking way, but it does not matter,
 // because this is an asynchronous function handled by the bubbletea runtime, so it does not freeze the app execution.
+// The parts of the catalog changed by the DDL statements that succeeded are set as ddlTargets, so only those are fetched again.
 func (m *Model) runConcurrentlyCmd(ctx context.Context, queries []string, maxConcurrency int) tea.Cmd {
 	return func() tea.Msg {
-		qsMsg := querySuccessMsg{}
+		qsMsg := querySuccessMsg{profile: m.c.Profile()}
 
-		for _, q := range queries {
-			cleanQuery := strings.TrimSpace(q)
-			firstWord := ""
-			if parts := strings.Fields(cleanQuery); len(parts) > 0 {
-				firstWord = strings.ToLower(parts[0])
-			}
-			// Check if any query runs a DDL commands.
-			switch firstWord {
-			case "create", "drop", "alter", "truncate", "rename":
-				qsMsg.reloadCatalog = true
+		// Check if any query runs a DDL commands.
+		targets := make(map[int]client.DDLTarget)
+		for i, q := range queries {
+			if target, ok := client.ParseDDL(q); ok {
+				targets[i] = target
 			}
 		}
 
@@ -473,10 +558,38 @@ func (m *Model) runConcurrentlyCmd(ctx context.Context, queries []string, maxCon
 		})
 
 		qsMsg.queriesResult = finalResults
+		qsMsg.ddlTargets = m.succeededDDLTargets(ctx, targets, finalResults)
 		return qsMsg
 	}
 }
 
+// succeededDDLTargets method returns the targets of the DDL statements that succeeded.
+// The statements that do not qualify the name of the object change the current schema.
+func (m *Model) succeededDDLTargets(ctx context.Context, targets map[int]client.DDLTarget, results []client.QueryResult) []client.DDLTarget {
+	var succeeded []client.DDLTarget
+	var currentSchema *string
+
+	for _, res := range results {
+		target, ok := targets[res.QueryIndex]
+		if !ok || res.Error != nil {
+			continue
+		}
+
+		if target.Schema == "" && !target.Catalog {
+			if currentSchema == nil {
+				// if the current schema is unknown, the whole top level of the catalog is fetched again.
+				schema, _ := m.c.CurrentSchema(ctx)
+				currentSchema = &schema
+			}
+			target.Schema = *currentSchema
+		}
+
+		succeeded = append(succeeded, target)
+	}
+
+	return succeeded
+}
+
 // prepareQueriesForExecution functions splits the text coming from the text editor by ';',
 // into multiple queries,
 // then, it removes the leading and trailing white spaces from every query.
@@ -99,9 +99,9 @@ func fetchProfilesCmd() tea.Cmd {
 		var items []list.Item
 		for name, profile := range profiles {
 			var label string
-			// SQLite is the only special case, since the database is a file.
-			if profile.Driver == drivers.SQLite {
-				label = fmt.Sprintf("%s (sqlite://%s)", name, profile.Host)
+			// SQLite and DuckDB are the special cases, since the database is a file.
+			if profile.Driver == drivers.SQLite || profile.Driver == drivers.DuckDB {
+				label = fmt.Sprintf("%s (%s://%s)", name, profile.Driver, profile.Host)
 			} else {
 				label = fmt.Sprintf("%s (%s://%s@%s)", name, profile.Driver, profile.User, profile.Host)
 			}
@@ -222,6 +222,7 @@ func Run() (command.Options, error) {
 
 	// add the password to the profile.
 	profile.Pass = pass
+	profile.Profile = m.selectedOption
 
 	// if the profile contains ssh credentials.
 	if profile.SSHUser != "" {
@@ -237,6 +238,12 @@ func Run() (command.Options, error) {
 		}
 	}
 
+	// Get the passwords of the ssh jumps, if any.
+	profile.SSHJumps, err = databaseProfiles.SSHJumpPasswords(m.selectedOption, profile.SSHJumps)
+	if err != nil {
+		return command.Options{}, err
+	}
+
 	return profile, nil
 }
 
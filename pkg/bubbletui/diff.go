@@ -0,0 +1,171 @@
+package bubbletui
+
+import (
+	"bytes"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/viewport"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/danvergara/dblab/internal/schemadiff"
+)
+
+var (
+	diffMissingStyle = lipgloss.NewStyle().Foreground(mutedGreen)
+	diffExtraStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF5555"))
+	diffChangedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#F1C40F"))
+	diffCommentStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#777777"))
+)
+
+// diff model custom keys.
+type diffKeyMap struct {
+	toggle key.Binding
+	quit   key.Binding
+}
+
+func newDiffKeyMap() diffKeyMap {
+	return diffKeyMap{
+		toggle: key.NewBinding(
+			key.WithKeys("tab"),
+			key.WithHelp("tab", "report/migration"),
+		),
+		quit: key.NewBinding(
+			key.WithKeys("q", "esc", "ctrl+c"),
+			key.WithHelp("q", "quit"),
+		),
+	}
+}
+
+// DiffModel shows the differences between two databases, and the migration script that fixes them.
+type DiffModel struct {
+	title         string
+	report        string
+	migration     string
+	showMigration bool
+	viewport      viewport.Model
+	keys          diffKeyMap
+	width, height int
+}
+
+// NewDiffModel returns the model of the diff view, given the title shown above the report.
+func NewDiffModel(title string, report schemadiff.Report) (*DiffModel, error) {
+	var out bytes.Buffer
+	if err := report.Write(&out); err != nil {
+		return nil, err
+	}
+
+	// the report is still worth showing when the migration script can't be written.
+	migration, err := report.Migration()
+	if err != nil {
+		migration = diffExtraStyle.Render("The migration script could not be written:\n" + err.Error())
+	} else {
+		migration = colorMigration(migration)
+	}
+
+	m := &DiffModel{
+		title:     title,
+		report:    colorReport(out.String()),
+		migration: migration,
+		viewport:  viewport.New(),
+		keys:      newDiffKeyMap(),
+	}
+	m.viewport.SetContent(m.report)
+
+	return m, nil
+}
+
+// RunDiff function shows the diff view until the user quits.
+func RunDiff(title string, report schemadiff.Report) error {
+	m, err := NewDiffModel(title, report)
+	if err != nil {
+		return err
+	}
+
+	_, err = tea.NewProgram(m).Run()
+	return err
+}
+
+func (m *DiffModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update method switches between the report and the migration script, and scrolls them.
+func (m *DiffModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.viewport.SetWidth(m.width - 2)
+		// leaves room for the title and the footer.
+		m.viewport.SetHeight(max(m.height-6, 1))
+	case tea.KeyPressMsg:
+		switch {
+		case key.Matches(msg, m.keys.quit):
+			return m, tea.Quit
+		case key.Matches(msg, m.keys.toggle):
+			m.showMigration = !m.showMigration
+			if m.showMigration {
+				m.viewport.SetContent(m.migration)
+			} else {
+				m.viewport.SetContent(m.report)
+			}
+			m.viewport.GotoTop()
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+func (m *DiffModel) View() tea.View {
+	var v tea.View
+	v.AltScreen = true
+
+	section := "Differences"
+	if m.showMigration {
+		section = "Migration script"
+	}
+
+	title := titleStyle.Width(max(m.width-2, 0)).Render(m.title + " - " + section)
+	footer := footerStyle.Render(strings.Join([]string{
+		m.keys.toggle.Help().Key + " " + m.keys.toggle.Help().Desc,
+		"↑/↓ scroll",
+		m.keys.quit.Help().Key + " " + m.keys.quit.Help().Desc,
+	}, " • "))
+
+	v.SetContent(lipgloss.JoinVertical(lipgloss.Left, title, m.viewport.View(), footer))
+	return v
+}
+
+// colorReport function colors the lines of the report by the kind of difference.
+func colorReport(report string) string {
+	lines := strings.Split(report, "\n")
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, string(schemadiff.Missing)):
+			lines[i] = diffMissingStyle.Render(line)
+		case strings.HasPrefix(line, string(schemadiff.Extra)):
+			lines[i] = diffExtraStyle.Render(line)
+		case strings.HasPrefix(line, string(schemadiff.Changed)):
+			lines[i] = diffChangedStyle.Render(line)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// colorMigration function dims the comments of the migration script.
+func colorMigration(script string) string {
+	lines := strings.Split(script, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, "--") {
+			lines[i] = diffCommentStyle.Render(line)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
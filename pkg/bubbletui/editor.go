@@ -4,6 +4,7 @@ import (
 	"io"
 	"os"
 	"strings"
+	"unicode"
 
 	"charm.land/bubbles/v2/key"
 	"charm.land/bubbles/v2/textarea"
@@ -32,6 +33,17 @@ type Editor struct {
 	register   string
 	pendingCmd string
 	dump       io.Writer
+	// words holds the names of the objects of the catalog, used to complete the word before the cursor.
+	words      []string
+	completion completion
+}
+
+// completion struct keeps track of the matches of the word being completed,
+// so pressing the complete key again cycles through them.
+type completion struct {
+	matches  []string
+	index    int
+	inserted string
 }
 
 func NewEditor(kb *command.TUIKeyMap) Editor {
@@ -85,7 +97,16 @@ func (e Editor) Update(msg tea.Msg) (Editor, tea.Cmd) {
 	switch msg := msg.(type) {
 	case querySelectedMsg:
 		e.editor.SetValue(msg.QueryText)
+	case insertTextMsg:
+		e.editor.InsertString(msg.text)
+	case catalogWordsMsg:
+		e.words = msg.words
+		return e, nil
 	case tea.KeyPressMsg:
+		if !key.Matches(msg, e.bindings.Editor.Complete) {
+			e.completion = completion{}
+		}
+
 		if key.Matches(msg, e.bindings.Editor.ExecuteQuery) {
 			editorContent := e.editor.Value()
 
@@ -209,6 +230,9 @@ func (e Editor) Update(msg tea.Msg) (Editor, tea.Cmd) {
 				e.editor.SetStyles(styles)
 				e.editor, _ = e.editor.Update(tea.KeyPressMsg{Code: tea.KeyLeft})
 				return e, nil
+			case key.Matches(msg, e.bindings.Editor.Complete):
+				e.complete()
+				return e, nil
 			}
 		}
 	}
@@ -266,3 +290,106 @@ func (e *Editor) pasteAfter() {
 		e.editor.SetValue(strings.Join(lines, "\n"))
 	}
 }
+
+// complete method completes the word before the cursor with the names of the catalog.
+// A unique match is completed right away, otherwise the longest common prefix of the matches is,
+// and the next presses cycle through the matches.
+func (e *Editor) complete() {
+	if len(e.completion.matches) > 1 {
+		e.completion.index = (e.completion.index + 1) % len(e.completion.matches)
+		e.replaceWord(e.completion.inserted, e.completion.matches[e.completion.index])
+		return
+	}
+
+	lines := strings.Split(e.editor.Value(), "\n")
+	row := e.editor.Line()
+	if row < 0 || row >= len(lines) {
+		return
+	}
+
+	word := wordBeforeCursor(lines[row], e.editor.Column())
+	if word == "" {
+		return
+	}
+
+	matches := completeWord(word, e.words)
+	switch len(matches) {
+	case 0:
+		return
+	case 1:
+		e.replaceWord(word, matches[0])
+		return
+	}
+
+	e.completion = completion{matches: matches, index: -1, inserted: word}
+	if prefix := commonPrefix(matches); len(prefix) > len(word) {
+		e.replaceWord(word, prefix)
+		return
+	}
+
+	e.completion.index = 0
+	e.replaceWord(word, matches[0])
+}
+
+// replaceWord method replaces the word right before the cursor with the given one.
+func (e *Editor) replaceWord(word, replacement string) {
+	for range []rune(word) {
+		e.editor, _ = e.editor.Update(tea.KeyPressMsg{Code: tea.KeyBackspace})
+	}
+
+	e.editor.InsertString(replacement)
+	e.completion.inserted = replacement
+}
+
+// wordBeforeCursor function returns the identifier that ends at the given column of the line,
+// a name qualified by its schema included.
+func wordBeforeCursor(line string, column int) string {
+	runes := []rune(line)
+	if column > len(runes) {
+		column = len(runes)
+	}
+
+	start := column
+	for start > 0 && isWordRune(runes[start-1]) {
+		start--
+	}
+
+	return string(runes[start:column])
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.' || r == '$'
+}
+
+// completeWord function returns the words that start with the given one, regardless of the case.
+func completeWord(word string, words []string) []string {
+	var matches []string
+
+	prefix := strings.ToLower(word)
+	for _, w := range words {
+		if len(w) > len(word) && strings.HasPrefix(strings.ToLower(w), prefix) {
+			matches = append(matches, w)
+		}
+	}
+
+	return matches
+}
+
+// commonPrefix function returns the longest prefix shared by the given words, regardless of the case.
+func commonPrefix(words []string) string {
+	if len(words) == 0 {
+		return ""
+	}
+
+	prefix := []rune(words[0])
+	for _, w := range words[1:] {
+		runes := []rune(w)
+		n := 0
+		for n < len(prefix) && n < len(runes) && unicode.ToLower(prefix[n]) == unicode.ToLower(runes[n]) {
+			n++
+		}
+		prefix = prefix[:n]
+	}
+
+	return string(prefix)
+}
@@ -28,3 +28,40 @@ func TestQueryAtCursor(t *testing.T) {
 		})
 	}
 }
+
+func TestWordBeforeCursor(t *testing.T) {
+	tests := []struct {
+		name   string
+		line   string
+		column int
+		want   string
+	}{
+		{name: "end of line", line: "SELECT * FROM us", column: 16, want: "us"},
+		{name: "qualified name", line: "SELECT * FROM public.us", column: 23, want: "public.us"},
+		{name: "middle of line", line: "SELECT na FROM users", column: 9, want: "na"},
+		{name: "after a space", line: "SELECT ", column: 7, want: ""},
+		{name: "column out of range", line: "us", column: 10, want: "us"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, wordBeforeCursor(tt.line, tt.column))
+		})
+	}
+}
+
+func TestCompleteWord(t *testing.T) {
+	words := []string{"orders", "public", "public.orders", "public.users", "users", "users_audit"}
+
+	assert.Equal(t, []string{"users", "users_audit"}, completeWord("us", words))
+	assert.Equal(t, []string{"users_audit"}, completeWord("users", words))
+	assert.Equal(t, []string{"public.orders", "public.users"}, completeWord("PUBLIC.", words))
+	assert.Empty(t, completeWord("invoices", words))
+}
+
+func TestCommonPrefix(t *testing.T) {
+	assert.Equal(t, "users", commonPrefix([]string{"users", "users_audit"}))
+	assert.Equal(t, "public.", commonPrefix([]string{"public.orders", "public.users"}))
+	assert.Equal(t, "Ord", commonPrefix([]string{"Orders", "ordinal"}))
+	assert.Equal(t, "", commonPrefix(nil))
+}
@@ -0,0 +1,104 @@
+package bubbletui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/danvergara/dblab/internal/erd"
+	"github.com/danvergara/dblab/pkg/client"
+)
+
+// diagramSavedMsg reports the files the entity-relationship diagram was written to.
+type diagramSavedMsg struct {
+	paths []string
+}
+
+// diagramSaveErrMsg reports that the entity-relationship diagram could not be generated or written.
+type diagramSaveErrMsg struct{ err error }
+
+// exportDiagram method writes the entity-relationship diagram of the focused node asynchronously,
+// in every supported format, to the current directory.
+// A schema, or the database for the ones with no schemas, is drawn whole,
+// a table is drawn along with the tables it references and the ones referencing it.
+func (s *SidebarViewport) exportDiagram() tea.Cmd {
+	focused := s.dbTree.GetFocusedNode()
+	if focused == nil || focused.Data() == nil {
+		return nil
+	}
+
+	node := *focused.Data()
+
+	var schema, table string
+	switch node.Type {
+	case "database":
+	case "schema":
+		schema = node.EntityName
+	case "table":
+		schema, table = node.ParentName, node.EntityName
+	default:
+		return nil
+	}
+
+	c := s.c
+	return func() tea.Msg {
+		snapshot, err := c.Snapshot(context.Background(), schema)
+		if err != nil {
+			return diagramSaveErrMsg{err: err}
+		}
+
+		var tables []string
+		if table != "" {
+			tables = erd.Neighbors(snapshot, table)
+		}
+
+		diagram, err := erd.New(snapshot, tables...)
+		if err != nil {
+			return diagramSaveErrMsg{err: err}
+		}
+
+		name := diagram.Name
+		if table != "" {
+			name = joinPath(snapshot.Schema, table)
+		}
+
+		return saveDiagram(diagram, diagramFileName(name))
+	}
+}
+
+// saveDiagram function writes the diagram in every supported format, to files named after the given name.
+func saveDiagram(diagram erd.Diagram, name string) tea.Msg {
+	paths := make([]string, 0, len(erd.Formats))
+	for _, format := range erd.Formats {
+		text, err := diagram.Render(format)
+		if err != nil {
+			return diagramSaveErrMsg{err: err}
+		}
+
+		path := name + format.Extension()
+		if err := os.WriteFile(path, []byte(text), 0644); err != nil {
+			return diagramSaveErrMsg{err: fmt.Errorf("failed to write to the file: %w", err)}
+		}
+		paths = append(paths, path)
+	}
+
+	return diagramSavedMsg{paths: paths}
+}
+
+// diagramFileName function returns the name of the files a diagram is saved to, without the extension.
+func diagramFileName(name string) string {
+	return strings.NewReplacer("/", "_", "\\", "_").Replace(name) + ".erd"
+}
+
+// relationsText function returns the text of the Relations tab of a table:
+// the tables it references and the ones referencing it, or why they could not be read.
+func relationsText(table client.TableRef, foreignKeys []client.ForeignKey, err error) string {
+	if err != nil {
+		return fmt.Sprintf("the relations of %s could not be read: %s", table.Name, err)
+	}
+
+	return erd.ASCII(table, foreignKeys)
+}
@@ -0,0 +1,43 @@
+package bubbletui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/danvergara/dblab/internal/erd"
+	"github.com/danvergara/dblab/pkg/client"
+)
+
+func TestSaveDiagram(t *testing.T) {
+	snapshot := &client.Snapshot{
+		Schema: "public",
+		Tables: []client.TableSnapshot{
+			{Name: "users", Columns: []client.Column{{Name: "id", DataType: "integer", PrimaryKey: true}}},
+		},
+	}
+
+	diagram, err := erd.New(snapshot)
+	require.NoError(t, err)
+
+	name := filepath.Join(t.TempDir(), diagramFileName("public"))
+	msg := saveDiagram(diagram, name)
+
+	saved, ok := msg.(diagramSavedMsg)
+	require.True(t, ok, "unexpected message %#v", msg)
+	assert.Equal(t, []string{name + ".mmd", name + ".dot", name + ".puml"}, saved.paths)
+
+	for _, path := range saved.paths {
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "users")
+	}
+}
+
+func TestDiagramFileName(t *testing.T) {
+	assert.Equal(t, "public.orders.erd", diagramFileName("public.orders"))
+	assert.Equal(t, "a_b.erd", diagramFileName("a/b"))
+}
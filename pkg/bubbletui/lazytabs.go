@@ -0,0 +1,182 @@
+package bubbletui
+
+import (
+	"context"
+	"fmt"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/danvergara/dblab/pkg/client"
+)
+
+// the tabs of a table, in the order they're shown.
+const (
+	dataTab = iota
+	columnsTab
+	indexesTab
+	constraintsTab
+	ddlTab
+	relationsTab
+	statsTab
+	grantsTab
+)
+
+// lazyTabs are the tabs of a table read once they're opened, rather than along with the table,
+// since reading them may take long or extra privileges.
+var lazyTabs = []int{ddlTab, relationsTab, statsTab, grantsTab}
+
+// loadTabMsg asks to read the content of a tab of a table.
+type loadTabMsg struct {
+	tab   int
+	table client.TableRef
+	// exactCount asks to count the rows of the table along with its statistics, which scans the table.
+	exactCount bool
+}
+
+// tabLoadedMsg carries the content of a tab of a table, either a text or the columns and rows of a table.
+type tabLoadedMsg struct {
+	tab     int
+	table   client.TableRef
+	text    string
+	columns []string
+	rows    [][]string
+	// foreignKeys are the relations of the table, read along with the Relations tab.
+	foreignKeys    []client.ForeignKey
+	foreignKeysErr error
+}
+
+// loadTabCmd function asks to read the content of a tab of a table asynchronously.
+func loadTabCmd(tab int, table client.TableRef, exactCount bool) tea.Cmd {
+	return func() tea.Msg {
+		return loadTabMsg{tab: tab, table: table, exactCount: exactCount}
+	}
+}
+
+// resetLazyTabs method marks the tabs read on demand as not read, for the table just shown.
+func (r *ResultSet) resetLazyTabs() {
+	r.unloadedTabs = make(map[int]bool, len(lazyTabs))
+	for _, tab := range lazyTabs {
+		r.unloadedTabs[tab] = true
+	}
+}
+
+// loadActiveTab method asks to read the content of the active tab, if it's read on demand and was not read yet.
+func (r *ResultSet) loadActiveTab() tea.Cmd {
+	cmd := r.loadTab(r.activeTab)
+	if cmd != nil {
+		r.viewport.SetContent("loading...")
+	}
+
+	return cmd
+}
+
+// loadTab method asks to read the content of a tab, if it's read on demand and was not read yet.
+func (r *ResultSet) loadTab(tab int) tea.Cmd {
+	if r.table.Name == "" || !r.unloadedTabs[tab] {
+		return nil
+	}
+
+	delete(r.unloadedTabs, tab)
+	return loadTabCmd(tab, r.table, false)
+}
+
+// awaitForeignKeys method asks to read the foreign keys of the table, if they were not read yet,
+// so the given key, which needs them, is handled again once they're read.
+// It reports whether the key has to wait for them.
+func (r *ResultSet) awaitForeignKeys(msg tea.KeyPressMsg) (tea.Cmd, bool) {
+	if r.foreignKeysRead {
+		return nil, false
+	}
+
+	r.pendingKey = &msg
+	r.viewport.SetContent("reading the foreign keys...")
+
+	return r.loadTab(relationsTab), true
+}
+
+// setTabContent method fills a tab read on demand,
+// unless another table was opened while it was read.
+// The key waiting for the foreign keys is handled once they're read.
+func (r *ResultSet) setTabContent(msg tabLoadedMsg) tea.Cmd {
+	if msg.table != r.table || msg.tab >= len(r.tablesMetadata) {
+		return nil
+	}
+
+	delete(r.unloadedTabs, msg.tab)
+
+	switch panel := r.tablesMetadata[msg.tab].(type) {
+	case *TextPanel:
+		panel.SetContent(msg.text)
+	case *TablePanel:
+		columns, rows := populateTable(msg.columns, msg.rows)
+		panel.table.SetRows(nil)
+		panel.table.SetColumns(columns)
+		panel.table.SetRows(rows)
+	}
+
+	if msg.tab == r.activeTab {
+		r.viewport.SetContent(r.tablesMetadata[r.activeTab].View().Content)
+	}
+
+	if msg.tab != relationsTab {
+		return nil
+	}
+
+	r.foreignKeys, r.foreignKeysErr, r.foreignKeysRead = msg.foreignKeys, msg.foreignKeysErr, true
+	if r.pendingKey == nil {
+		return nil
+	}
+
+	pending := *r.pendingKey
+	r.pendingKey = nil
+
+	cmd, _ := r.updateNavigation(pending)
+	return cmd
+}
+
+// runLoadTab reads the content of a tab of a table asynchronously.
+// The content tells why it could not be read, if so, rather than failing the whole table.
+func (m *Model) runLoadTab(msg loadTabMsg) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		loaded := tabLoadedMsg{tab: msg.tab, table: msg.table}
+
+		switch msg.tab {
+		case ddlTab:
+			// some databases need extra privileges to build the DDL.
+			ddl, err := m.c.TableDDL(ctx, msg.table)
+			if err != nil {
+				ddl = fmt.Sprintf("-- the DDL of %s could not be built: %s", msg.table.Name, err)
+			}
+
+			loaded.text = ddl
+		case relationsTab:
+			// the relations are not worth failing the whole table for.
+			foreignKeys, err := m.c.TableForeignKeys(ctx, msg.table)
+			loaded.text = relationsText(msg.table, foreignKeys, err)
+			loaded.foreignKeys, loaded.foreignKeysErr = foreignKeys, err
+		case statsTab:
+			// some of the statistics are only visible to the owner of the table.
+			stats, err := m.c.TableStats(ctx, msg.table)
+			if err != nil {
+				stats = []client.Stat{{Name: "error", Value: fmt.Sprintf("the statistics of %s could not be read: %s", msg.table.Name, err)}}
+			}
+
+			if msg.exactCount {
+				stats = append([]client.Stat{m.c.ExactRowCount(ctx, msg.table)}, stats...)
+			}
+
+			loaded.columns, loaded.rows = []string{"statistic", "value"}, statsRows(stats)
+		case grantsTab:
+			// the catalogs listing the privileges take extra privileges themselves.
+			grants, err := m.c.TableGrants(ctx, msg.table)
+			if err != nil {
+				grants = client.Table{Columns: []string{"error"}, Rows: [][]string{{fmt.Sprintf("the privileges on %s could not be read: %s", msg.table.Name, err)}}}
+			}
+
+			loaded.columns, loaded.rows = grants.Columns, grants.Rows
+		}
+
+		return loaded
+	}
+}
@@ -0,0 +1,364 @@
+package bubbletui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/Digital-Shane/treeview/v2"
+
+	"github.com/danvergara/dblab/pkg/client"
+)
+
+// lockNode is a node of the blocking tree: a section, a session or one of its locks.
+type lockNode struct {
+	ID       string
+	Name     string
+	Children []*lockNode
+}
+
+// lockTreeProvider builds the blocking tree out of the lock nodes.
+type lockTreeProvider struct{}
+
+func (lockTreeProvider) ID(n *lockNode) string            { return n.ID }
+func (lockTreeProvider) Name(n *lockNode) string          { return n.Name }
+func (lockTreeProvider) Children(n *lockNode) []*lockNode { return n.Children }
+
+// locksMsg carries the locks of the server.
+type locksMsg struct {
+	locks []client.Lock
+	err   error
+}
+
+// lock viewer custom keys.
+type locksKeyMap struct {
+	refresh key.Binding
+	back    key.Binding
+}
+
+func newLocksKeyMap() locksKeyMap {
+	return locksKeyMap{
+		refresh: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "refresh"),
+		),
+		back: key.NewBinding(
+			key.WithKeys("esc", "q"),
+			key.WithHelp("esc", "back"),
+		),
+	}
+}
+
+// LocksModel is the lock viewer: it shows who is blocking whom as a tree, the sessions holding the locks first,
+// with the sessions waiting for them under them, followed by the rest of the locks.
+type LocksModel struct {
+	c    *client.Client
+	keys locksKeyMap
+
+	tree   *treeview.TuiTreeModel[*lockNode]
+	status string
+	err    error
+
+	width, height int
+}
+
+// NewLocksModel returns the model of the lock viewer of the server the client is connected to.
+func NewLocksModel(c *client.Client) *LocksModel {
+	return &LocksModel{
+		c:    c,
+		keys: newLocksKeyMap(),
+	}
+}
+
+// SetSize method is used to set the model size when the main tui model routes the size from the tea.WindowSizeMsg message to this model.
+func (l *LocksModel) SetSize(width, height int) {
+	l.width = width
+	l.height = height
+	if l.tree != nil {
+		l.tree = newLockTreeModel(l.tree.Tree, l.width, l.height)
+	}
+}
+
+// Open method loads the locks of the server.
+func (l *LocksModel) Open() tea.Cmd {
+	l.err = nil
+	l.status = "loading the locks of the server..."
+
+	c := l.c
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		locks, err := c.Locks(ctx)
+		return locksMsg{locks: locks, err: err}
+	}
+}
+
+func (l *LocksModel) Update(msg tea.Msg) (*LocksModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case locksMsg:
+		if msg.err != nil {
+			l.err = msg.err
+			return l, nil
+		}
+
+		tree, err := newLockTree(context.Background(), blockingTree(msg.locks))
+		if err != nil {
+			l.err = err
+			return l, nil
+		}
+
+		l.tree = newLockTreeModel(tree, l.width, l.height)
+		l.err = nil
+		l.status = fmt.Sprintf("%d locks, refreshed at %s", len(msg.locks), time.Now().Format(time.TimeOnly))
+		return l, nil
+	case tea.KeyPressMsg:
+		switch {
+		case key.Matches(msg, l.keys.back):
+			return l, func() tea.Msg { return backToNormalMsg{} }
+		case key.Matches(msg, l.keys.refresh):
+			return l, l.Open()
+		}
+
+		if l.tree != nil {
+			updatedModel, cmd := l.tree.Update(msg)
+			if tree, ok := updatedModel.(*treeview.TuiTreeModel[*lockNode]); ok {
+				l.tree = tree
+			}
+			return l, cmd
+		}
+	}
+
+	return l, nil
+}
+
+// View method renders the blocking tree, along with the status and the keys.
+func (l *LocksModel) View() tea.View {
+	var v tea.View
+	v.AltScreen = true
+
+	var b strings.Builder
+
+	b.WriteString(lipgloss.NewStyle().Background(darkPurple).Foreground(hiMagenta).Bold(true).Padding(0, 1).Render("Locks of the server"))
+	b.WriteString("\n\n")
+
+	if l.tree != nil {
+		b.WriteString(l.tree.View().Content)
+		b.WriteString("\n\n")
+	}
+
+	if l.err != nil {
+		b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("#FF0000")).Bold(true).Render(l.err.Error()))
+	} else {
+		b.WriteString(lipgloss.NewStyle().Foreground(mutedGreen).Render(l.status))
+	}
+	b.WriteString("\n")
+
+	parts := make([]string, 0, 2)
+	for _, binding := range []key.Binding{l.keys.refresh, l.keys.back} {
+		parts = append(parts, fmt.Sprintf("%s %s", binding.Help().Key, binding.Help().Desc))
+	}
+	b.WriteString(lipgloss.NewStyle().Foreground(whiteText).Render("enter toggle • " + strings.Join(parts, " • ")))
+
+	v.SetContent(lipgloss.NewStyle().Padding(1, 2).Render(b.String()))
+	return v
+}
+
+// newLockTree function builds the blocking tree, with every node expanded.
+func newLockTree(ctx context.Context, roots []*lockNode) (*treeview.Tree[*lockNode], error) {
+	tree, err := treeview.NewTreeFromNestedData[*lockNode](
+		ctx,
+		roots,
+		lockTreeProvider{},
+		treeview.WithProvider(treeview.NewDefaultNodeProvider(
+			treeview.WithStyleRule(
+				func(n *treeview.Node[*lockNode]) bool { return true },
+				lipgloss.NewStyle().
+					Foreground(whiteText).
+					PaddingLeft(2),
+				lipgloss.NewStyle().
+					Foreground(cyberGreen).
+					Background(darkPurple).
+					Bold(true).
+					PaddingLeft(1),
+			),
+		)),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for nodeInfo, err := range tree.All(ctx) {
+		if err != nil {
+			return nil, err
+		}
+		nodeInfo.Node.Expand()
+	}
+
+	return tree, nil
+}
+
+func newLockTreeModel(tree *treeview.Tree[*lockNode], width, height int) *treeview.TuiTreeModel[*lockNode] {
+	keyMap := treeview.DefaultKeyMap()
+	keyMap.SearchStart = nil
+	keyMap.Toggle = []string{"enter"}
+
+	// the title, the status and the keys take 8 lines along with the padding.
+	return treeview.NewTuiTreeModel(tree,
+		treeview.WithTuiWidth[*lockNode](max(width-4, 0)),
+		treeview.WithTuiHeight[*lockNode](max(height-8, 0)),
+		treeview.WithTuiKeyMap[*lockNode](keyMap),
+		treeview.WithTuiDisableNavBar[*lockNode](true),
+		treeview.WithTuiAllowResize[*lockNode](false),
+	)
+}
+
+// lockSession is a session of the server, along with its locks.
+type lockSession struct {
+	client.Lock
+	locks     []client.Lock
+	blockedBy []string
+}
+
+// blockingTree function builds the blocking chains out of the locks of the server:
+// the sessions blocking others, but not blocked themselves, are the roots, with the sessions waiting for them under them.
+// The sessions that block each other, i.e. a deadlock, are shown as roots too, with the cycle marked.
+// Each session lists its locks, the ones it waits for marked. The sessions out of any chain are listed apart.
+func blockingTree(locks []client.Lock) []*lockNode {
+	sessions := make(map[string]*lockSession)
+	var ids []string
+	for _, lock := range locks {
+		s, ok := sessions[lock.SessionID]
+		if !ok {
+			s = &lockSession{Lock: lock}
+			sessions[lock.SessionID] = s
+			ids = append(ids, lock.SessionID)
+		}
+
+		s.locks = append(s.locks, lock)
+		for _, blocker := range lock.BlockedBy {
+			if !slices.Contains(s.blockedBy, blocker) {
+				s.blockedBy = append(s.blockedBy, blocker)
+			}
+		}
+	}
+
+	// waiters are the sessions blocked by each session.
+	waiters := make(map[string][]string)
+	for _, id := range ids {
+		for _, blocker := range sessions[id].blockedBy {
+			waiters[blocker] = append(waiters[blocker], id)
+		}
+	}
+
+	chained := make(map[string]bool)
+	var chains []*lockNode
+
+	var sessionNode func(parentID, id string, path []string) *lockNode
+	sessionNode = func(parentID, id string, path []string) *lockNode {
+		nodeID := parentID + "/" + id
+		chained[id] = true
+
+		s, ok := sessions[id]
+		if !ok {
+			// a blocker holding no lock in the list, e.g. it's on another database.
+			return &lockNode{ID: nodeID, Name: fmt.Sprintf("session %s", id)}
+		}
+
+		if slices.Contains(path, id) {
+			return &lockNode{ID: nodeID, Name: fmt.Sprintf("session %s (deadlock)", id)}
+		}
+
+		node := &lockNode{ID: nodeID, Name: sessionLabel(s)}
+		for i, lock := range s.locks {
+			node.Children = append(node.Children, &lockNode{ID: fmt.Sprintf("%s#%d", nodeID, i), Name: lockLabel(lock)})
+		}
+
+		for _, waiter := range waiters[id] {
+			node.Children = append(node.Children, sessionNode(nodeID, waiter, slices.Concat(path, []string{id})))
+		}
+
+		return node
+	}
+
+	for _, id := range ids {
+		if len(waiters[id]) > 0 && len(sessions[id].blockedBy) == 0 {
+			chains = append(chains, sessionNode("chains", id, nil))
+		}
+	}
+
+	// the blockers missing from the list, which head their chains too.
+	var missing []string
+	for _, id := range ids {
+		for _, blocker := range sessions[id].blockedBy {
+			if _, ok := sessions[blocker]; !ok && !slices.Contains(missing, blocker) {
+				missing = append(missing, blocker)
+			}
+		}
+	}
+	for _, id := range missing {
+		node := &lockNode{ID: "chains/" + id, Name: fmt.Sprintf("session %s", id)}
+		for _, waiter := range waiters[id] {
+			node.Children = append(node.Children, sessionNode(node.ID, waiter, []string{id}))
+		}
+		chains = append(chains, node)
+	}
+
+	// the sessions blocking each other have no head, so the cycles are started anywhere.
+	for _, id := range ids {
+		if !chained[id] && len(sessions[id].blockedBy) > 0 {
+			chains = append(chains, sessionNode("chains", id, nil))
+		}
+	}
+
+	var others []*lockNode
+	for _, id := range ids {
+		if !chained[id] {
+			others = append(others, sessionNode("others", id, nil))
+		}
+	}
+
+	var roots []*lockNode
+	if len(chains) > 0 {
+		roots = append(roots, &lockNode{ID: "chains", Name: fmt.Sprintf("Blocking chains (%d)", len(chains)), Children: chains})
+	}
+	if len(others) > 0 {
+		roots = append(roots, &lockNode{ID: "others", Name: fmt.Sprintf("Other sessions holding locks (%d)", len(others)), Children: others})
+	}
+	if len(roots) == 0 {
+		roots = append(roots, &lockNode{ID: "none", Name: "No locks held"})
+	}
+
+	return roots
+}
+
+// sessionLabel function describes a session of the blocking tree, e.g. session 42 · app · active · 1m2s · UPDATE orders ...
+func sessionLabel(s *lockSession) string {
+	parts := []string{fmt.Sprintf("session %s", s.SessionID)}
+	for _, part := range []string{s.User, s.State, formatDuration(s.Wait), strings.Join(strings.Fields(s.Query), " ")} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+
+	label := []rune(strings.Join(parts, " · "))
+	if len(label) > 120 {
+		return string(label[:117]) + "..."
+	}
+
+	return string(label)
+}
+
+// lockLabel function describes a lock of a session, e.g. waits for AccessExclusiveLock on public.orders.
+func lockLabel(lock client.Lock) string {
+	if lock.Granted {
+		return fmt.Sprintf("holds %s on %s", lock.Mode, lock.Object)
+	}
+
+	return fmt.Sprintf("waits for %s on %s", lock.Mode, lock.Object)
+}
@@ -0,0 +1,62 @@
+package bubbletui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/danvergara/dblab/pkg/client"
+)
+
+// lockTreeNames function returns the names of the nodes of the tree, indented by their depth.
+func lockTreeNames(nodes []*lockNode, depth int) []string {
+	var names []string
+	for _, n := range nodes {
+		names = append(names, strings.Repeat("  ", depth)+n.Name)
+		names = append(names, lockTreeNames(n.Children, depth+1)...)
+	}
+	return names
+}
+
+func TestBlockingTree(t *testing.T) {
+	locks := []client.Lock{
+		{SessionID: "10", User: "admin", State: "idle in transaction", Mode: "AccessExclusiveLock", Granted: true, Object: "orders"},
+		{SessionID: "11", BlockedBy: []string{"10"}, User: "app", State: "active", Mode: "RowExclusiveLock", Object: "orders", Query: "UPDATE orders SET note = ''"},
+		{SessionID: "12", BlockedBy: []string{"11"}, User: "app", State: "active", Mode: "AccessShareLock", Object: "orders", Query: "SELECT 1"},
+		{SessionID: "13", User: "report", State: "active", Mode: "AccessShareLock", Granted: true, Object: "invoices"},
+	}
+
+	assert.Equal(t, []string{
+		"Blocking chains (1)",
+		"  session 10 · admin · idle in transaction · 0s",
+		"    holds AccessExclusiveLock on orders",
+		"    session 11 · app · active · 0s · UPDATE orders SET note = ''",
+		"      waits for RowExclusiveLock on orders",
+		"      session 12 · app · active · 0s · SELECT 1",
+		"        waits for AccessShareLock on orders",
+		"Other sessions holding locks (1)",
+		"  session 13 · report · active · 0s",
+		"    holds AccessShareLock on invoices",
+	}, lockTreeNames(blockingTree(locks), 0))
+}
+
+func TestBlockingTree_Deadlock(t *testing.T) {
+	locks := []client.Lock{
+		{SessionID: "20", BlockedBy: []string{"21"}, Mode: "ShareLock", Object: "transactionid"},
+		{SessionID: "21", BlockedBy: []string{"20"}, Mode: "ShareLock", Object: "transactionid"},
+	}
+
+	assert.Equal(t, []string{
+		"Blocking chains (1)",
+		"  session 20 · 0s",
+		"    waits for ShareLock on transactionid",
+		"    session 21 · 0s",
+		"      waits for ShareLock on transactionid",
+		"      session 20 (deadlock)",
+	}, lockTreeNames(blockingTree(locks), 0))
+}
+
+func TestBlockingTree_NoLocks(t *testing.T) {
+	assert.Equal(t, []string{"No locks held"}, lockTreeNames(blockingTree(nil), 0))
+}
@@ -0,0 +1,405 @@
+package bubbletui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/table"
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/danvergara/dblab/pkg/client"
+)
+
+// nullValue is the text of the NULL values of a result set.
+const nullValue = "<nil>"
+
+// location is a table opened in the result set, along with the conditions its rows are filtered by.
+type location struct {
+	table      client.TableRef
+	conditions []client.Condition
+}
+
+func (l location) equal(other location) bool {
+	return l.table == other.table && slices.Equal(l.conditions, other.conditions)
+}
+
+// String method describes the location, e.g. public.orders [customer_id = 5].
+func (l location) String() string {
+	text := joinPath(l.table.Schema, l.table.Name)
+	if len(l.conditions) > 0 {
+		text += " " + conditionsLabel(l.conditions)
+	}
+
+	return text
+}
+
+// openRowsMsg asks to open the rows of a table matching some conditions, e.g. the row referenced by a foreign key.
+type openRowsMsg struct {
+	location location
+}
+
+// navigation is the history of the tables opened in the result set,
+// walked back and forward like the history of a browser.
+type navigation struct {
+	locations []location
+	index     int
+}
+
+// visit method adds a location to the history, dropping the locations ahead of the current one.
+// Visiting the current location again, e.g. when going back or forward, leaves the history as it is.
+func (n *navigation) visit(l location) {
+	if len(n.locations) > 0 && n.locations[n.index].equal(l) {
+		return
+	}
+
+	if len(n.locations) > 0 {
+		n.locations = n.locations[:n.index+1]
+	}
+
+	n.locations = append(n.locations, l)
+	n.index = len(n.locations) - 1
+}
+
+// back method moves to the previous location, if any.
+func (n *navigation) back() (location, bool) {
+	if n.index == 0 || len(n.locations) == 0 {
+		return location{}, false
+	}
+
+	n.index--
+	return n.locations[n.index], true
+}
+
+// forward method moves to the next location, if any.
+func (n *navigation) forward() (location, bool) {
+	if n.index >= len(n.locations)-1 {
+		return location{}, false
+	}
+
+	n.index++
+	return n.locations[n.index], true
+}
+
+// referrer is a table whose rows reference the selected row through a foreign key.
+type referrer struct {
+	foreignKey client.ForeignKey
+	location   location
+}
+
+// referencedRow function returns the location of the row referenced by the foreign key the given column belongs to.
+// The headers are the columns of the row.
+func referencedRow(current client.TableRef, foreignKeys []client.ForeignKey, headers, row []string, column string) (location, error) {
+	for _, fk := range foreignKeys {
+		if fk.Table != current.Name || !slices.ContainsFunc(fk.Columns, func(c string) bool { return strings.EqualFold(c, column) }) {
+			continue
+		}
+
+		conditions, err := rowConditions(fk.Columns, fk.RefColumns, headers, row)
+		if err != nil {
+			return location{}, err
+		}
+
+		target := client.TableRef{Name: fk.RefTable}
+		// the tables are qualified by their schema only on the databases that need it.
+		if current.Schema != "" {
+			target.Schema = fk.RefSchema
+			if target.Schema == "" {
+				target.Schema = current.Schema
+			}
+		}
+
+		return location{table: target, conditions: conditions}, nil
+	}
+
+	return location{}, fmt.Errorf("the column %s is not part of a foreign key of %s", column, current.Name)
+}
+
+// referencingRows function returns the tables referencing the given row, one per foreign key,
+// along with the conditions that match the rows referencing it.
+// The foreign keys whose referenced columns are null on the row are skipped.
+func referencingRows(current client.TableRef, foreignKeys []client.ForeignKey, headers, row []string) []referrer {
+	var referrers []referrer
+	for _, fk := range foreignKeys {
+		if fk.RefTable != current.Name || (current.Schema != "" && fk.RefSchema != "" && !strings.EqualFold(fk.RefSchema, current.Schema)) {
+			continue
+		}
+
+		conditions, err := rowConditions(fk.RefColumns, fk.Columns, headers, row)
+		if err != nil {
+			continue
+		}
+
+		// the foreign keys of a schema are the ones defined on its own tables.
+		target := client.TableRef{Schema: current.Schema, Name: fk.Table}
+		referrers = append(referrers, referrer{foreignKey: fk, location: location{table: target, conditions: conditions}})
+	}
+
+	return referrers
+}
+
+// rowConditions function matches the values of the given columns of a row with the target columns,
+// paired by position, e.g. the columns of a foreign key with the ones it references.
+func rowConditions(columns, targets, headers, row []string) ([]client.Condition, error) {
+	conditions := make([]client.Condition, 0, len(columns))
+	for i, column := range columns {
+		index := slices.IndexFunc(headers, func(h string) bool { return strings.EqualFold(h, column) })
+		if index < 0 || index >= len(row) || i >= len(targets) {
+			return nil, fmt.Errorf("the column %s is not in the result set", column)
+		}
+
+		if row[index] == nullValue {
+			return nil, fmt.Errorf("the column %s is null on the selected row", column)
+		}
+
+		conditions = append(conditions, client.Condition{Column: targets[i], Value: row[index]})
+	}
+
+	return conditions, nil
+}
+
+// conditionsLabel function describes the conditions rows are filtered by, e.g. [customer_id = 5].
+func conditionsLabel(conditions []client.Condition) string {
+	parts := make([]string, 0, len(conditions))
+	for _, condition := range conditions {
+		parts = append(parts, condition.String())
+	}
+
+	return "[" + strings.Join(parts, ", ") + "]"
+}
+
+// updateNavigation method handles the keys that walk through the related rows of the data tab of a table.
+// It reports whether the key was handled.
+func (r *ResultSet) updateNavigation(msg tea.KeyPressMsg) (tea.Cmd, bool) {
+	if r.referrers != nil {
+		return r.updateReferrers(msg), true
+	}
+
+	// the history can be walked from any tab.
+	switch {
+	case key.Matches(msg, r.bindings.Back):
+		if l, ok := r.history.back(); ok {
+			return openRowsCmd(l), true
+		}
+		return nil, true
+	case key.Matches(msg, r.bindings.Forward):
+		if l, ok := r.history.forward(); ok {
+			return openRowsCmd(l), true
+		}
+		return nil, true
+	}
+
+	data, ok := r.dataPanel()
+	if !ok {
+		return nil, false
+	}
+
+	switch {
+	case key.Matches(msg, r.bindings.NextColumn):
+		data.moveColumn(1)
+	case key.Matches(msg, r.bindings.PrevColumn):
+		data.moveColumn(-1)
+	case key.Matches(msg, r.bindings.FollowReference):
+		row, column, ok := data.selection()
+		if !ok {
+			return nil, true
+		}
+
+		if cmd, waiting := r.awaitForeignKeys(msg); waiting {
+			return cmd, true
+		}
+
+		if r.foreignKeysErr != nil {
+			r.showNavigationError(r.foreignKeysErr)
+			return nil, true
+		}
+
+		l, err := referencedRow(r.table, r.foreignKeys, data.headers, row, column)
+		if err != nil {
+			r.showNavigationError(err)
+			return nil, true
+		}
+		return openRowsCmd(l), true
+	case key.Matches(msg, r.bindings.ReferencingRows):
+		row, _, ok := data.selection()
+		if !ok {
+			return nil, true
+		}
+
+		if cmd, waiting := r.awaitForeignKeys(msg); waiting {
+			return cmd, true
+		}
+
+		if r.foreignKeysErr != nil {
+			r.showNavigationError(r.foreignKeysErr)
+			return nil, true
+		}
+
+		referrers := referencingRows(r.table, r.foreignKeys, data.headers, row)
+		if len(referrers) == 0 {
+			r.showNavigationError(fmt.Errorf("no foreign keys reference the rows of %s", r.table.Name))
+			return nil, true
+		}
+
+		r.referrers = referrers
+		r.referrerCursor = 0
+		r.viewport.SetContent(r.referrersView())
+		r.viewport.GotoTop()
+		return nil, true
+	default:
+		return nil, false
+	}
+
+	r.viewport.SetContent(data.View().Content)
+	r.scrollToColumn(data)
+	return nil, true
+}
+
+// updateReferrers method moves through the list of the tables referencing the selected row,
+// and opens the one chosen.
+func (r *ResultSet) updateReferrers(msg tea.KeyPressMsg) tea.Cmd {
+	switch msg.String() {
+	case "up", "k":
+		r.referrerCursor = max(r.referrerCursor-1, 0)
+	case "down", "j":
+		r.referrerCursor = min(r.referrerCursor+1, len(r.referrers)-1)
+	case "enter":
+		l := r.referrers[r.referrerCursor].location
+		r.referrers = nil
+		return openRowsCmd(l)
+	case "esc", "q":
+		r.referrers = nil
+		r.viewport.SetContent(r.tablesMetadata[r.activeTab].View().Content)
+		return nil
+	}
+
+	r.viewport.SetContent(r.referrersView())
+	return nil
+}
+
+// referrersView method lists the tables referencing the selected row, to choose the one to open.
+func (r *ResultSet) referrersView() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Rows referencing the selected row of %s\n\n", r.table.Name)
+	for i, ref := range r.referrers {
+		cursor := "  "
+		if i == r.referrerCursor {
+			cursor = "▸ "
+		}
+		fmt.Fprintf(&b, "%s%s (%s)\n", cursor, ref.location, ref.foreignKey.Name)
+	}
+	b.WriteString("\nenter to open • esc to cancel")
+
+	return b.String()
+}
+
+func (r *ResultSet) showNavigationError(err error) {
+	errorText := fmt.Sprintf("❌ CAN'T OPEN THE RELATED ROWS\n\n%s", err.Error())
+	r.viewport.SetContent(errorStyle.Render(errorText))
+	r.viewport.GotoTop()
+}
+
+// dataPanel method returns the data tab of the table shown, if it's the active tab.
+func (r *ResultSet) dataPanel() (*TablePanel, bool) {
+	if r.table.Name == "" || r.activeTab != 0 || len(r.tablesMetadata) == 0 {
+		return nil, false
+	}
+
+	data, ok := r.tablesMetadata[0].(*TablePanel)
+	return data, ok
+}
+
+// scrollToColumn method scrolls the result set horizontally, so the selected column is visible.
+func (r *ResultSet) scrollToColumn(data *TablePanel) {
+	start, width := data.columnBounds()
+	switch {
+	case start < r.viewport.XOffset():
+		r.viewport.SetXOffset(start)
+	case start+width > r.viewport.XOffset()+r.viewport.Width():
+		r.viewport.SetXOffset(max(start+width-r.viewport.Width(), 0))
+	}
+}
+
+// openRowsCmd function asks to open the rows of the given location.
+func openRowsCmd(l location) tea.Cmd {
+	return func() tea.Msg {
+		return openRowsMsg{location: l}
+	}
+}
+
+// setData method fills the table with the given result set.
+// The selected column, if enabled, is marked in the header.
+func (t *TablePanel) setData(headers []string, rows [][]string) {
+	columns, tableRows := populateTable(headers, rows)
+
+	t.headers = headers
+	t.widths = make([]int, len(columns))
+	for i, column := range columns {
+		t.widths[i] = column.Width
+	}
+
+	t.table.SetColumns(columns)
+	t.table.SetRows(tableRows)
+	t.markColumn()
+}
+
+// enableColumnCursor method lets a column be selected, the first one to begin with.
+func (t *TablePanel) enableColumnCursor() {
+	t.column = 0
+	t.markColumn()
+}
+
+func (t *TablePanel) moveColumn(delta int) {
+	if t.column < 0 || len(t.headers) == 0 {
+		return
+	}
+
+	t.column = min(max(t.column+delta, 0), len(t.headers)-1)
+	t.markColumn()
+}
+
+// markColumn method marks the header of the selected column.
+func (t *TablePanel) markColumn() {
+	columns := t.table.Columns()
+	if len(columns) != len(t.headers) {
+		return
+	}
+
+	marked := make([]table.Column, len(columns))
+	for i, column := range columns {
+		column.Title = t.headers[i]
+		if i == t.column {
+			column.Title = "▸ " + column.Title
+		}
+		marked[i] = column
+	}
+
+	t.table.SetColumns(marked)
+}
+
+// selection method returns the selected row and the name of the selected column.
+func (t *TablePanel) selection() ([]string, string, bool) {
+	row := t.table.SelectedRow()
+	if row == nil || t.column < 0 || t.column >= len(t.headers) {
+		return nil, "", false
+	}
+
+	return row, t.headers[t.column], true
+}
+
+// columnBounds method returns the position and the width of the selected column, in characters.
+func (t *TablePanel) columnBounds() (int, int) {
+	if t.column < 0 || t.column >= len(t.widths) {
+		return 0, 0
+	}
+
+	// every cell is padded by a space on each side.
+	start := 0
+	for _, width := range t.widths[:t.column] {
+		start += width + 2
+	}
+
+	return start, t.widths[t.column] + 2
+}
@@ -0,0 +1,177 @@
+package bubbletui
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/danvergara/dblab/pkg/client"
+	"github.com/danvergara/dblab/pkg/command"
+)
+
+var (
+	ordersTable      = client.TableRef{Schema: "public", Name: "orders"}
+	ordersForeignKey = client.ForeignKey{
+		Name:       "orders_customer_fk",
+		Table:      "orders",
+		Columns:    []string{"customer_id"},
+		RefSchema:  "public",
+		RefTable:   "customers",
+		RefColumns: []string{"id"},
+	}
+	itemsForeignKey = client.ForeignKey{
+		Name:       "items_order_fk",
+		Table:      "order_items",
+		Columns:    []string{"order_id"},
+		RefSchema:  "public",
+		RefTable:   "orders",
+		RefColumns: []string{"id"},
+	}
+)
+
+func TestNavigation(t *testing.T) {
+	orders := location{table: ordersTable}
+	customer := location{table: client.TableRef{Schema: "public", Name: "customers"}, conditions: []client.Condition{{Column: "id", Value: "7"}}}
+	items := location{table: client.TableRef{Schema: "public", Name: "order_items"}, conditions: []client.Condition{{Column: "order_id", Value: "1"}}}
+
+	var n navigation
+	_, ok := n.back()
+	assert.False(t, ok)
+
+	n.visit(orders)
+	n.visit(customer)
+	// visiting the current location again is a no-op.
+	n.visit(customer)
+	assert.Len(t, n.locations, 2)
+
+	l, ok := n.back()
+	assert.True(t, ok)
+	assert.True(t, l.equal(orders))
+
+	_, ok = n.back()
+	assert.False(t, ok)
+
+	l, ok = n.forward()
+	assert.True(t, ok)
+	assert.True(t, l.equal(customer))
+
+	_, ok = n.forward()
+	assert.False(t, ok)
+
+	// a new location drops the ones ahead of the current one.
+	n.back()
+	n.visit(items)
+	assert.Len(t, n.locations, 2)
+	_, ok = n.forward()
+	assert.False(t, ok)
+}
+
+func TestReferencedRow(t *testing.T) {
+	foreignKeys := []client.ForeignKey{ordersForeignKey, itemsForeignKey}
+	headers := []string{"id", "customer_id", "total"}
+
+	l, err := referencedRow(ordersTable, foreignKeys, headers, []string{"1", "7", "9.99"}, "customer_id")
+	require.NoError(t, err)
+	assert.Equal(t, client.TableRef{Schema: "public", Name: "customers"}, l.table)
+	assert.Equal(t, []client.Condition{{Column: "id", Value: "7"}}, l.conditions)
+	assert.Equal(t, "public.customers [id = 7]", l.String())
+
+	_, err = referencedRow(ordersTable, foreignKeys, headers, []string{"1", "7", "9.99"}, "total")
+	assert.EqualError(t, err, "the column total is not part of a foreign key of orders")
+
+	_, err = referencedRow(ordersTable, foreignKeys, headers, []string{"1", nullValue, "9.99"}, "customer_id")
+	assert.EqualError(t, err, "the column customer_id is null on the selected row")
+
+	// the databases without schemas leave the tables unqualified.
+	l, err = referencedRow(client.TableRef{Name: "orders"}, foreignKeys, headers, []string{"1", "7", "9.99"}, "CUSTOMER_ID")
+	require.NoError(t, err)
+	assert.Equal(t, client.TableRef{Name: "customers"}, l.table)
+}
+
+func TestReferencingRows(t *testing.T) {
+	foreignKeys := []client.ForeignKey{ordersForeignKey, itemsForeignKey}
+
+	referrers := referencingRows(ordersTable, foreignKeys, []string{"id", "customer_id"}, []string{"1", "7"})
+	require.Len(t, referrers, 1)
+	assert.Equal(t, "items_order_fk", referrers[0].foreignKey.Name)
+	assert.Equal(t, client.TableRef{Schema: "public", Name: "order_items"}, referrers[0].location.table)
+	assert.Equal(t, []client.Condition{{Column: "order_id", Value: "1"}}, referrers[0].location.conditions)
+
+	assert.Empty(t, referencingRows(client.TableRef{Schema: "public", Name: "order_items"}, foreignKeys, []string{"order_id"}, []string{"1"}))
+}
+
+func TestResultset_FollowReference(t *testing.T) {
+	kb := command.DefaultKeyMap()
+	rs := NewResultSet(kb)
+
+	rs, _ = rs.Update(metadataSuccessMsg{
+		metadata: &client.Metadata{
+			TableContent: client.Table{Columns: []string{"id", "customer_id"}, Rows: [][]string{{"1", "7"}}},
+		},
+		isTable: true,
+		table:   ordersTable,
+	})
+
+	data, ok := rs.dataPanel()
+	require.True(t, ok)
+	assert.Equal(t, "▸ id", data.table.Columns()[0].Title)
+
+	rs, _ = rs.Update(tea.KeyPressMsg{Code: '>', Text: ">"})
+	assert.Equal(t, "id", data.table.Columns()[0].Title)
+	assert.Equal(t, "▸ customer_id", data.table.Columns()[1].Title)
+
+	// the foreign keys are read first, then the key is handled.
+	rs, cmd := rs.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	require.NotNil(t, cmd)
+	assert.Equal(t, loadTabMsg{tab: relationsTab, table: ordersTable}, cmd())
+
+	rs, cmd = rs.Update(tabLoadedMsg{tab: relationsTab, table: ordersTable, foreignKeys: []client.ForeignKey{ordersForeignKey, itemsForeignKey}})
+	require.NotNil(t, cmd)
+	msg, ok := cmd().(openRowsMsg)
+	require.True(t, ok)
+	assert.Equal(t, "public.customers [id = 7]", msg.location.String())
+
+	// the referenced row is opened, with its conditions shown on the data tab.
+	rs, _ = rs.Update(metadataSuccessMsg{
+		metadata:   &client.Metadata{TableContent: client.Table{Columns: []string{"id"}, Rows: [][]string{{"7"}}}},
+		isTable:    true,
+		table:      msg.location.table,
+		conditions: msg.location.conditions,
+	})
+	assert.Equal(t, "Data [id = 7]", rs.tabs[0])
+
+	_, cmd = rs.Update(tea.KeyPressMsg{Code: '[', Text: "["})
+	require.NotNil(t, cmd)
+	msg, ok = cmd().(openRowsMsg)
+	require.True(t, ok)
+	assert.True(t, msg.location.equal(location{table: ordersTable}))
+}
+
+func TestResultset_ReferencingRows(t *testing.T) {
+	kb := command.DefaultKeyMap()
+	rs := NewResultSet(kb)
+
+	rs, _ = rs.Update(metadataSuccessMsg{
+		metadata: &client.Metadata{
+			TableContent: client.Table{Columns: []string{"id", "customer_id"}, Rows: [][]string{{"1", "7"}}},
+		},
+		isTable: true,
+		table:   ordersTable,
+	})
+	rs, _ = rs.Update(tabLoadedMsg{tab: relationsTab, table: ordersTable, foreignKeys: []client.ForeignKey{ordersForeignKey, itemsForeignKey}})
+
+	rs, cmd := rs.Update(tea.KeyPressMsg{Code: 'R', Text: "R"})
+	assert.Nil(t, cmd)
+	require.Len(t, rs.referrers, 1)
+	assert.Contains(t, rs.referrersView(), "▸ public.order_items [order_id = 1] (items_order_fk)")
+
+	rs, cmd = rs.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	require.NotNil(t, cmd)
+	assert.Nil(t, rs.referrers)
+
+	msg, ok := cmd().(openRowsMsg)
+	require.True(t, ok)
+	assert.Equal(t, "public.order_items [order_id = 1]", msg.location.String())
+}
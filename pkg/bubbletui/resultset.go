@@ -45,6 +45,11 @@ type MetadataPanel interface {
 
 type TablePanel struct {
 	table table.Model
+	// headers are the names of the columns, without the mark of the selected one.
+	headers []string
+	widths  []int
+	// column is the index of the selected column, or -1 if the columns can't be selected.
+	column int
 }
 
 func (t *TablePanel) Init() tea.Cmd { return nil }
@@ -90,9 +95,36 @@ type ResultSet struct {
 
 	viewport       viewport.Model
 	tablesMetadata []MetadataPanel
-	dump           io.Writer
+	// object is the name of the table, view or object shown, used to name the file the text of a tab is saved to.
+	object string
+
+	// table is the table shown, if any, and foreignKeys its relations, used to open the rows related to a row.
+	// The foreign keys are read along with the Relations tab, the key waiting for them is handled once they're read.
+	table           client.TableRef
+	foreignKeys     []client.ForeignKey
+	foreignKeysErr  error
+	foreignKeysRead bool
+	pendingKey      *tea.KeyPressMsg
+	// history holds the tables opened, to go back and forward through them.
+	history navigation
+	// referrers lists the tables referencing the selected row, while one of them is being chosen.
+	referrers      []referrer
+	referrerCursor int
+	// unloadedTabs are the tabs of the table read on demand that were not read yet.
+	unloadedTabs map[int]bool
+
+	dump io.Writer
 }
 
+// textSavedMsg reports that the text of the tab at the given index was saved to a file.
+type textSavedMsg struct {
+	tab  int
+	path string
+}
+
+// textSaveErrMsg reports that the text of a tab could not be saved.
+type textSaveErrMsg struct{ err error }
+
 func NewResultSet(kb *command.TUIKeyMap) ResultSet {
 	var dump *os.File
 	if _, ok := os.LookupEnv("DBLAB_DEBUG"); ok {
@@ -103,7 +135,7 @@ func NewResultSet(kb *command.TUIKeyMap) ResultSet {
 		}
 	}
 	rs := ResultSet{
-		tabs:     []string{"Data", "Columns", "Indexes", "Constraints"},
+		tabs:     []string{"Data", "Columns", "Indexes", "Constraints", "DDL", "Relations", "Stats", "Grants"},
 		bindings: kb,
 		viewport: viewport.New(viewport.WithHeight(0), viewport.WithWidth(0)),
 		dump:     dump,
@@ -150,11 +182,19 @@ func (r *ResultSet) setupTables() {
 	data := newTablePanel(r.height, r.width)
 	constraints := newTablePanel(r.height, r.width)
 	indexes := newTablePanel(r.height, r.width)
+	ddl := newTextPanel()
+	relations := newTextPanel()
+	stats := newTablePanel(r.height, r.width)
+	grants := newTablePanel(r.height, r.width)
 	r.tablesMetadata = []MetadataPanel{
 		data,
 		columns,
 		indexes,
 		constraints,
+		ddl,
+		relations,
+		stats,
+		grants,
 	}
 }
 
@@ -171,6 +211,10 @@ func (r ResultSet) Update(msg tea.Msg) (ResultSet, tea.Cmd) {
 	var cmd tea.Cmd
 	switch msg := msg.(type) {
 	case tea.KeyPressMsg:
+		if cmd, ok := r.updateNavigation(msg); ok {
+			return r, cmd
+		}
+
 		switch {
 		case key.Matches(msg, r.bindings.NextTab):
 			if r.activeTab == len(r.tabs)-1 {
@@ -179,7 +223,7 @@ func (r ResultSet) Update(msg tea.Msg) (ResultSet, tea.Cmd) {
 				r.activeTab = min(r.activeTab+1, len(r.tabs)-1)
 			}
 			r.viewport.SetContent(r.tablesMetadata[r.activeTab].View().Content)
-			return r, nil
+			return r, r.loadActiveTab()
 		case key.Matches(msg, r.bindings.PrevTab):
 			if r.activeTab == 0 {
 				r.activeTab = len(r.tabs) - 1
@@ -187,7 +231,21 @@ func (r ResultSet) Update(msg tea.Msg) (ResultSet, tea.Cmd) {
 				r.activeTab = max(r.activeTab-1, 0)
 			}
 			r.viewport.SetContent(r.tablesMetadata[r.activeTab].View().Content)
-			return r, nil
+			return r, r.loadActiveTab()
+		case key.Matches(msg, r.bindings.CountRows):
+			if r.table.Name != "" && r.activeTab == statsTab {
+				delete(r.unloadedTabs, statsTab)
+				r.viewport.SetContent("counting the rows...")
+				return r, loadTabCmd(statsTab, r.table, true)
+			}
+		case key.Matches(msg, r.bindings.Copy):
+			if text, ok := r.activeText(); ok {
+				return r, tea.SetClipboard(text)
+			}
+		case key.Matches(msg, r.bindings.Save):
+			if text, ok := r.activeText(); ok {
+				return r, saveTextCmd(r.activeTab, textFileName(r.object), text)
+			}
 		case key.Matches(msg, r.bindings.BeginningOfLine):
 			r.viewport.SetXOffset(0)
 			return r, nil
@@ -227,6 +285,12 @@ func (r ResultSet) Update(msg tea.Msg) (ResultSet, tea.Cmd) {
 		r.viewport.SetContent(styledError)
 		r.viewport.GotoTop()
 		return r, nil
+	case childrenErrMsg:
+		errorText := fmt.Sprintf("❌ FAILED TO LOAD THE CATALOG\n\n%s", msg.err.Error())
+		styledError := errorStyle.Render(errorText)
+		r.viewport.SetContent(styledError)
+		r.viewport.GotoTop()
+		return r, nil
 	case updateGraphErrMsg:
 		errorText := fmt.Sprintf("❌ FAILED TO LOAD THE CATALOG\n\n%s", msg.err.Error())
 		styledError := errorStyle.Render(errorText)
@@ -241,6 +305,8 @@ func (r ResultSet) Update(msg tea.Msg) (ResultSet, tea.Cmd) {
 		return r, nil
 	case querySuccessMsg:
 		r.clearTables()
+		r.table = client.TableRef{}
+		r.referrers = nil
 
 		r.tabs = make([]string, len(msg.queriesResult))
 		r.tablesMetadata = make([]MetadataPanel, len(msg.queriesResult))
@@ -266,12 +332,49 @@ func (r ResultSet) Update(msg tea.Msg) (ResultSet, tea.Cmd) {
 		r.viewport.SetContent(r.tablesMetadata[r.activeTab].View().Content)
 		r.viewport.GotoTop()
 
-		return r, saveQueriesCmd(msg.queriesResult)
+		return r, saveQueriesCmd(msg.profile, msg.queriesResult)
+	case textSavedMsg:
+		if msg.tab < len(r.tabs) && !strings.HasSuffix(r.tabs[msg.tab], " ✓") {
+			r.tabs[msg.tab] += " ✓"
+		}
+		return r, nil
+	case textSaveErrMsg:
+		errorText := fmt.Sprintf("❌ FAILED TO SAVE THE FILE\n\n%s", msg.err.Error())
+		r.viewport.SetContent(errorStyle.Render(errorText))
+		r.viewport.GotoTop()
+		return r, nil
+	case diagramSavedMsg:
+		text := fmt.Sprintf("✔ ER DIAGRAM SAVED\n\n%s", strings.Join(msg.paths, "\n"))
+		r.viewport.SetContent(successStyle.Render(text))
+		r.viewport.GotoTop()
+		return r, nil
+	case diagramSaveErrMsg:
+		errorText := fmt.Sprintf("❌ FAILED TO SAVE THE ER DIAGRAM\n\n%s", msg.err.Error())
+		r.viewport.SetContent(errorStyle.Render(errorText))
+		r.viewport.GotoTop()
+		return r, nil
 	case metadataSuccessMsg:
-		r.updateMetadataOnChange(msg.metadata, msg.isTable)
+		r.object = msg.object
+		r.table, r.referrers = client.TableRef{}, nil
+		r.foreignKeys, r.foreignKeysErr, r.foreignKeysRead, r.pendingKey = nil, nil, false, nil
+		if msg.isTable {
+			r.table = msg.table
+			r.history.visit(location{table: msg.table, conditions: msg.conditions})
+		}
+
+		if msg.isObject {
+			r.updateDefinitionOnChange(msg.metadata)
+		} else {
+			r.updateMetadataOnChange(msg.metadata, msg.isTable)
+			if len(msg.conditions) > 0 {
+				r.tabs[0] += " " + conditionsLabel(msg.conditions)
+			}
+		}
 		r.viewport.SetContent(r.tablesMetadata[r.activeTab].View().Content)
 		r.viewport.GotoTop()
 		return r, nil
+	case tabLoadedMsg:
+		return r, r.setTabContent(msg)
 	case metadataErrMsg:
 		errorText := fmt.Sprintf("❌ failed to get metadata\n\n%s", msg.err.Error())
 		styledError := errorStyle.Render(errorText)
@@ -356,14 +459,14 @@ func (r *ResultSet) updateMetadataOnChange(metadata *client.Metadata, isTable bo
 		if isTable {
 			r.setupTables()
 
-			r.tabs = []string{"Data", "Columns", "Indexes", "Constraints"}
+			r.tabs = []string{"Data", "Columns", "Indexes", "Constraints", "DDL", "Relations", "Stats", "Grants"}
 			r.activeTab = 0
+			r.resetLazyTabs()
 
-			// table data.
-			tableContentColumns, tableContentRows := populateTable(metadata.TableContent.Columns, metadata.TableContent.Rows)
+			// table data, whose columns can be selected to open the rows they reference.
 			if tablePanel, ok := r.tablesMetadata[0].(*TablePanel); ok {
-				tablePanel.table.SetColumns(tableContentColumns)
-				tablePanel.table.SetRows(tableContentRows)
+				tablePanel.setData(metadata.TableContent.Columns, metadata.TableContent.Rows)
+				tablePanel.enableColumnCursor()
 			}
 
 			// table columns.
@@ -408,6 +511,66 @@ func (r *ResultSet) updateMetadataOnChange(metadata *client.Metadata, isTable bo
 	}
 }
 
+// statsRows function returns the statistics of a table as the rows of the Stats tab.
+func statsRows(stats []client.Stat) [][]string {
+	rows := make([][]string, 0, len(stats))
+	for _, stat := range stats {
+		rows = append(rows, []string{stat.Name, stat.Value})
+	}
+
+	return rows
+}
+
+// updateDefinitionOnChange method is used to print the source definition of a routine, sequence, trigger or type.
+func (r *ResultSet) updateDefinitionOnChange(metadata *client.Metadata) {
+	if metadata == nil {
+		return
+	}
+
+	definition := newTextPanel()
+	definition.SetContent(metadata.Definition)
+
+	r.tablesMetadata = []MetadataPanel{definition}
+	r.tabs = []string{"Definition"}
+	r.activeTab = 0
+}
+
+// activeText method returns the text of the active tab,
+// if it shows text, like a DDL or a definition, rather than a table.
+func (r *ResultSet) activeText() (string, bool) {
+	if r.activeTab >= len(r.tablesMetadata) {
+		return "", false
+	}
+
+	textPanel, ok := r.tablesMetadata[r.activeTab].(*TextPanel)
+	if !ok || textPanel.content == "" {
+		return "", false
+	}
+
+	return textPanel.content, true
+}
+
+// saveTextCmd function writes the given text to a file asynchronously.
+func saveTextCmd(tab int, path, text string) tea.Cmd {
+	return func() tea.Msg {
+		if err := os.WriteFile(path, []byte(text+"\n"), 0644); err != nil {
+			return textSaveErrMsg{err: fmt.Errorf("failed to write to the file: %w", err)}
+		}
+
+		return textSavedMsg{tab: tab, path: path}
+	}
+}
+
+// textFileName function returns the name of the file the text of a tab is saved to, in the current directory.
+// It's named after the object shown, e.g. public.users.sql.
+func textFileName(object string) string {
+	if object == "" {
+		object = "dblab"
+	}
+
+	return strings.NewReplacer("/", "_", "\\", "_").Replace(object) + ".sql"
+}
+
 // tabBorderWithBottom function is used to define the tab borders.
 // Borders changes whether the tabs is inacative or inactive.
 // Active tab misses the bottom border.
@@ -443,7 +606,8 @@ func newTablePanel(height, width int) *TablePanel {
 	t.SetStyles(s)
 
 	return &TablePanel{
-		table: t,
+		table:  t,
+		column: -1,
 	}
 }
 
@@ -493,7 +657,7 @@ func populateTable(headers []string, data [][]string) ([]table.Column, []table.R
 	return columns, rows
 }
 
-func saveQueriesCmd(queriesResult []client.QueryResult) tea.Cmd {
+func saveQueriesCmd(profile string, queriesResult []client.QueryResult) tea.Cmd {
 	return func() tea.Msg {
 		configDir, err := os.UserConfigDir()
 		if err != nil {
@@ -506,6 +670,7 @@ func saveQueriesCmd(queriesResult []client.QueryResult) tea.Cmd {
 				QueryText: qr.Query,
 				Timestamp: qr.Timestamp,
 				Duration:  qr.Duration,
+				Profile:   profile,
 			}
 
 			if qr.Error == nil {
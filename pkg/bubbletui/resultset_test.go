@@ -5,6 +5,7 @@ import (
 
 	"charm.land/bubbles/v2/table"
 	tea "charm.land/bubbletea/v2"
+	"github.com/danvergara/dblab/pkg/client"
 	"github.com/danvergara/dblab/pkg/command"
 	"github.com/stretchr/testify/assert"
 )
@@ -27,3 +28,160 @@ func TestResulset_UpdateBeforeResize(t *testing.T) {
 		rs.Update(msg)
 	})
 }
+
+func TestResultset_ObjectDefinition(t *testing.T) {
+	kb := command.DefaultKeyMap()
+	rs := NewResultSet(kb)
+
+	msg := metadataSuccessMsg{
+		metadata: &client.Metadata{Definition: "CREATE SEQUENCE public.orders_id_seq;"},
+		isObject: true,
+	}
+
+	rs, _ = rs.Update(msg)
+
+	assert.Equal(t, []string{"Definition"}, rs.tabs)
+	assert.Len(t, rs.tablesMetadata, 1)
+	assert.Equal(t, "CREATE SEQUENCE public.orders_id_seq;", rs.tablesMetadata[0].View().Content)
+}
+
+func TestResultset_TableDDL(t *testing.T) {
+	kb := command.DefaultKeyMap()
+	rs := NewResultSet(kb)
+
+	msg := metadataSuccessMsg{
+		metadata: &client.Metadata{},
+		isTable:  true,
+		object:   "public.users",
+		table:    client.TableRef{Schema: "public", Name: "users"},
+	}
+
+	rs, _ = rs.Update(msg)
+
+	assert.Equal(t, []string{"Data", "Columns", "Indexes", "Constraints", "DDL", "Relations", "Stats", "Grants"}, rs.tabs)
+	assert.Equal(t, "public.users", rs.object)
+
+	// the data tab is not text, so it's not copied.
+	_, ok := rs.activeText()
+	assert.False(t, ok)
+
+	// the DDL is built once the tab is opened.
+	rs.activeTab = constraintsTab
+	rs, cmd := rs.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	assert.Equal(t, ddlTab, rs.activeTab)
+	assert.NotNil(t, cmd)
+	assert.Equal(t, loadTabMsg{tab: ddlTab, table: msg.table}, cmd())
+
+	_, ok = rs.activeText()
+	assert.False(t, ok)
+
+	rs, _ = rs.Update(tabLoadedMsg{tab: ddlTab, table: msg.table, text: "CREATE TABLE public.users (\n    id integer NOT NULL\n);"})
+	text, ok := rs.activeText()
+	assert.True(t, ok)
+	assert.Equal(t, "CREATE TABLE public.users (\n    id integer NOT NULL\n);", text)
+
+	rs, _ = rs.Update(textSavedMsg{tab: 4, path: "public.users.sql"})
+	assert.Equal(t, "DDL ✓", rs.tabs[4])
+
+	rs, cmd = rs.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	assert.Equal(t, relationsTab, rs.activeTab)
+	assert.NotNil(t, cmd)
+	assert.Equal(t, loadTabMsg{tab: relationsTab, table: msg.table}, cmd())
+
+	rs, _ = rs.Update(tabLoadedMsg{tab: relationsTab, table: msg.table, text: relationsText(msg.table, nil, nil)})
+	text, ok = rs.activeText()
+	assert.True(t, ok)
+	assert.Equal(t, "users\n└── no relations\n", text)
+}
+
+func TestResultset_TableStats(t *testing.T) {
+	kb := command.DefaultKeyMap()
+	rs := NewResultSet(kb)
+
+	users := client.TableRef{Schema: "public", Name: "users"}
+	rs, _ = rs.Update(metadataSuccessMsg{
+		metadata: &client.Metadata{},
+		isTable:  true,
+		object:   "public.users",
+		table:    users,
+	})
+
+	// the statistics are read once the tab is opened.
+	rs.activeTab = relationsTab
+	rs, cmd := rs.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	assert.Equal(t, statsTab, rs.activeTab)
+	assert.NotNil(t, cmd)
+	assert.Equal(t, loadTabMsg{tab: statsTab, table: users}, cmd())
+
+	// the statistics of a table opened before are dropped.
+	rs, _ = rs.Update(tabLoadedMsg{tab: statsTab, table: client.TableRef{Schema: "public", Name: "orders"}, columns: []string{"statistic", "value"}, rows: [][]string{{"total size", "8 bytes"}}})
+
+	stats, ok := rs.tablesMetadata[statsTab].(*TablePanel)
+	assert.True(t, ok)
+	assert.Empty(t, stats.table.Rows())
+
+	rs, _ = rs.Update(tabLoadedMsg{tab: statsTab, table: users, columns: []string{"statistic", "value"}, rows: [][]string{
+		{"estimated rows", "3"},
+		{"total size", "16.0 KB (16384 bytes)"},
+	}})
+
+	stats, ok = rs.tablesMetadata[statsTab].(*TablePanel)
+	assert.True(t, ok)
+	assert.Equal(t, []table.Row{
+		{"estimated rows", "3"},
+		{"total size", "16.0 KB (16384 bytes)"},
+	}, stats.table.Rows())
+
+	// they're read only once.
+	rs, _ = rs.Update(tea.KeyPressMsg{Code: tea.KeyTab, Mod: tea.ModShift})
+	rs, cmd = rs.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	assert.Equal(t, statsTab, rs.activeTab)
+	assert.Nil(t, cmd)
+
+	// the rows are counted on demand.
+	_, cmd = rs.Update(tea.KeyPressMsg{Code: 'c', Text: "c"})
+	assert.NotNil(t, cmd)
+	assert.Equal(t, loadTabMsg{tab: statsTab, table: users, exactCount: true}, cmd())
+}
+
+func TestResultset_TableGrants(t *testing.T) {
+	kb := command.DefaultKeyMap()
+	rs := NewResultSet(kb)
+
+	users := client.TableRef{Schema: "public", Name: "users"}
+	rs, _ = rs.Update(metadataSuccessMsg{
+		metadata: &client.Metadata{},
+		isTable:  true,
+		object:   "public.users",
+		table:    users,
+	})
+
+	// the privileges are read once the tab is opened.
+	rs, cmd := rs.Update(tea.KeyPressMsg{Code: tea.KeyTab, Mod: tea.ModShift})
+	assert.Equal(t, grantsTab, rs.activeTab)
+	assert.NotNil(t, cmd)
+	assert.Equal(t, loadTabMsg{tab: grantsTab, table: users}, cmd())
+
+	rs, _ = rs.Update(tabLoadedMsg{
+		tab:     grantsTab,
+		table:   users,
+		columns: []string{"grantee", "privileges", "can write", "with grant option"},
+		rows: [][]string{
+			{"app", "SELECT, INSERT", "YES", ""},
+			{"reporting", "SELECT", "NO", "SELECT"},
+		},
+	})
+
+	grants, ok := rs.tablesMetadata[grantsTab].(*TablePanel)
+	assert.True(t, ok)
+	assert.Equal(t, []table.Row{
+		{"app", "SELECT, INSERT", "YES", ""},
+		{"reporting", "SELECT", "NO", "SELECT"},
+	}, grants.table.Rows())
+}
+
+func TestTextFileName(t *testing.T) {
+	assert.Equal(t, "public.users.sql", textFileName("public.users"))
+	assert.Equal(t, "a_b.sql", textFileName("a/b"))
+	assert.Equal(t, "dblab.sql", textFileName(""))
+}
@@ -2,18 +2,24 @@ package bubbletui
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"os"
+	"slices"
+	"strings"
 
 	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/spinner"
+	"charm.land/bubbles/v2/textinput"
 	"charm.land/bubbles/v2/viewport"
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 	"github.com/Digital-Shane/treeview/v2"
 
+	"github.com/danvergara/dblab/internal/bookmarks"
+	"github.com/danvergara/dblab/internal/catalogcache"
 	"github.com/danvergara/dblab/pkg/client"
 	"github.com/danvergara/dblab/pkg/command"
-	"github.com/danvergara/dblab/pkg/drivers"
 	"github.com/davecgh/go-spew/spew"
 )
 
@@ -33,19 +39,82 @@ type selectViewMsg struct {
 	View   string
 }
 
+// childrenMsg carries the children of a catalog node, fetched the first time the node is expanded.
+// The children of a table are its columns and indexes.
+type childrenMsg struct {
+	nodeID   string
+	children []*client.DBNode
+}
+
+// childrenErrMsg struct used to report the failure to fetch the children of a catalog node.
+type childrenErrMsg struct {
+	nodeID string
+	err    error
+}
+
+// insertTextMsg asks the editor to insert a text at the cursor position, e.g. the qualified name of a column.
+type insertTextMsg struct {
+	text string
+}
+
+// selectObjectMsg is sent when a function, procedure, sequence, trigger or type is selected.
+type selectObjectMsg struct {
+	Schema string
+	Name   string
+	Type   string
+}
+
 type SidebarViewport struct {
 	c        *client.Client
 	bindings *command.TUIKeyMap
 
 	sidebarViewport viewport.Model
 	dbTree          *treeview.TuiTreeModel[*client.DBNode]
-	width, height   int
+	// root is the database catalog the tree is built from.
+	// Nodes loaded on demand, like the columns of a table, are added to it.
+	root *client.DBNode
+	// loader keeps track of the nodes being loaded.
+	loader        *catalogLoader
+	width, height int
+
+	// bookmarks are the favorite and the recently opened tables of the connection,
+	// shown at the top of the tree.
+	bookmarks bookmarks.Bookmarks
+
+	// filtering is set while the fuzzy filter replaces the tree.
+	filtering   bool
+	filterInput textinput.Model
+	matches     []catalogEntry
+	matchCursor int
+	// catalogLoaded tells whether root holds the whole catalog, which the filter matches against.
+	catalogLoaded bool
+	// cached tells whether root was read from the catalog cache, so it's refreshed by Init.
+	cached bool
 
 	selected bool
 	dump     io.Writer
 }
 
-type DBGraphTreeBuilderProvider struct{}
+// catalogLoader keeps track of the catalog nodes whose children are being fetched,
+// so a spinner is drawn next to them, and of the changes found by the last refresh of the catalog.
+// It's a pointer shared by every copy of the SidebarViewport and by the tree formatter.
+type catalogLoader struct {
+	loading map[string]bool
+	spinner spinner.Model
+	// changes marks the nodes added or removed since the catalog was cached.
+	changes map[string]catalogChange
+	// removed are the nodes gone since the catalog was cached, by the ID of their parent.
+	// They are still shown, marked as removed, until the next refresh.
+	removed map[string][]*client.DBNode
+	// showRowCounts tells whether the approximate row count is shown next to the tables,
+	// rowCounts holds them by the ID of the table node.
+	showRowCounts bool
+	rowCounts     map[string]int64
+}
+
+type DBGraphTreeBuilderProvider struct {
+	removed map[string][]*client.DBNode
+}
 
 func (d DBGraphTreeBuilderProvider) ID(do *client.DBNode) string {
 	return do.ID
@@ -54,7 +123,17 @@ func (d DBGraphTreeBuilderProvider) ID(do *client.DBNode) string {
 func (d *DBGraphTreeBuilderProvider) Name(do *client.DBNode) string {
 	return do.Name
 }
+
+// Children method returns the children of the node, followed by the ones removed since the catalog was cached,
+// unless they were created again.
 func (p *DBGraphTreeBuilderProvider) Children(do *client.DBNode) []*client.DBNode {
+	removed := slices.DeleteFunc(slices.Clone(p.removed[do.ID]), func(r *client.DBNode) bool {
+		return slices.ContainsFunc(do.Children, func(c *client.DBNode) bool { return c.ID == r.ID })
+	})
+
+	if len(removed) > 0 {
+		return slices.Concat(do.Children, removed)
+	}
 	return do.Children
 }
 
@@ -68,28 +147,55 @@ func NewSidebarViewport(ctx context.Context, c *client.Client, kb *command.TUIKe
 		}
 	}
 
+	sp := spinner.New()
+	sp.Spinner = spinner.Dot
+	sp.Style = lipgloss.NewStyle().Foreground(cyberGreen)
+
 	svp := SidebarViewport{
 		c:        c,
 		bindings: kb,
 		dump:     dump,
+		// the catalog starts with the database alone, its children are fetched by Init.
+		root: c.Root(),
+		loader: &catalogLoader{
+			loading: make(map[string]bool),
+			spinner: sp,
+		},
+	}
+
+	// the bookmarks and the catalog cache are a nice to have, the sidebar works without them if they cannot be read.
+	if configDir, err := os.UserConfigDir(); err == nil {
+		svp.bookmarks, _ = bookmarks.Read(configDir, c.Identity())
+
+		// the cached catalog is shown right away, Init refreshes it in the background.
+		if cache, ok, _ := catalogcache.Read(configDir, c.Identity()); ok && cache.Root.ID == svp.root.ID {
+			svp.root = cache.Root
+			svp.cached = true
+		}
 	}
 
+	svp.filterInput = textinput.New()
+	svp.filterInput.Prompt = "/ "
+	svp.filterInput.Placeholder = "filter tables, views and schemas"
+
 	svp.sidebarViewport = viewport.New(viewport.WithHeight(0), viewport.WithWidth(0))
 	svp.sidebarViewport.KeyMap = viewport.KeyMap{}
 
-	root, err := c.Catalog(ctx)
+	tree, err := newDBTree(ctx, svp.treeRoots(), svp.loader)
 	if err != nil {
-		return SidebarViewport{}, err
+		return svp, err
 	}
 
-	tree, err := treeview.NewTreeFromNestedData[*client.DBNode](
-		ctx,
-		[]*client.DBNode{root},
-		&DBGraphTreeBuilderProvider{},
-		treeview.WithProvider(createCyberpunkProvider()),
-	)
-	if err != nil {
-		return svp, err
+	// the bookmarks sections and the cached database start expanded.
+	expandIDs := []string{svp.root.ID}
+	for _, section := range bookmarkSections(svp.bookmarks) {
+		expandIDs = append(expandIDs, section.ID)
+	}
+
+	for _, id := range expandIDs {
+		if node, err := tree.FindByID(ctx, id); err == nil && node != nil {
+			node.Expand()
+		}
 	}
 
 	svp.dbTree = svp.newTuiTreeModel(tree, 0, 80)
@@ -107,12 +213,15 @@ func (s *SidebarViewport) SetSize(w, h int) {
 	if s.dbTree != nil {
 		s.dbTree = s.newTuiTreeModel(s.dbTree.Tree, 0, s.height-2)
 	}
+
+	s.filterInput.SetWidth(s.width - 4)
 }
 
 func (s *SidebarViewport) newTuiTreeModel(tree *treeview.Tree[*client.DBNode], width, height int) *treeview.TuiTreeModel[*client.DBNode] {
 	// Create custom key map to avoid key conflicts
 	keyMap := treeview.DefaultKeyMap()
-	keyMap.SearchStart = []string{"/"}
+	// "/" opens the catalog filter of the sidebar instead of the tree search.
+	keyMap.SearchStart = nil
 	keyMap.Up = []string{"up", "k", "w"}
 	keyMap.Down = []string{"down", "j", "s"}
 	keyMap.Toggle = []string{"enter"}
@@ -126,8 +235,15 @@ func (s *SidebarViewport) newTuiTreeModel(tree *treeview.Tree[*client.DBNode], w
 	)
 }
 
+// Init method starts fetching the top level of the catalog, so the TUI shows up without waiting for it.
+// If the catalog was cached, the whole catalog is refreshed instead, and its words are sent to the editor meanwhile.
 func (s SidebarViewport) Init() tea.Cmd {
-	return nil
+	if s.cached {
+		words := catalogWords(s.root)
+		return tea.Batch(s.refreshCatalog(), func() tea.Msg { return catalogWordsMsg{words: words} })
+	}
+
+	return s.startLoading(s.root)
 }
 
 func (s SidebarViewport) Update(msg tea.Msg) (SidebarViewport, tea.Cmd) {
@@ -138,34 +254,73 @@ func (s SidebarViewport) Update(msg tea.Msg) (SidebarViewport, tea.Cmd) {
 	var cmd tea.Cmd
 	switch msg := msg.(type) {
 	case tea.KeyPressMsg:
+		if s.filtering {
+			return s.updateFilter(msg)
+		}
+
+		switch {
+		case msg.String() == "/":
+			return s, s.openFilter()
+		case key.Matches(msg, s.bindings.Favorite):
+			return s, s.toggleFavorite()
+		case key.Matches(msg, s.bindings.RefreshCatalog):
+			return s, s.refreshCatalog()
+		case key.Matches(msg, s.bindings.ExportDiagram):
+			return s, s.exportDiagram()
+		case key.Matches(msg, s.bindings.RowCounts):
+			return s, s.toggleRowCounts()
+		}
+
 		switch msg.Code {
 		case tea.KeyEnter:
 			selectedNode := s.dbTree.GetFocusedNode()
 			if selectedNode != nil && selectedNode.Data() != nil {
+				// the entries of the favorites and recent sections just open the table or view they point to.
+				if isBookmarkNode(*selectedNode.Data()) {
+					return s, s.openNode(*selectedNode.Data())
+				}
+
 				switch (*selectedNode.Data()).Type {
+				case "database", "schema", "security":
+					// the children are fetched the first time the node is expanded,
+					// afterwards enter just toggles the node.
+					if !(*selectedNode.Data()).Loaded {
+						return s, s.startLoading(*selectedNode.Data())
+					}
 				case "table":
-					selectTableCmd := func() tea.Msg {
-						stm := selectTableMsg{Table: (*selectedNode.Data()).EntityName}
-						switch s.c.Driver() {
-						case drivers.PostgreSQL, drivers.Postgres, drivers.PostgresSSH, drivers.Oracle:
-							stm.Schema = (*selectedNode.Data()).ParentName
-						}
-						return stm
+					openCmd := s.openNode(*selectedNode.Data())
+
+					// columns and indexes are loaded the first time the table is expanded,
+					// afterwards enter just toggles the table node.
+					if !(*selectedNode.Data()).Loaded {
+						return s, tea.Batch(openCmd, s.startLoading(*selectedNode.Data()))
 					}
 
-					return s, selectTableCmd
-				case "view":
-					selectViewCmd := func() tea.Msg {
-						stm := selectViewMsg{View: (*selectedNode.Data()).EntityName}
-						switch s.c.Driver() {
+					updatedModel, treeCmd := s.dbTree.Update(msg)
+					if newTreeModel, ok := updatedModel.(*treeview.TuiTreeModel[*client.DBNode]); ok {
+						s.dbTree = newTreeModel
+					}
 
-						case drivers.PostgreSQL, drivers.Postgres, drivers.PostgresSSH, drivers.Oracle:
-							stm.Schema = (*selectedNode.Data()).ParentName
-						}
+					return s, tea.Batch(openCmd, treeCmd)
+				case "column":
+					text := s.qualifiedColumnName(*selectedNode.Data())
+					insertTextCmd := func() tea.Msg {
+						return insertTextMsg{text: text}
+					}
 
-						return stm
+					return s, insertTextCmd
+				case "view", "materialized_view":
+					return s, s.openNode(*selectedNode.Data())
+				case "function", "procedure", "sequence", "trigger", "type":
+					node := *selectedNode.Data()
+					selectObjectCmd := func() tea.Msg {
+						return selectObjectMsg{
+							Schema: node.ParentName,
+							Name:   node.EntityName,
+							Type:   node.Type,
+						}
 					}
-					return s, selectViewCmd
+					return s, selectObjectCmd
 				}
 			}
 		}
@@ -225,23 +380,89 @@ func (s SidebarViewport) Update(msg tea.Msg) (SidebarViewport, tea.Cmd) {
 
 		return s, cmd
 	case querySuccessMsg:
-		var cmd tea.Cmd
-		if msg.reloadCatalog {
-			cmd = s.updateGraph()
+		return s, s.reloadTargets(msg.ddlTargets)
+	case childrenMsg:
+		delete(s.loader.loading, msg.nodeID)
+
+		node := findDBNode(s.root, msg.nodeID)
+		if node == nil {
+			return s, nil
 		}
-		return s, cmd
-	case updateGraphMsg:
-		s.dbTree = msg.tree
+
+		// a node is expanded the first time it's loaded, the nodes fetched again keep their state,
+		// along with the children loaded under them.
+		var expandIDs []string
+		if !node.Loaded {
+			expandIDs = append(expandIDs, node.ID)
+		}
+
+		mergeChildren(node.Children, msg.children)
+		node.Children = msg.children
+		node.Loaded = true
+
+		// a new schema is not loaded yet, so the filter needs the whole catalog again.
+		if node == s.root && !allLoaded(node.Children) {
+			s.catalogLoaded = false
+		}
+
+		if err := s.rebuildTree(context.Background(), expandIDs...); err != nil {
+			return s, func() tea.Msg { return updateGraphErrMsg{err} }
+		}
+		return s, tea.Batch(s.catalogChanged(), s.loadRowCounts(tableContainers(node)...))
+	case childrenErrMsg:
+		delete(s.loader.loading, msg.nodeID)
 		return s, nil
+	case catalogMsg:
+		delete(s.loader.loading, catalogLoadingID)
+
+		// the whole catalog takes the place of the one loaded so far, keeping the columns and indexes already fetched.
+		mergeCatalog(s.root, msg.root)
+		if msg.refresh {
+			s.loader.changes, s.loader.removed = diffCatalog(s.root, msg.root)
+		}
+		s.root = msg.root
+		s.catalogLoaded = true
+
+		if s.filtering {
+			s.refreshMatches()
+		}
+
+		if err := s.rebuildTree(context.Background(), s.root.ID); err != nil {
+			return s, func() tea.Msg { return updateGraphErrMsg{err} }
+		}
+		return s, tea.Batch(s.catalogChanged(), s.loadRowCounts(tableContainers(s.root)...))
+	case catalogErrMsg:
+		delete(s.loader.loading, catalogLoadingID)
+		return s, nil
+	case rowCountsMsg:
+		s.setRowCounts(msg)
+		return s, nil
+	case spinner.TickMsg:
+		// the spinner stops once every node is loaded.
+		if len(s.loader.loading) == 0 {
+			return s, nil
+		}
+
+		s.loader.spinner, cmd = s.loader.spinner.Update(msg)
+		return s, cmd
 	case updateGraphErrMsg:
 		return s, nil
+	default:
+		// e.g. the blinking of the filter cursor.
+		if s.filtering {
+			s.filterInput, cmd = s.filterInput.Update(msg)
+		}
 	}
 
 	return s, cmd
 }
 
 func (s SidebarViewport) View() string {
-	s.sidebarViewport.SetContent(s.dbTree.View().Content)
+	if s.filtering {
+		s.sidebarViewport.SetContent(s.filterView())
+	} else {
+		s.sidebarViewport.SetContent(s.dbTree.View().Content)
+	}
 	sideViewContent := s.sidebarViewport.View()
 
 	listBorder := darkPurple
@@ -253,45 +474,310 @@ func (s SidebarViewport) View() string {
 	return sideViewContent
 }
 
-// updateGraph method refreshes the database catalog asynchronously, so it does not block the bubbletea execution.
-// If it succeeds, returns a updateGraphMsg with the a new database tree. Otherwise, it returns  updateGraphErrMsg with the error.
-func (s *SidebarViewport) updateGraph() tea.Cmd {
-	return func() tea.Msg {
+// reloadTargets method fetches again the parts of the catalog changed by DDL statements, asynchronously.
+// The tree keeps its expanded nodes and its focus once they are loaded.
+func (s *SidebarViewport) reloadTargets(targets []client.DDLTarget) tea.Cmd {
+	var cmds []tea.Cmd
+	reloading := make(map[string]bool)
+
+	for _, target := range targets {
+		for _, node := range affectedNodes(s.root, target) {
+			if reloading[node.ID] {
+				continue
+			}
+			reloading[node.ID] = true
+			cmds = append(cmds, s.startLoading(node))
+		}
+	}
+
+	return tea.Batch(cmds...)
+}
+
+// startLoading method marks the node as loading and fetches its children asynchronously.
+func (s *SidebarViewport) startLoading(node *client.DBNode) tea.Cmd {
+	return s.track(node.ID, s.loadChildren(node))
+}
+
+// loadCatalog method fetches the whole catalog asynchronously, so the filter matches every table, view and schema,
+// not only the ones loaded so far. The columns and indexes of the given tables are fetched as well.
+// A refresh marks the objects added and removed since then.
+func (s *SidebarViewport) loadCatalog(refresh bool, tables ...*client.DBNode) tea.Cmd {
+	return s.track(catalogLoadingID, func() tea.Msg {
 		ctx := context.Background()
+
 		root, err := s.c.Catalog(ctx)
 		if err != nil {
-			return updateGraphErrMsg{err}
+			return catalogErrMsg{err}
+		}
+
+		for _, table := range tables {
+			node := findDBNode(root, table.ID)
+			if node == nil {
+				// the table is gone.
+				continue
+			}
+
+			children, err := s.c.Children(ctx, table)
+			if err != nil {
+				return catalogErrMsg{err}
+			}
+			node.Children = children
+			node.Loaded = true
 		}
 
-		tree, err := treeview.NewTreeFromNestedData[*client.DBNode](
-			ctx,
-			[]*client.DBNode{root},
-			&DBGraphTreeBuilderProvider{},
-			treeview.WithProvider(createCyberpunkProvider()),
-		)
+		return catalogMsg{root: root, refresh: refresh}
+	})
+}
+
+// track method marks the given key as loading and returns the command that loads it, unless it's already loading.
+// The spinner starts ticking along with the first key being loaded.
+func (s *SidebarViewport) track(id string, load tea.Cmd) tea.Cmd {
+	if s.loader.loading[id] {
+		return nil
+	}
+
+	cmds := []tea.Cmd{load}
+	if len(s.loader.loading) == 0 {
+		cmds = append(cmds, s.loader.spinner.Tick)
+	}
+	s.loader.loading[id] = true
+
+	return tea.Batch(cmds...)
+}
+
+// loadChildren method fetches the children of a catalog node asynchronously.
+// If it succeeds, returns a childrenMsg with the new nodes. Otherwise, it returns childrenErrMsg with the error.
+func (s *SidebarViewport) loadChildren(node *client.DBNode) tea.Cmd {
+	// a copy of the node is passed to the client, since the original one belongs to the catalog tree.
+	target := *node
+	target.Children = nil
+
+	return func() tea.Msg {
+		children, err := s.c.Children(context.Background(), &target)
+		if err != nil {
+			return childrenErrMsg{nodeID: target.ID, err: err}
+		}
+
+		return childrenMsg{nodeID: target.ID, children: children}
+	}
+}
+
+// rebuildTree method builds the tree again out of the catalog root, after nodes were added to it.
+// The nodes that were expanded stay expanded, along with the ones passed as arguments, and the focus does not move.
+func (s *SidebarViewport) rebuildTree(ctx context.Context, expandIDs ...string) error {
+	var focus *client.DBNode
+	if focused := s.dbTree.GetFocusedNode(); focused != nil && focused.Data() != nil {
+		focus = *focused.Data()
+	}
+
+	expanded := make(map[string]bool)
+	for nodeInfo, err := range s.dbTree.All(ctx) {
+		if err != nil {
+			break
+		}
+
+		if nodeInfo.Node.IsExpanded() {
+			expanded[nodeInfo.Node.ID()] = true
+		}
+	}
+
+	for _, id := range expandIDs {
+		expanded[id] = true
+	}
+
+	tree, err := newDBTree(ctx, s.treeRoots(), s.loader)
+	if err != nil {
+		return err
+	}
+
+	for nodeInfo, err := range tree.All(ctx) {
 		if err != nil {
-			return updateGraphErrMsg{err}
+			return err
+		}
+
+		if expanded[nodeInfo.Node.ID()] {
+			nodeInfo.Node.Expand()
+		} else {
+			nodeInfo.Node.Collapse()
+		}
+	}
+
+	s.dbTree = s.newTuiTreeModel(tree, 0, s.height-2)
+	if focus != nil {
+		// if the focused node is gone, e.g. a dropped table, the focus moves to the node above it.
+		for _, id := range []string{focus.ID, focus.ParentID, s.root.ID} {
+			if ok, err := s.dbTree.SetFocusedID(ctx, id); ok && err == nil {
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// qualifiedColumnName method returns the name of a column prefixed by its table,
+// and the schema of the table for the databases that have one.
+func (s *SidebarViewport) qualifiedColumnName(column *client.DBNode) string {
+	table := findDBNode(s.root, column.ParentID)
+	if table == nil {
+		return column.EntityName
+	}
+
+	if s.c.HasSchemas() && table.ParentName != "" {
+		return fmt.Sprintf("%s.%s.%s", table.ParentName, table.EntityName, column.EntityName)
+	}
+
+	return fmt.Sprintf("%s.%s", table.EntityName, column.EntityName)
+}
+
+// treeRoots method returns the top level nodes of the tree: the bookmarks sections followed by the database.
+func (s *SidebarViewport) treeRoots() []*client.DBNode {
+	return append(bookmarkSections(s.bookmarks), s.root)
+}
+
+// newDBTree function builds a tree out of the database catalog and the bookmarks sections.
+func newDBTree(ctx context.Context, roots []*client.DBNode, loader *catalogLoader) (*treeview.Tree[*client.DBNode], error) {
+	return treeview.NewTreeFromNestedData[*client.DBNode](
+		ctx,
+		roots,
+		&DBGraphTreeBuilderProvider{removed: loader.removed},
+		treeview.WithProvider(createCyberpunkProvider(loader)),
+	)
+}
+
+// affectedNodes function returns the loaded nodes of the catalog changed by a DDL statement:
+// the schema of the object, or the database if there are no schemas, and the table whose columns or indexes changed.
+// The database is returned if the schema is not in the catalog, e.g. it was just created.
+func affectedNodes(root *client.DBNode, target client.DDLTarget) []*client.DBNode {
+	if root == nil || !root.Loaded {
+		return nil
+	}
+
+	if target.Catalog {
+		return []*client.DBNode{root}
+	}
+
+	container := root
+	if slices.ContainsFunc(root.Children, func(n *client.DBNode) bool { return n.Type == "schema" }) {
+		container = childNamed(root, "schema", target.Schema)
+		if container == nil {
+			return []*client.DBNode{root}
+		}
+	}
+
+	// the nodes not loaded yet are fetched when they are expanded.
+	if !container.Loaded {
+		return nil
+	}
+
+	nodes := []*client.DBNode{container}
+	if target.Table != "" {
+		if table := childNamed(container, "table", target.Table); table != nil && table.Loaded {
+			nodes = append(nodes, table)
+		}
+	}
+
+	return nodes
+}
+
+// childNamed function looks for the child of the given type and name, regardless of the case,
+// since unquoted identifiers are folded to lower or upper case depending on the database.
+func childNamed(parent *client.DBNode, nodeType, name string) *client.DBNode {
+	for _, child := range parent.Children {
+		if child.Type == nodeType && strings.EqualFold(child.EntityName, name) {
+			return child
+		}
+	}
+
+	return nil
+}
+
+// mergeChildren function copies the children loaded under the old nodes into the fresh ones with the same ID,
+// so fetching a node again does not drop what was loaded under it.
+func mergeChildren(old, fresh []*client.DBNode) {
+	loaded := make(map[string]*client.DBNode, len(old))
+	for _, node := range old {
+		if node.Loaded {
+			loaded[node.ID] = node
 		}
+	}
 
-		dbTree := s.newTuiTreeModel(tree, 0, s.height-2)
-		_, _ = dbTree.SetFocusedID(ctx, root.ID)
+	for _, node := range fresh {
+		if previous, ok := loaded[node.ID]; ok {
+			node.Children = previous.Children
+			node.Loaded = true
+		}
+	}
+}
 
-		return updateGraphMsg{tree: dbTree}
+// allLoaded function tells whether the children of every schema in the list were fetched.
+func allLoaded(nodes []*client.DBNode) bool {
+	for _, node := range nodes {
+		if node.Type == "schema" && !node.Loaded {
+			return false
+		}
 	}
+
+	return true
 }
 
-func createCyberpunkProvider() *treeview.DefaultNodeProvider[*client.DBNode] {
+// findDBNode function looks for the node with the given ID in the catalog.
+func findDBNode(root *client.DBNode, id string) *client.DBNode {
+	if root == nil {
+		return nil
+	}
+
+	if root.ID == id {
+		return root
+	}
+
+	for _, child := range root.Children {
+		if node := findDBNode(child, id); node != nil {
+			return node
+		}
+	}
+
+	return nil
+}
+
+func createCyberpunkProvider(loader *catalogLoader) *treeview.DefaultNodeProvider[*client.DBNode] {
 	// Icons for database objects.
 	databaseIconRule := treeview.WithIconRule(dbObjectHasType("database"), "⛃")
 	schemaIconRule := treeview.WithIconRule(dbObjectHasType("schema"), "📁")
 	tableIconRule := treeview.WithIconRule(dbObjectHasType("table"), "📋")
 	viewIconRule := treeview.WithIconRule(dbObjectHasType("view"), "📑")
+	materializedViewIconRule := treeview.WithIconRule(dbObjectHasType("materialized_view"), "🗂")
+	functionIconRule := treeview.WithIconRule(dbObjectHasType("function"), "ƒ")
+	procedureIconRule := treeview.WithIconRule(dbObjectHasType("procedure"), "⚙")
+	sequenceIconRule := treeview.WithIconRule(dbObjectHasType("sequence"), "🔢")
+	triggerIconRule := treeview.WithIconRule(dbObjectHasType("trigger"), "⚡")
+	typeIconRule := treeview.WithIconRule(dbObjectHasType("type"), "🔤")
+	columnIconRule := treeview.WithIconRule(dbObjectHasType("column"), "▫")
+	indexIconRule := treeview.WithIconRule(dbObjectHasType("index"), "🔍")
+	securityIconRule := treeview.WithIconRule(dbObjectHasType("security"), "🔒")
+	roleIconRule := treeview.WithIconRule(dbObjectHasType("role"), "👤")
+	membershipIconRule := treeview.WithIconRule(dbObjectHasType("membership"), "👥")
+	favoritesIconRule := treeview.WithIconRule(dbObjectHasType(favoritesNodeID), "★")
+	recentIconRule := treeview.WithIconRule(dbObjectHasType(recentNodeID), "🕘")
 
 	return treeview.NewDefaultNodeProvider[*client.DBNode](
 		databaseIconRule,
 		schemaIconRule,
 		tableIconRule,
 		viewIconRule,
+		materializedViewIconRule,
+		functionIconRule,
+		procedureIconRule,
+		sequenceIconRule,
+		triggerIconRule,
+		typeIconRule,
+		columnIconRule,
+		indexIconRule,
+		securityIconRule,
+		roleIconRule,
+		membershipIconRule,
+		favoritesIconRule,
+		recentIconRule,
 		treeview.WithStyleRule(
 			func(n *treeview.Node[*client.DBNode]) bool { return true },
 			lipgloss.NewStyle().
@@ -308,7 +794,27 @@ func createCyberpunkProvider() *treeview.DefaultNodeProvider[*client.DBNode] {
 				PaddingLeft(1),
 		),
 		treeview.WithFormatter(func(node *treeview.Node[*client.DBNode]) (string, bool) {
-			return node.Name(), true
+			if loader == nil {
+				return node.Name(), true
+			}
+
+			name := node.Name()
+			switch loader.changes[node.ID()] {
+			case nodeAdded:
+				name += " (new)"
+			case nodeRemoved:
+				name += " (removed)"
+			}
+
+			if count, ok := loader.rowCounts[node.ID()]; ok && loader.showRowCounts {
+				name += " ~" + formatRowCount(count)
+			}
+
+			// the database shows the spinner while the whole catalog is fetched.
+			if loader.loading[node.ID()] || ((*node.Data()).Type == "database" && loader.loading[catalogLoadingID]) {
+				name += " " + loader.spinner.View()
+			}
+			return name, true
 		}),
 	)
 }
@@ -0,0 +1,165 @@
+package bubbletui
+
+import (
+	"os"
+	"slices"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/danvergara/dblab/internal/catalogcache"
+	"github.com/danvergara/dblab/pkg/client"
+)
+
+// catalogChange tells whether a catalog node was added or removed since the catalog was cached.
+type catalogChange int
+
+const (
+	nodeAdded catalogChange = iota + 1
+	nodeRemoved
+)
+
+// catalogWordsMsg carries the names of the objects of the catalog, used by the editor to complete words.
+type catalogWordsMsg struct {
+	words []string
+}
+
+// diffCatalog function compares the catalog shown so far with the one just fetched.
+// It returns the nodes added and removed, and the removed nodes by the ID of their parent, so they are still shown.
+// Only the levels loaded in both catalogs are compared, the columns and indexes of the tables are left out.
+func diffCatalog(old, fresh *client.DBNode) (map[string]catalogChange, map[string][]*client.DBNode) {
+	changes := make(map[string]catalogChange)
+	removed := make(map[string][]*client.DBNode)
+
+	var walk func(o, f *client.DBNode)
+	walk = func(o, f *client.DBNode) {
+		if !o.Loaded || !f.Loaded || (f.Type != "database" && f.Type != "schema") {
+			return
+		}
+
+		previous := make(map[string]*client.DBNode, len(o.Children))
+		for _, child := range o.Children {
+			previous[child.ID] = child
+		}
+
+		for _, child := range f.Children {
+			if p, ok := previous[child.ID]; ok {
+				walk(p, child)
+				delete(previous, child.ID)
+				continue
+			}
+			changes[child.ID] = nodeAdded
+		}
+
+		// the nodes left are gone.
+		for _, child := range o.Children {
+			if _, ok := previous[child.ID]; ok {
+				changes[child.ID] = nodeRemoved
+				removed[f.ID] = append(removed[f.ID], child)
+			}
+		}
+	}
+
+	if old != nil && fresh != nil && old.ID == fresh.ID {
+		walk(old, fresh)
+	}
+
+	return changes, removed
+}
+
+// loadedTables function returns copies of the tables whose columns and indexes were loaded,
+// so they are fetched again along with the catalog.
+func loadedTables(root *client.DBNode) []*client.DBNode {
+	var tables []*client.DBNode
+
+	var walk func(node *client.DBNode)
+	walk = func(node *client.DBNode) {
+		if node.Type == "table" {
+			if node.Loaded {
+				table := *node
+				table.Children = nil
+				tables = append(tables, &table)
+			}
+			return
+		}
+
+		for _, child := range node.Children {
+			walk(child)
+		}
+	}
+
+	if root != nil {
+		walk(root)
+	}
+
+	return tables
+}
+
+// catalogWords function returns the sorted names of the schemas, tables, views and columns of the catalog,
+// along with the names of the tables and views qualified by their schema.
+func catalogWords(root *client.DBNode) []string {
+	var words []string
+
+	var walk func(node *client.DBNode, schema string)
+	walk = func(node *client.DBNode, schema string) {
+		switch node.Type {
+		case "schema":
+			schema = node.EntityName
+			words = append(words, node.EntityName)
+		case "table", "view", "materialized_view":
+			words = append(words, node.EntityName)
+			if schema != "" {
+				words = append(words, joinPath(schema, node.EntityName))
+			}
+		case "column":
+			words = append(words, node.EntityName)
+		}
+
+		for _, child := range node.Children {
+			walk(child, schema)
+		}
+	}
+
+	if root != nil {
+		walk(root, "")
+	}
+
+	slices.Sort(words)
+	return slices.Compact(words)
+}
+
+// refreshCatalog method fetches the whole catalog again in the background, along with the columns and indexes
+// of the tables loaded so far, and marks the objects added and removed since then.
+func (s *SidebarViewport) refreshCatalog() tea.Cmd {
+	return s.loadCatalog(true, loadedTables(s.root)...)
+}
+
+// catalogChanged method stores the catalog in the cache and sends its words to the editor,
+// after nodes were added to it.
+func (s *SidebarViewport) catalogChanged() tea.Cmd {
+	words := catalogWords(s.root)
+	wordsCmd := func() tea.Msg {
+		return catalogWordsMsg{words: words}
+	}
+
+	return tea.Batch(wordsCmd, s.saveCatalogCache())
+}
+
+// saveCatalogCache method stores the catalog of the connection asynchronously.
+func (s *SidebarViewport) saveCatalogCache() tea.Cmd {
+	identity := s.c.Identity()
+	// the command runs in another goroutine, so it gets its own copy of the catalog.
+	root := s.root.Clone()
+
+	return func() tea.Msg {
+		configDir, err := os.UserConfigDir()
+		if err != nil {
+			return errMsg{err: err}
+		}
+
+		if err := catalogcache.Save(configDir, identity, root); err != nil {
+			return errMsg{err: err}
+		}
+
+		return nil
+	}
+}
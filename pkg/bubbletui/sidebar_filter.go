@@ -0,0 +1,397 @@
+package bubbletui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/sahilm/fuzzy"
+
+	"github.com/danvergara/dblab/internal/bookmarks"
+	"github.com/danvergara/dblab/pkg/client"
+)
+
+// IDs of the sections shown at the top of the sidebar tree.
+const (
+	favoritesNodeID = "favorites"
+	recentNodeID    = "recent"
+)
+
+// catalogLoadingID is the key of the loader used while the whole catalog is fetched for the filter.
+const catalogLoadingID = "catalog"
+
+var (
+	filterMatchStyle    = lipgloss.NewStyle().Foreground(whiteText)
+	filterSelectedStyle = lipgloss.NewStyle().Foreground(cyberGreen).Background(darkPurple).Bold(true)
+	filterStatusStyle   = lipgloss.NewStyle().Foreground(mutedGreen)
+
+	// matchIcons are the icons of the nodes listed by the filter, the same ones shown in the tree.
+	matchIcons = map[string]string{
+		"schema":            "📁",
+		"table":             "📋",
+		"view":              "📑",
+		"materialized_view": "🗂",
+	}
+)
+
+// catalogMsg carries the whole database catalog, fetched in the background the first time the filter is opened,
+// or when the catalog is refreshed.
+type catalogMsg struct {
+	root    *client.DBNode
+	refresh bool
+}
+
+// catalogErrMsg struct used to report the failure to fetch the whole catalog.
+type catalogErrMsg struct{ err error }
+
+// catalogEntry is a schema, table or view the sidebar filter matches against.
+type catalogEntry struct {
+	node *client.DBNode
+	// path is the name of the node prefixed by its schema, e.g. "public.users".
+	path string
+	// ancestorIDs are the IDs of the nodes to expand to reach the node in the tree, from the top.
+	ancestorIDs []string
+}
+
+// catalogEntries is a list of catalog entries that implements fuzzy.Source.
+type catalogEntries []catalogEntry
+
+func (e catalogEntries) String(i int) string { return e[i].path }
+func (e catalogEntries) Len() int            { return len(e) }
+
+// collectCatalogEntries function walks the catalog and returns its schemas, tables and views.
+func collectCatalogEntries(root *client.DBNode) catalogEntries {
+	entries := make(catalogEntries, 0)
+
+	var walk func(node *client.DBNode, path string, ancestorIDs []string)
+	walk = func(node *client.DBNode, path string, ancestorIDs []string) {
+		switch node.Type {
+		case "schema":
+			path = node.EntityName
+			entries = append(entries, catalogEntry{node: node, path: path, ancestorIDs: ancestorIDs})
+		case "table", "view", "materialized_view":
+			entries = append(entries, catalogEntry{node: node, path: joinPath(path, node.EntityName), ancestorIDs: ancestorIDs})
+			// columns and indexes are not matched.
+			return
+		}
+
+		ids := append(ancestorIDs[:len(ancestorIDs):len(ancestorIDs)], node.ID)
+		for _, child := range node.Children {
+			walk(child, path, ids)
+		}
+	}
+
+	if root != nil {
+		walk(root, "", nil)
+	}
+
+	return entries
+}
+
+// matchCatalog function returns the entries that fuzzy-match the pattern, the best matches first.
+// An empty pattern matches every entry.
+func matchCatalog(entries catalogEntries, pattern string) []catalogEntry {
+	if strings.TrimSpace(pattern) == "" {
+		return entries
+	}
+
+	matches := fuzzy.FindFrom(pattern, entries)
+	result := make([]catalogEntry, 0, len(matches))
+	for _, match := range matches {
+		result = append(result, entries[match.Index])
+	}
+
+	return result
+}
+
+// mergeCatalog function copies the columns and indexes already loaded under the tables of the old catalog
+// into the new one, so they are not fetched again.
+func mergeCatalog(old, fresh *client.DBNode) {
+	for _, entry := range collectCatalogEntries(fresh) {
+		if entry.node.Type != "table" {
+			continue
+		}
+
+		if entry.node.Loaded {
+			continue
+		}
+
+		if table := findDBNode(old, entry.node.ID); table != nil && table.Loaded {
+			entry.node.Children = table.Children
+			entry.node.Loaded = true
+		}
+	}
+}
+
+// bookmarkSections function builds the favorites and the recently opened tables sections
+// shown at the top of the tree. Empty sections are left out.
+func bookmarkSections(b bookmarks.Bookmarks) []*client.DBNode {
+	sections := make([]*client.DBNode, 0, 2)
+
+	if len(b.Favorites) > 0 {
+		sections = append(sections, bookmarkSection(favoritesNodeID, "Favorites", b.Favorites))
+	}
+
+	if len(b.Recent) > 0 {
+		sections = append(sections, bookmarkSection(recentNodeID, "Recent", b.Recent))
+	}
+
+	return sections
+}
+
+func bookmarkSection(id, name string, list []bookmarks.Bookmark) *client.DBNode {
+	section := &client.DBNode{
+		ID:     id,
+		Name:   name,
+		Type:   id,
+		Loaded: true,
+	}
+
+	for _, bm := range list {
+		section.Children = append(section.Children, &client.DBNode{
+			ID:         fmt.Sprintf("%s:%s", id, bm.ID),
+			Name:       joinPath(bm.Schema, bm.Name),
+			EntityName: bm.Name,
+			Type:       bm.Type,
+			ParentID:   id,
+			ParentName: bm.Schema,
+			Loaded:     true,
+		})
+	}
+
+	return section
+}
+
+// isBookmarkNode function tells whether the node is an entry of the favorites or the recent section.
+func isBookmarkNode(node *client.DBNode) bool {
+	return node.ParentID == favoritesNodeID || node.ParentID == recentNodeID
+}
+
+// bookmarkOf function returns the bookmark pointing to the table or view of the node,
+// which could be either a catalog node or an entry of a bookmarks section.
+func bookmarkOf(node *client.DBNode) bookmarks.Bookmark {
+	id := node.ID
+	if isBookmarkNode(node) {
+		id = strings.TrimPrefix(node.ID, node.ParentID+":")
+	}
+
+	return bookmarks.Bookmark{
+		ID:     id,
+		Schema: node.ParentName,
+		Name:   node.EntityName,
+		Type:   node.Type,
+	}
+}
+
+func joinPath(schema, name string) string {
+	if schema == "" {
+		return name
+	}
+
+	return schema + "." + name
+}
+
+// openFilter method replaces the tree with the fuzzy filter.
+// The whole catalog is fetched in the background the first time, meanwhile the filter matches the nodes loaded so far.
+func (s *SidebarViewport) openFilter() tea.Cmd {
+	s.filtering = true
+	s.filterInput.Reset()
+	s.refreshMatches()
+
+	cmds := []tea.Cmd{s.filterInput.Focus()}
+	if !s.catalogLoaded {
+		cmds = append(cmds, s.loadCatalog(false))
+	}
+
+	return tea.Batch(cmds...)
+}
+
+// closeFilter method brings the tree back.
+func (s *SidebarViewport) closeFilter() {
+	s.filtering = false
+	s.filterInput.Blur()
+	s.matches = nil
+	s.matchCursor = 0
+}
+
+// updateFilter method handles the keys pressed while the filter is open.
+func (s SidebarViewport) updateFilter(msg tea.KeyPressMsg) (SidebarViewport, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		s.closeFilter()
+		return s, nil
+	case "enter":
+		if s.matchCursor >= len(s.matches) {
+			return s, nil
+		}
+
+		entry := s.matches[s.matchCursor]
+		s.closeFilter()
+		return s, s.jumpTo(entry)
+	case "up", "ctrl+p":
+		if s.matchCursor > 0 {
+			s.matchCursor--
+		}
+		return s, nil
+	case "down", "ctrl+n":
+		if s.matchCursor < len(s.matches)-1 {
+			s.matchCursor++
+		}
+		return s, nil
+	}
+
+	var cmd tea.Cmd
+	pattern := s.filterInput.Value()
+	s.filterInput, cmd = s.filterInput.Update(msg)
+
+	if s.filterInput.Value() != pattern {
+		s.matchCursor = 0
+		s.refreshMatches()
+	}
+
+	return s, cmd
+}
+
+// refreshMatches method matches the catalog against the text typed in the filter.
+func (s *SidebarViewport) refreshMatches() {
+	s.matches = matchCatalog(collectCatalogEntries(s.root), s.filterInput.Value())
+	if s.matchCursor >= len(s.matches) {
+		s.matchCursor = max(len(s.matches)-1, 0)
+	}
+}
+
+// jumpTo method expands the nodes above the matched one and moves the focus to it.
+// Tables and views are opened as well.
+func (s *SidebarViewport) jumpTo(entry catalogEntry) tea.Cmd {
+	ctx := context.Background()
+
+	if err := s.rebuildTree(ctx, entry.ancestorIDs...); err != nil {
+		return func() tea.Msg { return updateGraphErrMsg{err} }
+	}
+	_, _ = s.dbTree.SetFocusedID(ctx, entry.node.ID)
+
+	switch entry.node.Type {
+	case "table", "view", "materialized_view":
+		return s.openNode(entry.node)
+	}
+
+	return nil
+}
+
+// openNode method selects a table or a view, so its metadata is shown, and moves it to the top of the recent section.
+func (s *SidebarViewport) openNode(node *client.DBNode) tea.Cmd {
+	var schema string
+	if s.c.HasSchemas() {
+		schema = node.ParentName
+	}
+
+	var selectMsg tea.Msg = selectViewMsg{Schema: schema, View: node.EntityName}
+	if node.Type == "table" {
+		selectMsg = selectTableMsg{Schema: schema, Table: node.EntityName}
+	}
+
+	// a new section shows up expanded.
+	var expandIDs []string
+	if len(s.bookmarks.Recent) == 0 {
+		expandIDs = append(expandIDs, recentNodeID)
+	}
+	s.bookmarks.Touch(bookmarkOf(node))
+
+	cmds := []tea.Cmd{
+		func() tea.Msg { return selectMsg },
+		s.saveBookmarks(),
+	}
+
+	if err := s.rebuildTree(context.Background(), expandIDs...); err != nil {
+		cmds = append(cmds, func() tea.Msg { return updateGraphErrMsg{err} })
+	}
+
+	return tea.Batch(cmds...)
+}
+
+// toggleFavorite method adds the focused table or view to the favorites, or removes it if it was one already.
+func (s *SidebarViewport) toggleFavorite() tea.Cmd {
+	focused := s.dbTree.GetFocusedNode()
+	if focused == nil || focused.Data() == nil {
+		return nil
+	}
+
+	node := *focused.Data()
+	switch node.Type {
+	case "table", "view", "materialized_view":
+	default:
+		return nil
+	}
+
+	var expandIDs []string
+	if len(s.bookmarks.Favorites) == 0 {
+		expandIDs = append(expandIDs, favoritesNodeID)
+	}
+	s.bookmarks.ToggleFavorite(bookmarkOf(node))
+
+	if err := s.rebuildTree(context.Background(), expandIDs...); err != nil {
+		return func() tea.Msg { return updateGraphErrMsg{err} }
+	}
+
+	return s.saveBookmarks()
+}
+
+// saveBookmarks method stores the bookmarks of the connection asynchronously.
+func (s *SidebarViewport) saveBookmarks() tea.Cmd {
+	identity := s.c.Identity()
+	// the command runs in another goroutine, so it gets its own copy of the bookmarks.
+	b := bookmarks.Bookmarks{
+		Favorites: slices.Clone(s.bookmarks.Favorites),
+		Recent:    slices.Clone(s.bookmarks.Recent),
+	}
+
+	return func() tea.Msg {
+		configDir, err := os.UserConfigDir()
+		if err != nil {
+			return errMsg{err: err}
+		}
+
+		if err := bookmarks.Save(configDir, identity, b); err != nil {
+			return errMsg{err: err}
+		}
+
+		return nil
+	}
+}
+
+// filterView method renders the filter input followed by the matches, with their schema path.
+func (s SidebarViewport) filterView() string {
+	var b strings.Builder
+
+	b.WriteString(s.filterInput.View())
+	b.WriteString("\n")
+
+	status := fmt.Sprintf("%d matches", len(s.matches))
+	if s.loader.loading[catalogLoadingID] {
+		status += " " + s.loader.spinner.View() + " loading the catalog"
+	}
+	b.WriteString(filterStatusStyle.Render(status))
+
+	// the list scrolls along with the cursor.
+	visible := max(s.height-2, 1)
+	start := max(s.matchCursor-visible+1, 0)
+	end := min(start+visible, len(s.matches))
+
+	for i := start; i < end; i++ {
+		entry := s.matches[i]
+		line := matchIcons[entry.node.Type] + " " + entry.path
+
+		b.WriteString("\n")
+		if i == s.matchCursor {
+			b.WriteString(filterSelectedStyle.Render("> " + line))
+		} else {
+			b.WriteString(filterMatchStyle.Render("  " + line))
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,125 @@
+package bubbletui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/danvergara/dblab/pkg/client"
+)
+
+// rowCountsMsg carries the approximate row count of the tables under a catalog node, by table name.
+type rowCountsMsg struct {
+	parentID string
+	counts   map[string]int64
+}
+
+// toggleRowCounts method shows or hides the approximate row count of the tables of the sidebar.
+// The counts are fetched every time they are shown, so they are up to date.
+func (s *SidebarViewport) toggleRowCounts() tea.Cmd {
+	s.loader.showRowCounts = !s.loader.showRowCounts
+	if !s.loader.showRowCounts {
+		s.loader.rowCounts = nil
+		return nil
+	}
+
+	return s.loadRowCounts(tableContainers(s.root)...)
+}
+
+// loadRowCounts method fetches asynchronously the approximate row count of the tables under the given nodes,
+// if they are shown. A failure to read them leaves the tables with no count.
+func (s *SidebarViewport) loadRowCounts(nodes ...*client.DBNode) tea.Cmd {
+	if !s.loader.showRowCounts {
+		return nil
+	}
+
+	c := s.c
+	cmds := make([]tea.Cmd, 0, len(nodes))
+	for _, node := range nodes {
+		// the tables of the databases with no schemas hang from the database node.
+		var schema string
+		if node.Type == "schema" {
+			schema = node.EntityName
+		}
+
+		parentID := node.ID
+		cmds = append(cmds, func() tea.Msg {
+			counts, err := c.RowCounts(context.Background(), schema)
+			if err != nil {
+				return nil
+			}
+			return rowCountsMsg{parentID: parentID, counts: counts}
+		})
+	}
+
+	return tea.Batch(cmds...)
+}
+
+// setRowCounts method keeps the row counts of the tables under a catalog node, matched by name regardless of the case.
+func (s *SidebarViewport) setRowCounts(msg rowCountsMsg) {
+	if !s.loader.showRowCounts {
+		return
+	}
+
+	node := findDBNode(s.root, msg.parentID)
+	if node == nil {
+		return
+	}
+
+	if s.loader.rowCounts == nil {
+		s.loader.rowCounts = make(map[string]int64)
+	}
+
+	for name, count := range msg.counts {
+		if table := childNamed(node, "table", name); table != nil {
+			s.loader.rowCounts[table.ID] = count
+		}
+	}
+}
+
+// tableContainers function returns the loaded nodes of the catalog that hold tables:
+// the schemas, or the database for the ones with no schemas.
+func tableContainers(root *client.DBNode) []*client.DBNode {
+	if root == nil {
+		return nil
+	}
+
+	var containers []*client.DBNode
+	for _, child := range root.Children {
+		if child.Type == "table" {
+			containers = append(containers, root)
+			break
+		}
+	}
+
+	for _, child := range root.Children {
+		if child.Type == "schema" && child.Loaded {
+			containers = append(containers, tableContainers(child)...)
+		}
+	}
+
+	return containers
+}
+
+// formatRowCount function returns a row count in a short form, e.g. 1.2k or 3.4M.
+func formatRowCount(count int64) string {
+	units := []struct {
+		size   int64
+		suffix string
+	}{
+		{1_000_000_000, "B"},
+		{1_000_000, "M"},
+		{1_000, "k"},
+	}
+
+	for _, unit := range units {
+		if count >= unit.size {
+			text := fmt.Sprintf("%.1f", float64(count)/float64(unit.size))
+			return strings.TrimSuffix(text, ".0") + unit.suffix
+		}
+	}
+
+	return fmt.Sprintf("%d", count)
+}
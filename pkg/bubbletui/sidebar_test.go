@@ -0,0 +1,298 @@
+package bubbletui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/danvergara/dblab/internal/bookmarks"
+	"github.com/danvergara/dblab/pkg/client"
+)
+
+func TestFindDBNode(t *testing.T) {
+	column := &client.DBNode{ID: "db:shop.s:public.t:users.c:id", Type: "column"}
+	table := &client.DBNode{ID: "db:shop.s:public.t:users", Type: "table", Children: []*client.DBNode{column}}
+	schema := &client.DBNode{ID: "db:shop.s:public", Type: "schema", Children: []*client.DBNode{table}}
+	root := &client.DBNode{ID: "db:shop", Type: "database", Children: []*client.DBNode{schema}}
+
+	assert.Same(t, root, findDBNode(root, "db:shop"))
+	assert.Same(t, table, findDBNode(root, "db:shop.s:public.t:users"))
+	assert.Same(t, column, findDBNode(root, "db:shop.s:public.t:users.c:id"))
+	assert.Nil(t, findDBNode(root, "db:shop.s:public.t:orders"))
+	assert.Nil(t, findDBNode(nil, "db:shop"))
+}
+
+func testCatalog() *client.DBNode {
+	users := &client.DBNode{ID: "db:shop.s:public.t:users", EntityName: "users", Type: "table", ParentName: "public", ParentID: "db:shop.s:public"}
+	orders := &client.DBNode{ID: "db:shop.s:public.t:orders", EntityName: "orders", Type: "table", ParentName: "public", ParentID: "db:shop.s:public"}
+	totals := &client.DBNode{ID: "db:shop.s:sales.v:order_totals", EntityName: "order_totals", Type: "view", ParentName: "sales", ParentID: "db:shop.s:sales"}
+	nextID := &client.DBNode{ID: "db:shop.s:sales.sq:next_id", EntityName: "next_id", Type: "sequence", ParentName: "sales", ParentID: "db:shop.s:sales"}
+	public := &client.DBNode{ID: "db:shop.s:public", EntityName: "public", Type: "schema", Children: []*client.DBNode{users, orders}}
+	sales := &client.DBNode{ID: "db:shop.s:sales", EntityName: "sales", Type: "schema", Children: []*client.DBNode{totals, nextID}}
+
+	return &client.DBNode{ID: "db:shop", Type: "database", Children: []*client.DBNode{public, sales}}
+}
+
+func TestCollectCatalogEntries(t *testing.T) {
+	entries := collectCatalogEntries(testCatalog())
+
+	paths := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		paths = append(paths, entry.path)
+	}
+
+	assert.Equal(t, []string{"public", "public.users", "public.orders", "sales", "sales.order_totals"}, paths)
+	assert.Equal(t, []string{"db:shop"}, entries[0].ancestorIDs)
+	assert.Equal(t, []string{"db:shop", "db:shop.s:sales"}, entries[4].ancestorIDs)
+	assert.Empty(t, collectCatalogEntries(nil))
+}
+
+func TestMatchCatalog(t *testing.T) {
+	entries := collectCatalogEntries(testCatalog())
+
+	var tests = []struct {
+		name    string
+		pattern string
+		want    []string
+	}{
+		{name: "Empty pattern", pattern: "", want: []string{"public", "public.users", "public.orders", "sales", "sales.order_totals"}},
+		{name: "Table name", pattern: "usr", want: []string{"public.users"}},
+		{name: "Schema path", pattern: "sal.tot", want: []string{"sales.order_totals"}},
+		{name: "No matches", pattern: "xyz", want: []string{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			paths := make([]string, 0)
+			for _, entry := range matchCatalog(entries, test.pattern) {
+				paths = append(paths, entry.path)
+			}
+
+			assert.Equal(t, test.want, paths)
+		})
+	}
+}
+
+func TestMergeCatalog(t *testing.T) {
+	old := testCatalog()
+	users := findDBNode(old, "db:shop.s:public.t:users")
+	users.Children = []*client.DBNode{{ID: "db:shop.s:public.t:users.c:id", Type: "column"}}
+	users.Loaded = true
+
+	fresh := testCatalog()
+	mergeCatalog(old, fresh)
+
+	merged := findDBNode(fresh, "db:shop.s:public.t:users")
+	assert.True(t, merged.Loaded)
+	assert.Len(t, merged.Children, 1)
+	assert.False(t, findDBNode(fresh, "db:shop.s:public.t:orders").Loaded)
+}
+
+func TestBookmarkSections(t *testing.T) {
+	users := findDBNode(testCatalog(), "db:shop.s:public.t:users")
+
+	var b bookmarks.Bookmarks
+	assert.Empty(t, bookmarkSections(b))
+
+	b.ToggleFavorite(bookmarkOf(users))
+	sections := bookmarkSections(b)
+	assert.Len(t, sections, 1)
+	assert.Equal(t, favoritesNodeID, sections[0].ID)
+
+	entry := sections[0].Children[0]
+	assert.Equal(t, "favorites:db:shop.s:public.t:users", entry.ID)
+	assert.Equal(t, "public.users", entry.Name)
+	assert.True(t, isBookmarkNode(entry))
+	assert.False(t, isBookmarkNode(users))
+
+	// the bookmark of an entry points to the catalog node, not to the entry.
+	assert.Equal(t, bookmarkOf(users), bookmarkOf(entry))
+
+	b.Touch(bookmarkOf(users))
+	sections = bookmarkSections(b)
+	assert.Len(t, sections, 2)
+	assert.Equal(t, recentNodeID, sections[1].ID)
+}
+
+func TestAffectedNodes(t *testing.T) {
+	root := testCatalog()
+	root.Loaded = true
+	public := findDBNode(root, "db:shop.s:public")
+	public.Loaded = true
+	users := findDBNode(root, "db:shop.s:public.t:users")
+	users.Loaded = true
+
+	ids := func(nodes []*client.DBNode) []string {
+		result := make([]string, 0, len(nodes))
+		for _, node := range nodes {
+			result = append(result, node.ID)
+		}
+		return result
+	}
+
+	var tests = []struct {
+		name   string
+		target client.DDLTarget
+		want   []string
+	}{
+		{name: "Schema", target: client.DDLTarget{Schema: "public"}, want: []string{"db:shop.s:public"}},
+		{name: "Schema folded to upper case", target: client.DDLTarget{Schema: "PUBLIC"}, want: []string{"db:shop.s:public"}},
+		{name: "Altered table", target: client.DDLTarget{Schema: "public", Table: "users"}, want: []string{"db:shop.s:public", "db:shop.s:public.t:users"}},
+		{name: "Table not loaded", target: client.DDLTarget{Schema: "public", Table: "orders"}, want: []string{"db:shop.s:public"}},
+		{name: "Schema not loaded", target: client.DDLTarget{Schema: "sales"}, want: []string{}},
+		{name: "New schema", target: client.DDLTarget{Schema: "reports"}, want: []string{"db:shop"}},
+		{name: "Catalog", target: client.DDLTarget{Catalog: true}, want: []string{"db:shop"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, ids(affectedNodes(root, test.target)))
+		})
+	}
+
+	// databases without schemas, like MySQL or SQLite.
+	table := &client.DBNode{ID: "db:shop.t:users", EntityName: "users", Type: "table", Loaded: true}
+	flat := &client.DBNode{ID: "db:shop", Type: "database", Loaded: true, Children: []*client.DBNode{table}}
+	assert.Equal(t, []string{"db:shop", "db:shop.t:users"}, ids(affectedNodes(flat, client.DDLTarget{Table: "users"})))
+}
+
+func TestMergeChildren(t *testing.T) {
+	column := &client.DBNode{ID: "db:shop.t:users.c:id", Type: "column"}
+	old := []*client.DBNode{
+		{ID: "db:shop.t:users", Type: "table", Loaded: true, Children: []*client.DBNode{column}},
+		{ID: "db:shop.t:orders", Type: "table"},
+	}
+	fresh := []*client.DBNode{
+		{ID: "db:shop.t:users", Type: "table"},
+		{ID: "db:shop.t:orders", Type: "table"},
+		{ID: "db:shop.t:payments", Type: "table"},
+	}
+
+	mergeChildren(old, fresh)
+
+	assert.True(t, fresh[0].Loaded)
+	assert.Equal(t, []*client.DBNode{column}, fresh[0].Children)
+	assert.False(t, fresh[1].Loaded)
+	assert.False(t, fresh[2].Loaded)
+}
+
+func loadedCatalog() *client.DBNode {
+	root := testCatalog()
+	root.Loaded = true
+	for _, schema := range root.Children {
+		schema.Loaded = true
+	}
+
+	return root
+}
+
+func TestDiffCatalog(t *testing.T) {
+	old := loadedCatalog()
+	fresh := loadedCatalog()
+
+	// drops orders and creates invoices in the fresh catalog.
+	public := fresh.Children[0]
+	invoices := &client.DBNode{ID: "db:shop.s:public.t:invoices", EntityName: "invoices", Type: "table", ParentID: public.ID}
+	public.Children = []*client.DBNode{public.Children[0], invoices}
+
+	changes, removed := diffCatalog(old, fresh)
+
+	assert.Equal(t, map[string]catalogChange{
+		"db:shop.s:public.t:invoices": nodeAdded,
+		"db:shop.s:public.t:orders":   nodeRemoved,
+	}, changes)
+	assert.Len(t, removed["db:shop.s:public"], 1)
+	assert.Equal(t, "db:shop.s:public.t:orders", removed["db:shop.s:public"][0].ID)
+
+	// the schemas not loaded in both catalogs are not compared.
+	fresh.Children[1].Loaded = false
+	fresh.Children[1].Children = nil
+	changes, _ = diffCatalog(old, fresh)
+	assert.NotContains(t, changes, "db:shop.s:sales.v:order_totals")
+
+	changes, removed = diffCatalog(old, &client.DBNode{ID: "db:other", Loaded: true})
+	assert.Empty(t, changes)
+	assert.Empty(t, removed)
+}
+
+func TestLoadedTables(t *testing.T) {
+	root := testCatalog()
+	users := root.Children[0].Children[0]
+	users.Loaded = true
+	users.Children = []*client.DBNode{{ID: users.ID + ".c:id", EntityName: "id", Type: "column", ParentID: users.ID}}
+
+	tables := loadedTables(root)
+
+	assert.Len(t, tables, 1)
+	assert.Equal(t, users.ID, tables[0].ID)
+	assert.Empty(t, tables[0].Children)
+	// the catalog itself is left untouched.
+	assert.Len(t, users.Children, 1)
+	assert.Empty(t, loadedTables(nil))
+}
+
+func TestCatalogWords(t *testing.T) {
+	root := testCatalog()
+	users := root.Children[0].Children[0]
+	users.Children = []*client.DBNode{{ID: users.ID + ".c:id", EntityName: "id", Type: "column", ParentID: users.ID}}
+
+	assert.Equal(t, []string{
+		"id",
+		"order_totals",
+		"orders",
+		"public",
+		"public.orders",
+		"public.users",
+		"sales",
+		"sales.order_totals",
+		"users",
+	}, catalogWords(root))
+	assert.Empty(t, catalogWords(nil))
+}
+
+func TestTableContainers(t *testing.T) {
+	root := testCatalog()
+	public, sales := root.Children[0], root.Children[1]
+	public.Loaded = true
+	sales.Loaded = true
+
+	// the schemas not loaded yet have no tables to count.
+	assert.Equal(t, []*client.DBNode{public}, tableContainers(root))
+
+	public.Loaded = false
+	assert.Empty(t, tableContainers(root))
+
+	// the tables of the databases with no schemas hang from the database node.
+	sqlite := &client.DBNode{ID: "db:shop", Type: "database", Children: []*client.DBNode{{ID: "db:shop.t:users", EntityName: "users", Type: "table"}}}
+	assert.Equal(t, []*client.DBNode{sqlite}, tableContainers(sqlite))
+	assert.Empty(t, tableContainers(nil))
+}
+
+func TestSetRowCounts(t *testing.T) {
+	root := testCatalog()
+	s := SidebarViewport{root: root, loader: &catalogLoader{showRowCounts: true}}
+
+	s.setRowCounts(rowCountsMsg{parentID: "db:shop.s:public", counts: map[string]int64{"USERS": 1200, "invoices": 3}})
+
+	assert.Equal(t, map[string]int64{"db:shop.s:public.t:users": 1200}, s.loader.rowCounts)
+}
+
+func TestFormatRowCount(t *testing.T) {
+	var tests = []struct {
+		given int64
+		want  string
+	}{
+		{given: 0, want: "0"},
+		{given: 999, want: "999"},
+		{given: 1000, want: "1k"},
+		{given: 1234, want: "1.2k"},
+		{given: 3_400_000, want: "3.4M"},
+		{given: 5_000_000_000, want: "5B"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.want, func(t *testing.T) {
+			assert.Equal(t, tc.want, formatRowCount(tc.given))
+		})
+	}
+}
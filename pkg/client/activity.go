@@ -0,0 +1,108 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrReadOnly is returned by the actions that change the state of the server on read-only connections,
+// like cancelling or terminating a session.
+var ErrReadOnly = errors.New("not allowed on a read-only connection")
+
+// Session is a connection to the database server, along with the query it's running, if any.
+type Session struct {
+	// ID identifies the session on the server, e.g. the pid on Postgres, or the sid and serial# on Oracle.
+	ID       string
+	User     string
+	Database string
+	State    string
+	// Wait is the event the session is waiting for, if any, e.g. a lock.
+	Wait     string
+	Query    string
+	Duration time.Duration
+}
+
+// ReadOnly method tells whether the connection is read-only.
+func (c *Client) ReadOnly() bool {
+	return c.readOnly
+}
+
+// Activity returns the sessions of the server, but the one of dblab itself, the longest running first.
+func (c *Client) Activity(ctx context.Context) ([]Session, error) {
+	query, args, err := c.databaseQuerier.Activity()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := queryStrings(ctx, c.db, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]Session, 0, len(rows))
+	for _, row := range rows {
+		if len(row) < 7 {
+			return nil, fmt.Errorf("unexpected number of columns in the activity of the server: %d", len(row))
+		}
+
+		// the duration is given in seconds, with a fraction on some databases.
+		seconds, _ := strconv.ParseFloat(strings.TrimSpace(row[4]), 64)
+
+		sessions = append(sessions, Session{
+			ID:       row[0],
+			User:     row[1],
+			Database: row[2],
+			State:    row[3],
+			Duration: time.Duration(seconds * float64(time.Second)),
+			Wait:     row[5],
+			Query:    row[6],
+		})
+	}
+
+	return sessions, nil
+}
+
+// CancelSession cancels the query running on a session, leaving the session open.
+func (c *Client) CancelSession(ctx context.Context, id string) error {
+	if c.readOnly {
+		return ErrReadOnly
+	}
+
+	query, args, err := c.databaseQuerier.CancelSession(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.db.ExecContext(ctx, query, args...)
+	return err
+}
+
+// TerminateSession closes a session, rolling back its open transaction, if any.
+func (c *Client) TerminateSession(ctx context.Context, id string) error {
+	if c.readOnly {
+		return ErrReadOnly
+	}
+
+	query, args, err := c.databaseQuerier.TerminateSession(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.db.ExecContext(ctx, query, args...)
+	return err
+}
+
+// sessionNumber function parses the ID of a session that's a number, like a Postgres pid or a MySQL thread ID.
+// The statements that take no placeholders, like KILL, get the number written in, so it's checked first.
+func sessionNumber(id string) (int64, error) {
+	n, err := strconv.ParseInt(strings.TrimSpace(id), 10, 64)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid session ID %q", id)
+	}
+
+	return n, nil
+}
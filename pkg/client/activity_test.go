@@ -0,0 +1,116 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/danvergara/dblab/pkg/drivers"
+)
+
+func TestSessionActionsOnReadOnlyConnections(t *testing.T) {
+	c := &Client{driver: drivers.Postgres, readOnly: true, databaseQuerier: newPostgres("users", "public", nil)}
+
+	require.True(t, c.ReadOnly())
+	require.ErrorIs(t, c.CancelSession(context.Background(), "42"), ErrReadOnly)
+	require.ErrorIs(t, c.TerminateSession(context.Background(), "42"), ErrReadOnly)
+}
+
+func TestSessionStatements(t *testing.T) {
+	type want struct {
+		cancel    string
+		terminate string
+		args      []any
+	}
+
+	var tests = []struct {
+		name    string
+		querier databaseQuerier
+		id      string
+		want    want
+	}{
+		{
+			name:    "postgres",
+			querier: newPostgres("users", "public", nil),
+			id:      "42",
+			want: want{
+				cancel:    "SELECT pg_cancel_backend($1)",
+				terminate: "SELECT pg_terminate_backend($1)",
+				args:      []any{int64(42)},
+			},
+		},
+		{
+			name:    "cockroachdb",
+			querier: newCockroachDB("users", "public", nil),
+			id:      "17a3c9f1e2b4d5a60000000000000001",
+			want: want{
+				cancel:    "CANCEL QUERY $1",
+				terminate: "CANCEL SESSIONS SELECT session_id FROM crdb_internal.cluster_queries WHERE query_id = $1",
+				args:      []any{"17a3c9f1e2b4d5a60000000000000001"},
+			},
+		},
+		{
+			name:    "redshift",
+			querier: newRedshift("users", "public", nil),
+			id:      "42",
+			want: want{
+				cancel:    "SELECT pg_cancel_backend($1)",
+				terminate: "SELECT pg_terminate_backend($1)",
+				args:      []any{int64(42)},
+			},
+		},
+		{
+			name:    "mysql",
+			querier: newMySQL("users", nil),
+			id:      "42",
+			want: want{
+				cancel:    "KILL QUERY 42",
+				terminate: "KILL 42",
+			},
+		},
+		{
+			name:    "oracle",
+			querier: newOracle("users", "app", nil),
+			id:      "42,1337",
+			want: want{
+				cancel:    "ALTER SYSTEM CANCEL SQL '42,1337'",
+				terminate: "ALTER SYSTEM KILL SESSION '42,1337' IMMEDIATE",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			query, args, err := tc.querier.CancelSession(tc.id)
+			require.NoError(t, err)
+			require.Equal(t, tc.want.cancel, query)
+			require.Equal(t, tc.want.args, args)
+
+			query, args, err = tc.querier.TerminateSession(tc.id)
+			require.NoError(t, err)
+			require.Equal(t, tc.want.terminate, query)
+			require.Equal(t, tc.want.args, args)
+		})
+	}
+}
+
+func TestSessionStatementsRejectInvalidIDs(t *testing.T) {
+	for _, id := range []string{"", "abc", "1; DROP TABLE users", "-1"} {
+		_, _, err := newMySQL("users", nil).TerminateSession(id)
+		require.Error(t, err, id)
+	}
+
+	for _, id := range []string{"42", "42,", "42,1337' IMMEDIATE --"} {
+		_, _, err := newOracle("users", "app", nil).TerminateSession(id)
+		require.Error(t, err, id)
+	}
+
+	// SQL Server can't cancel the requests of other sessions.
+	_, _, err := newMSSQL("users", "dbo", nil).CancelSession("42")
+	require.Error(t, err)
+
+	query, _, err := newMSSQL("users", "dbo", nil).TerminateSession("42")
+	require.NoError(t, err)
+	require.Equal(t, "KILL 42", query)
+}
@@ -0,0 +1,280 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// nodeKind describes how the database objects of a given type are shown in the catalog.
+type nodeKind struct {
+	// Type is the value of DBNode.Type.
+	Type string
+	// idPrefix is used to build a unique DBNode.ID under the parent node.
+	idPrefix string
+	// suffix is appended to the name shown on the TUI.
+	suffix string
+}
+
+// Kinds of the database objects listed in the catalog, besides databases, schemas, tables and views.
+var (
+	materializedViewKind = nodeKind{Type: "materialized_view", idPrefix: "mv", suffix: "mv"}
+	functionKind         = nodeKind{Type: "function", idPrefix: "f", suffix: "f"}
+	procedureKind        = nodeKind{Type: "procedure", idPrefix: "p", suffix: "p"}
+	sequenceKind         = nodeKind{Type: "sequence", idPrefix: "sq", suffix: "sq"}
+	triggerKind          = nodeKind{Type: "trigger", idPrefix: "tg", suffix: "tg"}
+	typeKind             = nodeKind{Type: "type", idPrefix: "ty", suffix: "ty"}
+	columnKind           = nodeKind{Type: "column", idPrefix: "c"}
+	indexKind            = nodeKind{Type: "index", idPrefix: "i", suffix: "i"}
+)
+
+// ObjectRef points to a database object that is neither a table nor a view,
+// such as a function, a procedure, a sequence, a trigger or a user-defined type.
+// Type is one of the DBNode types.
+type ObjectRef struct {
+	Schema string
+	Name   string
+	Type   string
+}
+
+// queryNodes runs a query that returns the names of database objects of the same kind, in a single column,
+// and turns every row into a DBNode under the given parent.
+func queryNodes(
+	ctx context.Context,
+	db *sqlx.DB,
+	kind nodeKind,
+	parentName, parentID string,
+	query string,
+	args ...any,
+) ([]*DBNode, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	nodes := make([]*DBNode, 0)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, &DBNode{
+			ID:         fmt.Sprintf("%s.%s:%s", parentID, kind.idPrefix, name),
+			Name:       name + " - " + kind.suffix,
+			EntityName: name,
+			Type:       kind.Type,
+			ParentName: parentName,
+			ParentID:   parentID,
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return nodes, nil
+}
+
+// queryColumnNodes runs a CatalogColumns query and turns every column into a DBNode under the table node.
+func queryColumnNodes(
+	ctx context.Context,
+	db *sqlx.DB,
+	parentName, parentID string,
+	query string,
+	args ...any,
+) ([]*DBNode, error) {
+	columns, err := queryColumns(ctx, db, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]*DBNode, 0, len(columns))
+	for _, column := range columns {
+		nodes = append(nodes, &DBNode{
+			ID:         fmt.Sprintf("%s.%s:%s", parentID, columnKind.idPrefix, column.Name),
+			Name:       columnNodeName(column.Name, column.DataType, column.Nullable, column.PrimaryKey, column.ForeignKey),
+			EntityName: column.Name,
+			Type:       columnKind.Type,
+			ParentName: parentName,
+			ParentID:   parentID,
+		})
+	}
+
+	return nodes, nil
+}
+
+// queryColumns runs a CatalogColumns query.
+// The query returns the name, the data type and three YES/NO flags: nullable, primary key and foreign key.
+func queryColumns(ctx context.Context, db *sqlx.DB, query string, args ...any) ([]Column, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns := make([]Column, 0)
+	for rows.Next() {
+		var name, dataType, nullable, pk, fk string
+		if err := rows.Scan(&name, &dataType, &nullable, &pk, &fk); err != nil {
+			return nil, err
+		}
+		columns = append(columns, Column{
+			Name:       name,
+			DataType:   dataType,
+			Nullable:   isYes(nullable),
+			PrimaryKey: isYes(pk),
+			ForeignKey: isYes(fk),
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return columns, nil
+}
+
+// queryIndexNodes runs a CatalogIndexes query and turns every index into a DBNode under the table node.
+func queryIndexNodes(
+	ctx context.Context,
+	db *sqlx.DB,
+	parentName, parentID string,
+	query string,
+	args ...any,
+) ([]*DBNode, error) {
+	indexes, err := queryIndexes(ctx, db, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]*DBNode, 0, len(indexes))
+	for _, index := range indexes {
+		label := index.Name
+		if index.Unique {
+			label += " (unique)"
+		}
+
+		nodes = append(nodes, &DBNode{
+			ID:         fmt.Sprintf("%s.%s:%s", parentID, indexKind.idPrefix, index.Name),
+			Name:       label + " - " + indexKind.suffix,
+			EntityName: index.Name,
+			Type:       indexKind.Type,
+			ParentName: parentName,
+			ParentID:   parentID,
+		})
+	}
+
+	return nodes, nil
+}
+
+// queryIndexes runs a CatalogIndexes query.
+// The query returns the name of the index, a YES/NO flag telling if it is unique
+// and the comma separated list of the columns or expressions it's built on.
+func queryIndexes(ctx context.Context, db *sqlx.DB, query string, args ...any) ([]Index, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	indexes := make([]Index, 0)
+	for rows.Next() {
+		var (
+			name, unique string
+			columns      sql.NullString
+		)
+		if err := rows.Scan(&name, &unique, &columns); err != nil {
+			return nil, err
+		}
+		indexes = append(indexes, Index{Name: name, Unique: isYes(unique), Columns: splitColumns(columns.String)})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return indexes, nil
+}
+
+// splitColumns splits the comma separated list of the columns or expressions an index is built on.
+// The commas inside parentheses or quotes belong to an expression, e.g. "a, coalesce(b, 'x,y')".
+func splitColumns(list string) []string {
+	if strings.TrimSpace(list) == "" {
+		return nil
+	}
+
+	var (
+		columns []string
+		depth   int
+		quote   rune
+		start   int
+	)
+	for i, r := range list {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"' || r == '`':
+			quote = r
+		case r == '(':
+			depth++
+		case r == ')':
+			depth--
+		case r == ',' && depth == 0:
+			columns = append(columns, strings.TrimSpace(list[start:i]))
+			start = i + 1
+		}
+	}
+
+	return append(columns, strings.TrimSpace(list[start:]))
+}
+
+// columnNodeName builds the name of a column shown on the TUI, e.g. "id integer [PK, NOT NULL]".
+func columnNodeName(name, dataType string, nullable, pk, fk bool) string {
+	var markers []string
+	if pk {
+		markers = append(markers, "PK")
+	}
+	if fk {
+		markers = append(markers, "FK")
+	}
+	if !nullable {
+		markers = append(markers, "NOT NULL")
+	}
+
+	label := strings.TrimSpace(name + " " + strings.ToLower(dataType))
+	if len(markers) > 0 {
+		label += " [" + strings.Join(markers, ", ") + "]"
+	}
+
+	return label
+}
+
+// isYes reports whether a flag returned by a catalog query is set.
+func isYes(flag string) bool {
+	switch strings.ToUpper(strings.TrimSpace(flag)) {
+	case "YES", "Y", "1", "TRUE":
+		return true
+	default:
+		return false
+	}
+}
+
+// definitionText joins the rows returned by a definition query into a single text.
+// Some databases, like Oracle, store the source code of an object one line per row.
+func definitionText(rows [][]string) string {
+	lines := make([]string, 0, len(rows))
+	for _, row := range rows {
+		if len(row) == 0 {
+			continue
+		}
+		lines = append(lines, strings.TrimRight(row[0], "\r\n"))
+	}
+
+	return strings.Join(lines, "\n")
+}
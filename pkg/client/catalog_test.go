@@ -0,0 +1,188 @@
+package client
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+
+	"github.com/danvergara/dblab/pkg/drivers"
+)
+
+func TestSQLiteCatalogObjects(t *testing.T) {
+	sandboxDir := t.TempDir()
+	dbName := filepath.Join(sandboxDir, "catalog.db")
+
+	db, err := sqlx.Open("sqlite", dbName)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec(`
+		CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT, updated_at TEXT);
+		CREATE VIEW user_names AS SELECT name FROM users;
+		CREATE TRIGGER touch_users AFTER UPDATE ON users
+		BEGIN
+			UPDATE users SET updated_at = datetime('now') WHERE id = NEW.id;
+		END;`)
+	require.NoError(t, err)
+
+	s := newSQLite(dbName, db)
+	c := &Client{db: db, dbName: dbName, driver: drivers.SQLite, databaseQuerier: s}
+
+	root, err := c.Catalog(context.Background())
+	require.NoError(t, err)
+	require.True(t, root.Loaded)
+
+	types := make(map[string]string)
+	for _, child := range root.Children {
+		types[child.EntityName] = child.Type
+	}
+
+	require.Equal(t, map[string]string{
+		"users":       "table",
+		"user_names":  "view",
+		"touch_users": "trigger",
+	}, types)
+
+	query, args, err := s.GetObjectDefinition(ObjectRef{Name: "touch_users", Type: "trigger"})
+	require.NoError(t, err)
+
+	var definition string
+	require.NoError(t, db.Get(&definition, query, args...))
+	require.Contains(t, definition, "CREATE TRIGGER touch_users AFTER UPDATE ON users")
+
+	_, _, err = s.GetObjectDefinition(ObjectRef{Name: "nextval", Type: "sequence"})
+	require.Error(t, err)
+}
+
+func TestDefinitionText(t *testing.T) {
+	var tests = []struct {
+		name  string
+		input [][]string
+		want  string
+	}{
+		{
+			name:  "Single row",
+			input: [][]string{{"CREATE SEQUENCE s;"}},
+			want:  "CREATE SEQUENCE s;",
+		},
+		{
+			name: "One line per row",
+			input: [][]string{
+				{"FUNCTION add_one(n NUMBER) RETURN NUMBER IS\n"},
+				{"BEGIN\n"},
+				{"  RETURN n + 1;\n"},
+				{"END;"},
+			},
+			want: "FUNCTION add_one(n NUMBER) RETURN NUMBER IS\nBEGIN\n  RETURN n + 1;\nEND;",
+		},
+		{
+			name:  "No rows",
+			input: [][]string{},
+			want:  "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.want, definitionText(test.input))
+		})
+	}
+}
+
+func TestSplitColumns(t *testing.T) {
+	var tests = []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{
+			name:  "Columns",
+			input: "last_name,first_name",
+			want:  []string{"last_name", "first_name"},
+		},
+		{
+			name:  "Expressions",
+			input: "a, (lower(b)), coalesce(c, 'x,y')",
+			want:  []string{"a", "(lower(b))", "coalesce(c, 'x,y')"},
+		},
+		{
+			name:  "Unknown expression",
+			input: "a,",
+			want:  []string{"a", ""},
+		},
+		{
+			name:  "Empty",
+			input: "",
+			want:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.want, splitColumns(test.input))
+		})
+	}
+}
+
+func TestSQLiteTableChildren(t *testing.T) {
+	sandboxDir := t.TempDir()
+	dbName := filepath.Join(sandboxDir, "children.db")
+
+	db, err := sqlx.Open("sqlite", dbName)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec(`
+		CREATE TABLE users (id INTEGER PRIMARY KEY, email TEXT NOT NULL);
+		CREATE TABLE orders (id INTEGER PRIMARY KEY, user_id INTEGER REFERENCES users(id), total REAL);
+		CREATE UNIQUE INDEX orders_user_id_idx ON orders (user_id);`)
+	require.NoError(t, err)
+
+	c := &Client{db: db, databaseQuerier: newSQLite(dbName, db)}
+
+	children, err := c.TableChildren(context.Background(), TableRef{Name: "orders"}, "db:test.t:orders")
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(children))
+	for _, child := range children {
+		names = append(names, child.Name)
+	}
+
+	require.Equal(t, []string{
+		"id integer [PK]",
+		"user_id integer [FK]",
+		"total real",
+		"orders_user_id_idx (unique) - i",
+	}, names)
+
+	require.Equal(t, "db:test.t:orders.c:user_id", children[1].ID)
+	require.Equal(t, "column", children[1].Type)
+	require.Equal(t, "user_id", children[1].EntityName)
+	require.Equal(t, "orders", children[1].ParentName)
+	require.Equal(t, "index", children[3].Type)
+}
+
+func TestColumnNodeName(t *testing.T) {
+	var tests = []struct {
+		name     string
+		column   string
+		dataType string
+		nullable bool
+		pk, fk   bool
+		want     string
+	}{
+		{name: "Primary key", column: "id", dataType: "INTEGER", pk: true, want: "id integer [PK, NOT NULL]"},
+		{name: "Nullable foreign key", column: "user_id", dataType: "bigint", nullable: true, fk: true, want: "user_id bigint [FK]"},
+		{name: "No markers", column: "notes", dataType: "text", nullable: true, want: "notes text"},
+		{name: "No data type", column: "value", nullable: true, want: "value"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.want, columnNodeName(test.column, test.dataType, test.nullable, test.pk, test.fk))
+		})
+	}
+}
@@ -0,0 +1,439 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+
+	clickhousedialect "github.com/danvergara/dblab/pkg/drivers/clickhouse"
+)
+
+// clickhouse struct is in charge of perform all the clickhouse related queries,
+// without the client knowing.
+// The databases of the server are shown as the schemas of the catalog, the database connected to is the root.
+type clickhouse struct {
+	db     *sqlx.DB
+	dbName string
+	schema string
+}
+
+// a validation to see if clickhouse is implementing databaseQuerier.
+var _ databaseQuerier = (*clickhouse)(nil)
+
+// returns a pointer to a clickhouse, it receives an schema as a parameter,
+// which is the only database of the server listed in the catalog, if given.
+func newClickHouse(dbName, schema string, db *sqlx.DB) *clickhouse {
+	c := clickhouse{
+		dbName: dbName,
+		db:     db,
+		schema: schema,
+	}
+
+	return &c
+}
+
+// clickhouseViewEngines are the engines of the tables of system.tables that are views.
+var clickhouseViewEngines = []string{"View", "MaterializedView", "LiveView", "WindowView"}
+
+// clickhouseSystemDatabases are the databases of the server itself, left out of the catalog.
+var clickhouseSystemDatabases = []string{"system", "INFORMATION_SCHEMA", "information_schema"}
+
+// TableStructure returns a query string to get all the relevant information of a given table,
+// under a database, along with whether each column is part of the partition, sorting, primary and sampling keys.
+func (c *clickhouse) TableStructure(table TableRef) (string, []any, error) {
+	// inKey tells whether the column is part of one of the keys of the table.
+	inKey := func(key string) string {
+		return fmt.Sprintf("if(is_in_%[1]s_key, 'YES', 'NO') AS %[1]s_key", key)
+	}
+
+	return sq.Select(
+		"name AS column_name",
+		"type AS data_type",
+		"default_kind",
+		"default_expression",
+		inKey("partition"),
+		inKey("sorting"),
+		inKey("primary"),
+		inKey("sampling"),
+		"compression_codec",
+		"comment",
+	).
+		From("system.columns").
+		Where(sq.Eq{
+			"database": table.Schema,
+			"table":    table.Name,
+		}).
+		OrderBy("position").
+		PlaceholderFormat(sq.Question).
+		ToSql()
+}
+
+// Constraints returns the CHECK and ASSUME constraints of a given table, under a database.
+// ClickHouse keeps them in the CREATE statement of the table only, so they are read from it.
+func (c *clickhouse) Constraints(table TableRef) (string, []any, error) {
+	query := `
+		SELECT
+			replaceAll(found[1], char(96), '') AS constraint_name,
+			t.name AS table_name,
+			found[2] AS constraint_type
+		FROM system.tables AS t
+		ARRAY JOIN extractAllGroupsVertical(t.create_table_query, 'CONSTRAINT\\s+(\\S+)\\s+(CHECK|ASSUME)\\s') AS found
+		WHERE t.database = ? AND t.name = ?`
+
+	return query, []any{table.Schema, table.Name}, nil
+}
+
+// Indexes returns the engine of a table and the expressions of its partition, sorting, primary and sampling keys,
+// followed by its data skipping indexes, the only secondary indexes of ClickHouse.
+// The columns of system.tables are qualified, since ClickHouse resolves the aliases of the SELECT list in the WHERE clause.
+func (c *clickhouse) Indexes(table TableRef) (string, []any, error) {
+	query := `
+		SELECT name, type, expression, granularity
+		FROM (
+			SELECT
+				table_key.2 AS name,
+				table_key.3 AS type,
+				table_key.4 AS expression,
+				'' AS granularity,
+				table_key.1 AS position
+			FROM system.tables AS t
+			ARRAY JOIN [
+				(1, 'engine', 'table engine', t.engine),
+				(2, 'partition key', 'table key', t.partition_key),
+				(3, 'sorting key', 'table key', t.sorting_key),
+				(4, 'primary key', 'table key', t.primary_key),
+				(5, 'sampling key', 'table key', t.sampling_key)
+			] AS table_key
+			WHERE t.database = ? AND t.name = ? AND table_key.4 != ''
+			UNION ALL
+			SELECT
+				name,
+				type,
+				expr,
+				toString(granularity),
+				6
+			FROM system.data_skipping_indices
+			WHERE database = ? AND table = ?
+		)
+		ORDER BY position, name`
+
+	return query, []any{table.Schema, table.Name, table.Schema, table.Name}, nil
+}
+
+// Children returns the nodes right under the given node of the ClickHouse database graph.
+// The database connected to holds the databases of the server, or only the one the client is scoped to,
+// shown as schemas, and they hold the correspondent lists of tables and views.
+// ClickHouse topography:
+//
+//					 [Database]
+//				       |
+//				       v
+//			     [Databases]
+//			      /     \
+//			     v       v
+//	 		 [Tables] 	[Views]
+func (c *clickhouse) Children(ctx context.Context, node *DBNode) ([]*DBNode, error) {
+	switch node.Type {
+	case "database":
+		if c.schema != "" {
+			return []*DBNode{{
+				ID:         fmt.Sprintf("%s.s:%s", node.ID, c.schema),
+				Name:       c.schema,
+				EntityName: c.schema,
+				Type:       "schema",
+				ParentID:   node.ID,
+			}}, nil
+		}
+
+		return c.fetchDatabases(ctx, node.ID)
+	case "schema":
+		psql := sq.StatementBuilder.PlaceholderFormat(sq.Question)
+
+		queries := []struct {
+			kind  nodeKind
+			query sq.SelectBuilder
+		}{
+			{
+				kind: nodeKind{Type: "table", idPrefix: "t", suffix: "t"},
+				query: psql.Select("name").
+					From("system.tables").
+					Where(sq.Eq{"database": node.Name}).
+					Where(sq.NotEq{"engine": clickhouseViewEngines}).
+					Where("NOT is_temporary").
+					OrderBy("name"),
+			},
+			{
+				kind: nodeKind{Type: "view", idPrefix: "v", suffix: "v"},
+				query: psql.Select("name").
+					From("system.tables").
+					Where(sq.Eq{
+						"database": node.Name,
+						"engine":   clickhouseViewEngines,
+					}).
+					OrderBy("name"),
+			},
+		}
+
+		var children []*DBNode
+		for _, q := range queries {
+			query, args, err := q.query.ToSql()
+			if err != nil {
+				return nil, err
+			}
+
+			nodes, err := queryNodes(ctx, c.db, q.kind, node.Name, node.ID, query, args...)
+			if err != nil {
+				return nil, err
+			}
+			children = slices.Concat(children, nodes)
+		}
+
+		return children, nil
+	}
+
+	return nil, nil
+}
+
+// GetViewDefinition method returns the CREATE statement of a given view, or materialized view.
+func (c *clickhouse) GetViewDefinition(view ViewRef) (string, []any, error) {
+	return sq.Select("create_table_query AS view_definition").
+		From("system.tables").
+		Where(sq.Eq{
+			"database": view.Schema,
+			"name":     view.Name,
+		}).
+		PlaceholderFormat(sq.Question).
+		ToSql()
+}
+
+// GetObjectDefinition method is not supported, the catalog of ClickHouse lists tables and views only.
+func (c *clickhouse) GetObjectDefinition(obj ObjectRef) (string, []any, error) {
+	return "", nil, fmt.Errorf("%s objects not supported", obj.Type)
+}
+
+// CatalogColumns returns a query to list the columns of a table, flagging nullable and primary key columns.
+// ClickHouse has no foreign keys.
+func (c *clickhouse) CatalogColumns(table TableRef) (string, []any, error) {
+	return sq.Select(
+		"name",
+		"type",
+		"if(startsWith(type, 'Nullable('), 'YES', 'NO')",
+		"if(is_in_primary_key, 'YES', 'NO')",
+		"'NO'",
+	).
+		From("system.columns").
+		Where(sq.Eq{
+			"database": table.Schema,
+			"table":    table.Name,
+		}).
+		OrderBy("position").
+		PlaceholderFormat(sq.Question).
+		ToSql()
+}
+
+// CatalogIndexes returns a query to list the data skipping indexes of a table, none of them is unique,
+// along with the expression they're built on.
+func (c *clickhouse) CatalogIndexes(table TableRef) (string, []any, error) {
+	return sq.Select(
+		"name",
+		"'NO'",
+		"expr",
+	).
+		From("system.data_skipping_indices").
+		Where(sq.Eq{
+			"database": table.Schema,
+			"table":    table.Name,
+		}).
+		OrderBy("name").
+		PlaceholderFormat(sq.Question).
+		ToSql()
+}
+
+// CatalogForeignKeys returns no query, ClickHouse has no foreign keys.
+func (c *clickhouse) CatalogForeignKeys(string) (string, []any, error) {
+	return "", nil, nil
+}
+
+// CatalogRowCounts returns a query to list the row count of the tables of a database.
+// The count is exact for the MergeTree family, and missing for the engines that don't keep it, like the views.
+func (c *clickhouse) CatalogRowCounts(schema string) (string, []any, error) {
+	return sq.Select(
+		"name",
+		"total_rows",
+	).
+		From("system.tables").
+		Where(sq.Eq{"database": schema}).
+		Where(sq.NotEq{"total_rows": nil}).
+		OrderBy("name").
+		PlaceholderFormat(sq.Question).
+		ToSql()
+}
+
+// TableStats returns the statistics of a table: its row count, its size on disk and uncompressed,
+// the number of its active parts and partitions, its engine and the time its metadata was last changed.
+func (c *clickhouse) TableStats(ctx context.Context, table TableRef) ([]Stat, error) {
+	return queryStats(ctx, c.db, `
+		SELECT
+			t.total_rows AS rows,
+			t.total_bytes AS total_size_bytes,
+			p.uncompressed_bytes AS uncompressed_size_bytes,
+			p.parts AS active_parts,
+			p.partitions AS partitions,
+			t.engine AS engine,
+			t.metadata_modification_time AS last_metadata_change
+		FROM system.tables AS t
+		LEFT JOIN (
+			SELECT
+				database,
+				table,
+				sum(data_uncompressed_bytes) AS uncompressed_bytes,
+				count() AS parts,
+				uniqExact(partition_id) AS partitions
+			FROM system.parts
+			WHERE active
+			GROUP BY database, table
+		) AS p ON p.database = t.database AND p.table = t.name
+		WHERE t.database = ? AND t.name = ?`, table.Schema, table.Name)
+}
+
+// Activity returns a query to list the queries running on the server from system.processes,
+// identified by their query ID, but the subqueries of the distributed ones.
+func (c *clickhouse) Activity() (string, []any, error) {
+	query := `
+		SELECT
+			query_id,
+			user,
+			current_database,
+			if(is_cancelled, 'cancelled', 'running'),
+			elapsed,
+			'',
+			query
+		FROM system.processes
+		WHERE is_initial_query AND query_id != queryID()
+		ORDER BY elapsed DESC`
+
+	return query, nil, nil
+}
+
+// CancelSession returns a statement to kill a query running on the server, given its query ID.
+func (c *clickhouse) CancelSession(id string) (string, []any, error) {
+	return "KILL QUERY WHERE query_id = ? ASYNC", []any{id}, nil
+}
+
+// TerminateSession is not supported, ClickHouse can kill the queries running on the server, but not their connections.
+func (c *clickhouse) TerminateSession(id string) (string, []any, error) {
+	return "", nil, fmt.Errorf("clickhouse can't terminate a connection, cancel its query instead")
+}
+
+// Locks is not supported, ClickHouse takes no row nor table locks the sessions can be blocked by.
+func (c *clickhouse) Locks() (string, []any, error) {
+	return "", nil, fmt.Errorf("clickhouse has no locks held by the sessions")
+}
+
+// Roles returns a query to list the users and roles of the server from system.users and system.roles,
+// along with the roles granted to them, from system.role_grants. It takes the SHOW USERS and SHOW ROLES privileges.
+func (c *clickhouse) Roles() (string, []any, error) {
+	query := `
+		SELECT
+			e.name,
+			e.kind,
+			arrayStringConcat(arraySort(arrayFilter(r -> r != '', groupArray(g.granted_role_name))), ',')
+		FROM (
+			SELECT name, 'user' AS kind FROM system.users
+			UNION ALL
+			SELECT name, 'role' AS kind FROM system.roles
+		) AS e
+		LEFT JOIN (
+			SELECT coalesce(user_name, role_name) AS grantee, granted_role_name
+			FROM system.role_grants
+		) AS g ON g.grantee = e.name
+		GROUP BY e.name, e.kind
+		ORDER BY e.name`
+
+	return query, nil, nil
+}
+
+// TableGrants returns a query to list the privileges that apply to a table, from system.grants:
+// the ones on the table itself or its columns, on its database and the global ones, but the partial revokes.
+func (c *clickhouse) TableGrants(table TableRef) (string, []any, error) {
+	query := `
+		SELECT
+			coalesce(user_name, role_name),
+			access_type,
+			if(grant_option, 'YES', 'NO'),
+			multiIf(
+				column IS NOT NULL, concat('column ', column),
+				table IS NOT NULL, 'table',
+				database IS NOT NULL, 'database',
+				'global'
+			)
+		FROM system.grants
+		WHERE NOT is_partial_revoke
+			AND (database IS NULL OR database = ?)
+			AND (table IS NULL OR table = ?)
+		ORDER BY 1, 2`
+
+	return query, []any{table.Schema, table.Name}, nil
+}
+
+// fetchDatabases method lists the databases of the server, but the system ones.
+func (c *clickhouse) fetchDatabases(ctx context.Context, parentID string) ([]*DBNode, error) {
+	query, args, err := sq.Select("name").
+		From("system.databases").
+		Where(sq.NotEq{"name": clickhouseSystemDatabases}).
+		OrderBy("name").
+		PlaceholderFormat(sq.Question).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := c.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var databases []*DBNode
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		databases = append(databases, &DBNode{
+			ID:         fmt.Sprintf("%s.s:%s", parentID, name),
+			Name:       name,
+			EntityName: name,
+			Type:       "schema",
+			ParentID:   parentID,
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return databases, nil
+}
+
+// TableDDL returns the CREATE TABLE statement of a table, as given by SHOW CREATE TABLE,
+// which includes its engine, keys, data skipping indexes and comments.
+func (c *clickhouse) TableDDL(ctx context.Context, table TableRef) (string, error) {
+	rows, err := queryStrings(ctx, c.db, fmt.Sprintf(
+		"SHOW CREATE TABLE %s.%s",
+		clickhousedialect.QuoteIdentifier(table.Schema),
+		clickhousedialect.QuoteIdentifier(table.Name),
+	))
+	if err != nil {
+		return "", err
+	}
+
+	if len(rows) == 0 {
+		return "", fmt.Errorf("definition of table %s not found", table.Name)
+	}
+
+	return joinStatements([]string{rows[0][0]}), nil
+}
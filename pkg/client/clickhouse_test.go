@@ -0,0 +1,221 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+
+	"github.com/danvergara/dblab/pkg/command"
+	"github.com/danvergara/dblab/pkg/drivers"
+)
+
+// ClickHouseTestSuite runs the ClickHouse querier against a server container,
+// over the native protocol and the HTTP interface.
+// It's run with DB_DRIVER=clickhouse, e.g. make test DB_DRIVER=clickhouse.
+type ClickHouseTestSuite struct {
+	suite.Suite
+	container  testcontainers.Container
+	ctx        context.Context
+	user       string
+	password   string
+	dbName     string
+	host       string
+	nativePort nat.Port
+	httpPort   nat.Port
+}
+
+func (suite *ClickHouseTestSuite) SetupSuite() {
+	if testing.Short() {
+		suite.T().Skip("skipping integration tests in short mode.")
+	}
+
+	if os.Getenv("DB_DRIVER") != drivers.ClickHouse {
+		suite.T().Skip("skipping clickhouse integration tests, DB_DRIVER is not clickhouse.")
+	}
+
+	suite.ctx = context.Background()
+	suite.user = os.Getenv("DB_USER")
+	suite.password = os.Getenv("DB_PASSWORD")
+	suite.dbName = os.Getenv("DB_NAME")
+
+	container, err := testcontainers.GenericContainer(suite.ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        "clickhouse/clickhouse-server:24.8-alpine",
+			ExposedPorts: []string{"9000/tcp", "8123/tcp"},
+			Env: map[string]string{
+				"CLICKHOUSE_DB":                        suite.dbName,
+				"CLICKHOUSE_USER":                      suite.user,
+				"CLICKHOUSE_PASSWORD":                  suite.password,
+				"CLICKHOUSE_DEFAULT_ACCESS_MANAGEMENT": "1",
+			},
+			WaitingFor: wait.ForHTTP("/ping").WithPort("8123/tcp").WithStartupTimeout(60 * time.Second),
+		},
+		Started: true,
+	})
+	require.NoError(suite.T(), err)
+	suite.container = container
+
+	suite.host, err = container.Host(suite.ctx)
+	require.NoError(suite.T(), err)
+	suite.nativePort, err = container.MappedPort(suite.ctx, "9000")
+	require.NoError(suite.T(), err)
+	suite.httpPort, err = container.MappedPort(suite.ctx, "8123")
+	require.NoError(suite.T(), err)
+
+	c := suite.newClient(false)
+	defer c.DB().Close()
+
+	for _, statement := range []string{
+		`CREATE TABLE events (
+			ts DateTime,
+			user_id UInt64,
+			kind LowCardinality(String),
+			value Nullable(Float64),
+			INDEX value_idx value TYPE minmax GRANULARITY 4,
+			CONSTRAINT positive_user CHECK user_id > 0
+		)
+		ENGINE = MergeTree
+		PARTITION BY toYYYYMM(ts)
+		ORDER BY (user_id, ts)`,
+		`INSERT INTO events VALUES
+			('2024-01-01 00:00:00', 1, 'click', 1.5),
+			('2024-01-02 00:00:00', 2, 'view', NULL),
+			('2024-02-01 00:00:00', 3, 'click', 3)`,
+		`CREATE VIEW clicks AS SELECT * FROM events WHERE kind = 'click'`,
+	} {
+		_, err := c.DB().Exec(statement)
+		require.NoError(suite.T(), err, statement)
+	}
+}
+
+func (suite *ClickHouseTestSuite) TearDownSuite() {
+	if suite.container != nil {
+		if err := suite.container.Terminate(suite.ctx); err != nil {
+			suite.T().Fatalf("failed to terminate container: %s", err)
+		}
+	}
+}
+
+// newClient method connects to the server over the native protocol, by the connection flags.
+func (suite *ClickHouseTestSuite) newClient(readOnly bool) *Client {
+	c, err := New(command.Options{
+		Driver:   drivers.ClickHouse,
+		Host:     suite.host,
+		Port:     suite.nativePort.Port(),
+		User:     suite.user,
+		Pass:     suite.password,
+		DBName:   suite.dbName,
+		Limit:    2,
+		ReadOnly: readOnly,
+	})
+	require.NoError(suite.T(), err)
+
+	return c
+}
+
+func (suite *ClickHouseTestSuite) TestCatalog() {
+	c := suite.newClient(false)
+	defer c.DB().Close()
+
+	root, err := c.Catalog(suite.ctx)
+	suite.NoError(err)
+
+	var database *DBNode
+	for _, child := range root.Children {
+		suite.NotEqual("system", child.Name)
+		if child.Type == "schema" && child.Name == suite.dbName {
+			database = child
+		}
+	}
+	suite.Require().NotNil(database)
+
+	types := make(map[string]string)
+	for _, child := range database.Children {
+		types[child.EntityName] = child.Type
+	}
+	suite.Equal(map[string]string{"events": "table", "clicks": "view"}, types)
+
+	columns, err := c.Children(suite.ctx, &DBNode{ID: "t", Type: "table", EntityName: "events", ParentName: suite.dbName})
+	suite.NoError(err)
+	suite.Equal("ts", columns[0].EntityName)
+}
+
+func (suite *ClickHouseTestSuite) TestMetadata() {
+	c := suite.newClient(false)
+	defer c.DB().Close()
+
+	m, err := c.Metadata(TableRef{Schema: suite.dbName, Name: "events"})
+	suite.NoError(err)
+
+	// the rows are paginated by LIMIT and OFFSET.
+	suite.Len(m.TableContent.Rows, 2)
+	suite.Equal(2, m.TotalPages)
+
+	suite.Len(m.Structure.Rows, 4)
+	suite.Contains(m.Structure.Columns, "partition_key")
+	suite.Contains(m.Structure.Columns, "sorting_key")
+
+	keys := make(map[string]string)
+	for _, row := range m.Indexes.Rows {
+		keys[row[0]] = row[2]
+	}
+	suite.Equal("MergeTree", keys["engine"])
+	suite.Equal("toYYYYMM(ts)", keys["partition key"])
+	suite.Equal("user_id, ts", keys["sorting key"])
+	suite.Equal("value", keys["value_idx"])
+
+	suite.Equal([][]string{{"positive_user", "events", "CHECK"}}, m.Constraints.Rows)
+
+	ddl, err := c.TableDDL(context.Background(), TableRef{Schema: suite.dbName, Name: "events"})
+	suite.NoError(err)
+	suite.Contains(ddl, "ENGINE = MergeTree")
+
+	foreignKeys, err := c.TableForeignKeys(context.Background(), TableRef{Schema: suite.dbName, Name: "events"})
+	suite.NoError(err)
+	suite.Empty(foreignKeys)
+
+	view, err := c.ViewMetadata(ViewRef{Schema: suite.dbName, Name: "clicks"})
+	suite.NoError(err)
+	suite.Len(view.TableContent.Rows, 2)
+	suite.Contains(view.ViewDef.Rows[0][0], "CREATE VIEW")
+}
+
+func (suite *ClickHouseTestSuite) TestHTTP() {
+	c, err := New(command.Options{
+		URL: fmt.Sprintf(
+			"clickhouse://%s:%s@%s:%s/%s?protocol=http",
+			suite.user, suite.password, suite.host, suite.httpPort.Port(), suite.dbName,
+		),
+		Limit: 10,
+	})
+	suite.Require().NoError(err)
+	defer c.DB().Close()
+
+	rows, _, err := c.Query("SELECT count() FROM events WHERE kind = ?", "click")
+	suite.NoError(err)
+	suite.Equal([][]string{{"2"}}, rows)
+}
+
+func (suite *ClickHouseTestSuite) TestReadOnly() {
+	c := suite.newClient(true)
+	defer c.DB().Close()
+
+	_, err := c.DB().Exec(`INSERT INTO events VALUES ('2024-03-01 00:00:00', 4, 'view', NULL)`)
+	suite.Error(err)
+
+	rows, _, err := c.Query("SELECT count() FROM events")
+	suite.NoError(err)
+	suite.Equal([][]string{{"3"}}, rows)
+}
+
+func TestClickHouseTestSuite(t *testing.T) {
+	suite.Run(t, new(ClickHouseTestSuite))
+}
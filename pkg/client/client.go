@@ -3,21 +3,19 @@ package client
 import (
 	"context"
 	"fmt"
+	"net"
+	"net/url"
 	"regexp"
 	"strings"
 	"sync"
 	"time"
 
-	_ "github.com/go-sql-driver/mysql"
 	"github.com/jmoiron/sqlx"
-	_ "github.com/lib/pq"
-	_ "github.com/microsoft/go-mssqldb"
-	_ "github.com/sijms/go-ora/v2"
-	_ "modernc.org/sqlite"
 
 	"github.com/danvergara/dblab/pkg/command"
 	"github.com/danvergara/dblab/pkg/connection"
 	"github.com/danvergara/dblab/pkg/drivers"
+	pgdialect "github.com/danvergara/dblab/pkg/drivers/postgres"
 	"github.com/danvergara/dblab/pkg/pagination"
 )
 
@@ -52,6 +50,26 @@ type DBNode struct {
 	ParentID   string
 	ParentName string
 	Children   []*DBNode
+	// Loaded tells whether the children of the node were fetched already,
+	// since the catalog is loaded on demand.
+	Loaded bool
+}
+
+// Clone returns a deep copy of the node and the nodes under it.
+func (n *DBNode) Clone() *DBNode {
+	if n == nil {
+		return nil
+	}
+
+	clone := *n
+	if n.Children != nil {
+		clone.Children = make([]*DBNode, 0, len(n.Children))
+		for _, child := range n.Children {
+			clone.Children = append(clone.Children, child.Clone())
+		}
+	}
+
+	return &clone
 }
 
 // databaseQuerier is an interface that indicates the methods
@@ -63,8 +81,64 @@ type databaseQuerier interface {
 	TableStructure(table TableRef) (string, []any, error)
 	Constraints(table TableRef) (string, []any, error)
 	Indexes(table TableRef) (string, []any, error)
-	Catalog(context.Context) (*DBNode, error)
+	Children(ctx context.Context, node *DBNode) ([]*DBNode, error)
 	GetViewDefinition(view ViewRef) (string, []any, error)
+	GetObjectDefinition(obj ObjectRef) (string, []any, error)
+	CatalogColumns(table TableRef) (string, []any, error)
+	CatalogIndexes(table TableRef) (string, []any, error)
+	CatalogForeignKeys(schema string) (string, []any, error)
+	CatalogRowCounts(schema string) (string, []any, error)
+	TableStats(ctx context.Context, table TableRef) ([]Stat, error)
+	TableDDL(ctx context.Context, table TableRef) (string, error)
+	Activity() (string, []any, error)
+	CancelSession(id string) (string, []any, error)
+	TerminateSession(id string) (string, []any, error)
+	Locks() (string, []any, error)
+	Roles() (string, []any, error)
+	TableGrants(table TableRef) (string, []any, error)
+}
+
+// queriers are the implementations of databaseQuerier, by the name of the dialect of the database they query.
+// The rest of what's specific to a database is owned by its dialect, see drivers.Dialect.
+var queriers = map[string]func(dbName, schema string, db *sqlx.DB) databaseQuerier{
+	drivers.Postgres: func(dbName, schema string, db *sqlx.DB) databaseQuerier {
+		return newPostgres(dbName, schema, db)
+	},
+	drivers.MySQL: func(dbName, _ string, db *sqlx.DB) databaseQuerier {
+		return newMySQL(dbName, db)
+	},
+	drivers.MariaDB: func(dbName, _ string, db *sqlx.DB) databaseQuerier {
+		return newMariaDB(dbName, db)
+	},
+	drivers.SQLite: func(dbName, _ string, db *sqlx.DB) databaseQuerier {
+		return newSQLite(dbName, db)
+	},
+	drivers.Oracle: func(dbName, schema string, db *sqlx.DB) databaseQuerier {
+		return newOracle(dbName, schema, db)
+	},
+	drivers.SQLServer: func(dbName, schema string, db *sqlx.DB) databaseQuerier {
+		return newMSSQL(dbName, schema, db)
+	},
+	drivers.DuckDB: func(dbName, schema string, db *sqlx.DB) databaseQuerier {
+		return newDuckDB(dbName, schema, db)
+	},
+	drivers.ClickHouse: func(dbName, schema string, db *sqlx.DB) databaseQuerier {
+		return newClickHouse(dbName, schema, db)
+	},
+}
+
+// flavorQueriers are the implementations of databaseQuerier for the servers speaking the wire protocol of a dialect,
+// by the name of their flavor, see drivers.Flavored. They take the place of the querier of the dialect.
+var flavorQueriers = map[string]func(dbName, schema string, db *sqlx.DB) databaseQuerier{
+	pgdialect.CockroachDB: func(dbName, schema string, db *sqlx.DB) databaseQuerier {
+		return newCockroachDB(dbName, schema, db)
+	},
+	pgdialect.YugabyteDB: func(dbName, schema string, db *sqlx.DB) databaseQuerier {
+		return newYugabyteDB(dbName, schema, db)
+	},
+	pgdialect.Redshift: func(dbName, schema string, db *sqlx.DB) databaseQuerier {
+		return newRedshift(dbName, schema, db)
+	},
 }
 
 // Client is used to store the pool of db connection.
@@ -73,10 +147,14 @@ type Client struct {
 	dbName            string
 	databaseQuerier   databaseQuerier
 	driver, schema    string
+	flavor            string
 	host              string
 	paginationManager *pagination.Manager
 	limit             uint
 	readOnly          bool
+	profile           string
+	// address is the host:port of the database, the one the ssh tunnel forwards to, if any.
+	address string
 }
 
 // New return an instance of the client.
@@ -95,63 +173,55 @@ func New(opts command.Options) (*Client, error) {
 		db:       db,
 		dbName:   opts.DBName,
 		host:     opts.Host,
+		address:  address(opts),
 		driver:   opts.Driver,
 		limit:    opts.Limit,
 		readOnly: opts.ReadOnly,
+		profile:  opts.Profile,
 	}
 
 	if opts.Schema != "" {
 		c.schema = opts.Schema
 	}
 
+	dialect, ok := drivers.Lookup(c.driver)
+	if !ok {
+		return nil, fmt.Errorf("%s driver not supported", c.driver)
+	}
+
 	// This is where an implementation of databaseQuerier is getting picked up.
-	switch c.driver {
-	case drivers.Postgres, drivers.PostgreSQL, drivers.PostgresSSH:
-		c.databaseQuerier = newPostgres(c.dbName, c.schema, c.db)
-	case drivers.MySQL:
-		c.databaseQuerier = newMySQL(c.dbName, c.db)
-	case drivers.SQLite:
-		c.databaseQuerier = newSQLite(c.dbName, c.db)
-	case drivers.Oracle:
-		c.databaseQuerier = newOracle(c.dbName, c.schema, c.db)
-	case drivers.SQLServer:
-		c.databaseQuerier = newMSSQL(c.dbName, c.schema, c.db)
-	default:
+	newQuerier, ok := queriers[dialect.Name()]
+	if !ok {
 		return nil, fmt.Errorf("%s driver not supported", c.driver)
 	}
 
-	switch c.driver {
-	case drivers.PostgreSQL, drivers.Postgres, drivers.PostgresSSH:
-		if _, err = db.Exec(fmt.Sprintf("set search_path = '%s'", c.schema)); err != nil {
+	statements := dialect.SessionSetup(c.schema, c.readOnly)
+
+	// the server is told apart before the session is set up, since the setup may differ, e.g. on Redshift.
+	if flavored, ok := dialect.(drivers.Flavored); ok {
+		var value string
+		if err := db.QueryRow(flavored.FlavorQuery()).Scan(&value); err != nil {
 			return nil, err
 		}
-	case drivers.Oracle:
-		if c.schema != "" {
-			if _, err = db.Exec(fmt.Sprintf("ALTER SESSION SET CURRENT_SCHEMA = %s", c.schema)); err != nil {
+
+		c.flavor = flavored.Flavor(value)
+		if c.flavor != "" {
+			statements, err = flavored.FlavorSessionSetup(c.flavor, c.schema, c.readOnly)
+			if err != nil {
 				return nil, err
 			}
+
+			if newFlavorQuerier, ok := flavorQueriers[c.flavor]; ok {
+				newQuerier = newFlavorQuerier
+			}
 		}
 	}
 
-	if c.readOnly {
-		switch c.driver {
-		case drivers.PostgreSQL, drivers.Postgres, drivers.PostgresSSH:
-			if _, err = db.Exec("SET SESSION default_transaction_read_only = 'on';"); err != nil {
-				return nil, err
-			}
-		case drivers.MySQL:
-			if _, err = db.Exec("SET SESSION transaction_read_only = 1;"); err != nil {
-				return nil, err
-			}
-		case drivers.Oracle:
-			if _, err = db.Exec("ALTER SESSION SET READ_ONLY = TRUE;"); err != nil {
-				return nil, err
-			}
-		case drivers.SQLite:
-			_, err := db.Exec("PRAGMA query_only = true")
-			if err != nil {
-				return nil, err
-			}
+	c.databaseQuerier = newQuerier(c.dbName, c.schema, c.db)
+
+	for _, statement := range statements {
+		if _, err = db.Exec(statement); err != nil {
+			return nil, err
 		}
 	}
 
@@ -170,15 +240,65 @@ func (c *Client) DB() *sqlx.DB {
 	return c.db
 }
 
+// dialect method returns the dialect of the database the client is connected to.
+func (c *Client) dialect() drivers.Dialect {
+	d, _ := drivers.Lookup(c.driver)
+	return d
+}
+
 // Driver returns the driver of the database.
 func (c *Client) Driver() string {
 	return c.driver
 }
 
+// Flavor returns the server speaking the wire protocol of the driver, e.g. cockroachdb on the postgres driver,
+// or an empty string if it's the database of the driver itself.
+func (c *Client) Flavor() string {
+	return c.flavor
+}
+
 func (c *Client) Host() string {
 	return c.host
 }
 
+// Profile returns the name of the connection profile in use, if any.
+func (c *Client) Profile() string {
+	return c.profile
+}
+
+// Identity returns the name of the connection profile in use,
+// or dialect://host:port/database when the connection was made without a profile,
+// so it doesn't change with the database/sql driver, e.g. pgx or lib/pq, or with an ssh tunnel,
+// whose local port stands for the address of the database.
+// It's used to store data that belongs to a connection, like the sidebar bookmarks.
+func (c *Client) Identity() string {
+	if c.profile != "" {
+		return c.profile
+	}
+
+	return fmt.Sprintf("%s://%s/%s", c.dialect().Name(), c.address, c.dbName)
+}
+
+// address function returns the host:port of the database the options connect to,
+// the one the ssh tunnel forwards a local port to, if any, or the host of the URL.
+func address(opts command.Options) string {
+	if opts.ForwardedAddr != "" {
+		return opts.ForwardedAddr
+	}
+
+	if opts.URL != "" {
+		if u, err := url.Parse(opts.URL); err == nil && u.Host != "" {
+			return u.Host
+		}
+	}
+
+	if opts.Port == "" {
+		return opts.Host
+	}
+
+	return net.JoinHostPort(opts.Host, opts.Port)
+}
+
 // AsyncQuery runs multiple queries concurrently and it returns the results through a channel.
 // It relies on a fuffered channel (Semaphore): To cap the maximum number of concurrent database connections.
 func (c *Client) AsyncQuery(ctx context.Context, queries []string, maxConcurrency int, args ...any) <-chan QueryResult {
@@ -224,71 +344,17 @@ func (c *Client) AsyncQuery(ctx context.Context, queries []string, maxConcurrenc
 
 				defer rows.Close()
 
-				columnNames, err := rows.Columns()
+				resultSet, columnNames, err := c.scanRows(rows)
 				if err != nil {
 					result.Error = err
 					resultChan <- result
 					return
 				}
 
-				colTypes, err := rows.ColumnTypes()
-				if err != nil {
-					result.Error = err
-					resultChan <- result
-					return
-				}
-
-				resultSet := make([][]string, 0)
-
-				rowsCount := 0
-				for rows.Next() {
-					rowsCount++
-					// cols is an []any of all of the column results.
-					cols, err := rows.SliceScan()
-					if err != nil {
-						result.Error = err
-						resultChan <- result
-						return
-					}
-
-					// Convert []any into []string.
-					s := make([]string, len(cols))
-					for i, v := range cols {
-						switch val := v.(type) {
-						case []byte:
-							// Isolate []byte and check the database type
-							dbType := colTypes[i].DatabaseTypeName()
-
-							// Check for both MySQL BLOBs and Postgres BYTEA
-							switch dbType {
-							case "BLOB", "TINYBLOB", "MEDIUMBLOB", "LONGBLOB", "BYTEA":
-								// Safely represent the BLOB without printing raw binary
-								s[i] = fmt.Sprintf("[BLOB - %d bytes]", len(val))
-							default:
-								// It's a normal string/text type returned as []byte, safe to convert
-								s[i] = string(val)
-							}
-						case string, rune:
-							s[i] = fmt.Sprintf("%s", val)
-						case nil:
-							s[i] = fmt.Sprint(val)
-						default:
-							s[i] = fmt.Sprintf("%v", val)
-						}
-					}
-
-					resultSet = append(resultSet, s)
-				}
-				if err := rows.Err(); err != nil {
-					result.Error = err
-					resultChan <- result
-					return
-				}
-
 				// Send the result back over the thread-safe channel.
 				result.ResultSet = resultSet
 				result.Headers = columnNames
-				result.RowCount = rowsCount
+				result.RowCount = len(resultSet)
 				resultChan <- result
 			} else {
 				start := time.Now()
@@ -327,8 +393,6 @@ func (c *Client) AsyncQuery(ctx context.Context, queries []string, maxConcurrenc
 
 // Query returns performs the query and returns the result set and the column names.
 func (c *Client) Query(q string, args ...any) ([][]string, []string, error) {
-	resultSet := [][]string{}
-
 	// Runs the query extracting the content of the view calling the Buffer method.
 	rows, err := c.db.Queryx(q, args...)
 	if err != nil {
@@ -336,6 +400,14 @@ func (c *Client) Query(q string, args ...any) ([][]string, []string, error) {
 	}
 	defer rows.Close()
 
+	return c.scanRows(rows)
+}
+
+// scanRows method reads the rows of a result set as text, formatting each value by the database type of its column,
+// and returns them along with the column names.
+func (c *Client) scanRows(rows *sqlx.Rows) ([][]string, []string, error) {
+	resultSet := [][]string{}
+
 	// Gets the names of the columns of the result set.
 	columnNames, err := rows.Columns()
 	if err != nil {
@@ -347,6 +419,8 @@ func (c *Client) Query(q string, args ...any) ([][]string, []string, error) {
 		return nil, nil, err
 	}
 
+	formatter, _ := c.dialect().(drivers.ValueFormatter)
+
 	for rows.Next() {
 		// cols is an []any of all of the column results.
 		cols, err := rows.SliceScan()
@@ -357,27 +431,14 @@ func (c *Client) Query(q string, args ...any) ([][]string, []string, error) {
 		// Convert []any into []string.
 		s := make([]string, len(cols))
 		for i, v := range cols {
-			switch val := v.(type) {
-			case []byte:
-				// Isolate []byte and check the database type
-				dbType := colTypes[i].DatabaseTypeName()
-
-				// Check for both MySQL BLOBs and Postgres BYTEA
-				switch dbType {
-				case "BLOB", "TINYBLOB", "MEDIUMBLOB", "LONGBLOB", "BYTEA":
-					// Safely represent the BLOB without printing raw binary
-					s[i] = fmt.Sprintf("[BLOB - %d bytes]", len(val))
-				default:
-					// It's a normal string/text type returned as []byte, safe to convert
-					s[i] = string(val)
+			if formatter != nil {
+				if text, ok := formatter.FormatValue(v, colTypes[i].DatabaseTypeName()); ok {
+					s[i] = text
+					continue
 				}
-			case string, rune:
-				s[i] = fmt.Sprintf("%s", val)
-			case nil:
-				s[i] = fmt.Sprint(val)
-			default:
-				s[i] = fmt.Sprintf("%v", val)
 			}
+
+			s[i] = formatValue(v, colTypes[i].DatabaseTypeName())
 		}
 
 		resultSet = append(resultSet, s)
@@ -407,12 +468,15 @@ type Metadata struct {
 	Constraints  Table
 	Indexes      Table
 	ViewDef      Table
-	TotalPages   int
+	// Definition is the source code of routines, sequences, triggers and types.
+	Definition string
+	TotalPages int
 }
 
 // Metadata returns the most relevant data from a given table.
-func (c *Client) Metadata(table TableRef) (*Metadata, error) {
-	tcRows, tcColumns, err := c.tableContent(table)
+// If conditions are given, only the rows of the table matching all of them are returned.
+func (c *Client) Metadata(table TableRef, conditions ...Condition) (*Metadata, error) {
+	tcRows, tcColumns, err := c.tableContent(table, conditions...)
 	if err != nil {
 		return nil, err
 	}
@@ -480,84 +544,46 @@ func (c *Client) ViewMetadata(view ViewRef) (*Metadata, error) {
 	return &vm, nil
 }
 
-// tableContent returns a portion of the data of a given table scoped by the offset and limit.
-func (c *Client) tableContent(table TableRef) ([][]string, []string, error) {
-	var query string
-
-	switch c.driver {
-	case drivers.Postgres, drivers.PostgreSQL, drivers.PostgresSSH:
-		query = fmt.Sprintf(
-			"SELECT * FROM %s.%s LIMIT %d OFFSET %d;",
-			table.Schema,
-			table.Name,
-			c.paginationManager.Limit(),
-			c.paginationManager.Offset(),
-		)
-	case drivers.Oracle:
-		query = fmt.Sprintf(
-			"SELECT * FROM %s.%s OFFSET %d ROWS FETCH NEXT %d ROWS ONLY",
-			strings.ToUpper(table.Schema),
-			strings.ToUpper(table.Name),
-			c.paginationManager.Offset(),
-			c.paginationManager.Limit(),
-		)
-	case drivers.SQLServer:
-		query = fmt.Sprintf(
-			"SELECT * FROM %s.%s ORDER BY (SELECT NULL) OFFSET %d ROWS FETCH NEXT %d ROWS ONLY",
-			table.Schema,
-			table.Name,
-			c.paginationManager.Offset(),
-			c.paginationManager.Limit(),
-		)
-	default:
-		query = fmt.Sprintf(
-			"SELECT * FROM %s LIMIT %d OFFSET %d;",
-			table.Name,
-			c.paginationManager.Limit(),
-			c.paginationManager.Offset(),
-		)
+// ObjectMetadata returns the source definition of a database object
+// other than a table or a view, such as a function or a trigger.
+func (c *Client) ObjectMetadata(obj ObjectRef) (*Metadata, error) {
+	query, args, err := c.databaseQuerier.GetObjectDefinition(obj)
+	if err != nil {
+		return nil, err
 	}
 
-	return c.Query(query)
+	rows, _, err := c.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("definition of %s %s not found", obj.Type, obj.Name)
+	}
+
+	return &Metadata{Definition: definitionText(rows)}, nil
+}
+
+// tableContent returns a portion of the data of a given table scoped by the offset and limit,
+// and by the given conditions, if any.
+func (c *Client) tableContent(table TableRef, conditions ...Condition) ([][]string, []string, error) {
+	where, args := c.whereClause(conditions)
+	query := c.dialect().SelectPage(c.fromTable(table), where, c.paginationManager.Limit(), c.paginationManager.Offset())
+
+	rows, columns, err := c.Query(query, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	c.formatJSONColumns(table, rows, columns)
+
+	return rows, columns, nil
 }
 
 // viewContent returns a portion of the data of a given view scoped by the offset and limit.
 func (c *Client) viewContent(view ViewRef) ([][]string, []string, error) {
-	var query string
-	switch c.driver {
-	case drivers.Postgres, drivers.PostgreSQL, drivers.PostgresSSH:
-		query = fmt.Sprintf(
-			"SELECT * FROM %s.%s LIMIT %d OFFSET %d;",
-			view.Schema,
-			view.Name,
-			c.paginationManager.Limit(),
-			c.paginationManager.Offset(),
-		)
-	case drivers.Oracle:
-		query = fmt.Sprintf(
-			"SELECT * FROM %s.%s OFFSET %d ROWS FETCH NEXT %d ROWS ONLY",
-			strings.ToUpper(view.Schema),
-			strings.ToUpper(view.Name),
-			c.paginationManager.Offset(),
-			c.paginationManager.Limit(),
-		)
-	case drivers.SQLServer:
-		query = fmt.Sprintf(
-			"SELECT * FROM %s.%s ORDER BY (SELECT NULL) OFFSET %d ROWS FETCH NEXT %d ROWS ONLY",
-			view.Schema,
-			view.Name,
-			c.paginationManager.Offset(),
-			c.paginationManager.Limit(),
-		)
-	default:
-		query = fmt.Sprintf(
-			"SELECT * FROM %s LIMIT %d OFFSET %d;",
-			view.Name,
-			c.paginationManager.Limit(),
-			c.paginationManager.Offset(),
-		)
-	}
-	return c.Query(query)
+	from := c.fromTable(TableRef{Schema: view.Schema, Name: view.Name})
+	return c.Query(c.dialect().SelectPage(from, "", c.paginationManager.Limit(), c.paginationManager.Offset()))
 }
 
 // tableStructure returns the structure of the table columns.
@@ -596,8 +622,97 @@ func (c *Client) indexes(table TableRef) ([][]string, []string, error) {
 	return c.Query(query, args...)
 }
 
+// TableDDL returns the CREATE TABLE statement of a table, along with its indexes and comments.
+// It comes from the database itself where it's available, otherwise it's reconstructed from the catalog.
+func (c *Client) TableDDL(ctx context.Context, table TableRef) (string, error) {
+	return c.databaseQuerier.TableDDL(ctx, table)
+}
+
+// Root returns the root node of the database graph, the database itself, without its children.
+func (c *Client) Root() *DBNode {
+	return &DBNode{
+		ID:   fmt.Sprintf("db:%s", c.dbName),
+		Name: c.dbName,
+		Type: "database",
+	}
+}
+
+// Children returns the nodes right under the given node of the database graph,
+// so the catalog can be loaded on demand, one level at a time.
+// The children of tables are their columns and indexes, the ones of the security node the roles of the server.
+func (c *Client) Children(ctx context.Context, node *DBNode) ([]*DBNode, error) {
+	switch node.Type {
+	case "table":
+		return c.TableChildren(ctx, TableRef{Schema: node.ParentName, Name: node.EntityName}, node.ID)
+	case "security":
+		return c.RoleNodes(ctx, node.ID)
+	}
+
+	children, err := c.databaseQuerier.Children(ctx, node)
+	if err != nil {
+		return nil, err
+	}
+
+	// the embedded databases have no users nor roles.
+	if node.Type == "database" && c.driver != drivers.SQLite && c.driver != drivers.DuckDB {
+		children = append(children, securityNode(node))
+	}
+
+	return children, nil
+}
+
+// Catalog returns the whole database graph, from the database down to tables, views and the rest of objects.
+// It walks the graph breadth-first, one query per level of every node.
+// The columns and indexes of the tables, and the roles of the server, are left out, they are fetched on demand by Children.
 func (c *Client) Catalog(ctx context.Context) (*DBNode, error) {
-	return c.databaseQuerier.Catalog(ctx)
+	root := c.Root()
+	queue := []*DBNode{root}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if current.Type != "database" && current.Type != "schema" {
+			continue
+		}
+
+		children, err := c.Children(ctx, current)
+		if err != nil {
+			return nil, err
+		}
+
+		current.Children = children
+		current.Loaded = true
+		queue = append(queue, children...)
+	}
+
+	return root, nil
+}
+
+// TableChildren returns the columns and the indexes of a table as catalog nodes,
+// so they can be loaded on demand under the table node, given its ID.
+func (c *Client) TableChildren(ctx context.Context, table TableRef, parentID string) ([]*DBNode, error) {
+	query, args, err := c.databaseQuerier.CatalogColumns(table)
+	if err != nil {
+		return nil, err
+	}
+
+	columns, err := queryColumnNodes(ctx, c.db, table.Name, parentID, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	query, args, err = c.databaseQuerier.CatalogIndexes(table)
+	if err != nil {
+		return nil, err
+	}
+
+	indexes, err := queryIndexNodes(ctx, c.db, table.Name, parentID, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(columns, indexes...), nil
 }
 
 func (c *Client) viewDefintion(view ViewRef) ([][]string, []string, error) {
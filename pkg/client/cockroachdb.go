@@ -0,0 +1,131 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// cockroachDB struct is in charge of the queries of CockroachDB, which speaks the wire protocol of Postgres.
+// It takes the postgres queries the catalog of CockroachDB answers alike, and reads the rest from crdb_internal
+// and the SHOW statements, e.g. the view definitions, the sessions and the zone configurations.
+type cockroachDB struct {
+	*postgres
+}
+
+// a validation to see if cockroachDB is implementing databaseQuerier.
+var _ databaseQuerier = (*cockroachDB)(nil)
+
+// returns a pointer to a cockroachDB, it receives an schema as a parameter.
+func newCockroachDB(dbName, schema string, db *sqlx.DB) *cockroachDB {
+	p := newPostgres(dbName, schema, db)
+	p.systemSchemas = append(p.systemSchemas, "crdb_internal", "pg_extension")
+
+	return &cockroachDB{postgres: p}
+}
+
+// GetViewDefinition method returns the CREATE VIEW statement of a given view, from crdb_internal.create_statements,
+// since pg_get_viewdef doesn't give back the query of the view.
+func (c *cockroachDB) GetViewDefinition(view ViewRef) (string, []any, error) {
+	query := `
+		SELECT create_statement AS view_definition
+		FROM crdb_internal.create_statements
+		WHERE database_name = current_database() AND schema_name = $1 AND descriptor_name = $2`
+
+	return query, []any{view.Schema, view.Name}, nil
+}
+
+// CatalogRowCounts returns a query to list the estimated row count of the tables of a schema,
+// as kept by the table statistics, since pg_class.reltuples is not.
+func (c *cockroachDB) CatalogRowCounts(schema string) (string, []any, error) {
+	query := `
+		SELECT s.table_name, s.estimated_row_count
+		FROM crdb_internal.table_row_statistics s
+		JOIN crdb_internal.tables t ON t.table_id = s.table_id
+		WHERE t.database_name = current_database() AND t.schema_name = $1
+		ORDER BY s.table_name`
+
+	return query, []any{schema}, nil
+}
+
+// TableStats returns the statistics of a table: its estimated row count, the ranges its data is split into
+// and the zone configuration the ranges are replicated by.
+func (c *cockroachDB) TableStats(ctx context.Context, table TableRef) ([]Stat, error) {
+	relation := pgRelation(table.Schema, table.Name)
+
+	return queryStats(ctx, c.db, fmt.Sprintf(`
+		SELECT
+			(SELECT estimated_row_count FROM crdb_internal.table_row_statistics
+			WHERE table_id = $1::text::regclass::oid::int) AS estimated_rows,
+			(SELECT count(*) FROM [SHOW RANGES FROM TABLE %[1]s]) AS ranges,
+			(SELECT regexp_replace(raw_config_sql, '\s+', ' ', 'g')
+			FROM [SHOW ZONE CONFIGURATION FROM TABLE %[1]s]) AS zone_configuration`, relation), relation)
+}
+
+// Activity returns a query to list the queries running on the cluster from crdb_internal.cluster_queries,
+// identified by their query ID, since the sessions of CockroachDB have no pid.
+func (c *cockroachDB) Activity() (string, []any, error) {
+	query := `
+		SELECT
+			query_id,
+			user_name,
+			'',
+			phase,
+			EXTRACT(EPOCH FROM now() - start),
+			'',
+			query
+		FROM crdb_internal.cluster_queries
+		WHERE session_id <> (SELECT session_id FROM [SHOW session_id])
+		ORDER BY 5 DESC`
+
+	return query, nil, nil
+}
+
+// CancelSession returns a statement to cancel a query running on the cluster, given its query ID.
+func (c *cockroachDB) CancelSession(id string) (string, []any, error) {
+	return "CANCEL QUERY $1", []any{id}, nil
+}
+
+// TerminateSession returns a statement to terminate the session running a query, given the query ID.
+func (c *cockroachDB) TerminateSession(id string) (string, []any, error) {
+	return "CANCEL SESSIONS SELECT session_id FROM crdb_internal.cluster_queries WHERE query_id = $1", []any{id}, nil
+}
+
+// Locks is not supported, CockroachDB locks the keys of a range rather than the tables held by the sessions.
+func (c *cockroachDB) Locks() (string, []any, error) {
+	return "", nil, fmt.Errorf("cockroachdb locks keys, not the tables held by the sessions")
+}
+
+// TableGrants returns a query to list the privileges granted on a table, as given by SHOW GRANTS,
+// since CockroachDB has no aclexplode.
+func (c *cockroachDB) TableGrants(table TableRef) (string, []any, error) {
+	query := fmt.Sprintf(`
+		SELECT
+			grantee,
+			privilege_type,
+			CASE WHEN is_grantable THEN 'YES' ELSE 'NO' END,
+			'table'
+		FROM [SHOW GRANTS ON TABLE %s]
+		ORDER BY 1, 2`, pgRelation(table.Schema, table.Name))
+
+	return query, nil, nil
+}
+
+// TableDDL returns the CREATE TABLE statement of a table, as given by SHOW CREATE TABLE,
+// which includes its indexes, families and comments.
+func (c *cockroachDB) TableDDL(ctx context.Context, table TableRef) (string, error) {
+	rows, err := queryStrings(ctx, c.db, fmt.Sprintf(
+		"SELECT create_statement FROM [SHOW CREATE TABLE %s]",
+		pgRelation(table.Schema, table.Name),
+	))
+	if err != nil {
+		return "", err
+	}
+
+	if len(rows) == 0 {
+		return "", fmt.Errorf("definition of table %s not found", table.Name)
+	}
+
+	return joinStatements([]string{rows[0][0]}), nil
+}
@@ -0,0 +1,194 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+)
+
+// DDLTarget describes the part of the catalog changed by a DDL statement,
+// so only that part is fetched again.
+type DDLTarget struct {
+	// Schema is the schema of the object created, altered or dropped.
+	// It's empty if the statement does not qualify the name of the object.
+	Schema string
+	// Table is set when the columns or indexes of an existing table change, e.g. ALTER TABLE or CREATE INDEX.
+	Table string
+	// Catalog is set when the statement changes the schemas themselves, or it could not be parsed,
+	// so the whole top level of the catalog has to be fetched again.
+	Catalog bool
+}
+
+// ParseDDL function tells which part of the catalog a DDL statement changes.
+// It returns false if the query is not a DDL statement.
+func ParseDDL(query string) (DDLTarget, bool) {
+	tokens := ddlTokens(commentRegex.ReplaceAllString(query+"\n", " "))
+	if len(tokens) == 0 {
+		return DDLTarget{}, false
+	}
+
+	verb := strings.ToLower(tokens[0])
+	switch verb {
+	case "create", "drop", "alter", "truncate", "rename":
+	default:
+		return DDLTarget{}, false
+	}
+
+	// TRUNCATE and RENAME may omit the TABLE keyword.
+	if (verb == "truncate" || verb == "rename") && len(tokens) > 1 && !strings.EqualFold(tokens[1], "table") {
+		return DDLTarget{Schema: qualifier(ddlName(tokens, 1))}, true
+	}
+
+	// looks for the kind of object, skipping modifiers like OR REPLACE, TEMPORARY, UNIQUE, MATERIALIZED,
+	// or the DEFINER and ALGORITHM clauses of MySQL.
+	i := objectKindIndex(tokens)
+	if i < 0 {
+		return DDLTarget{Catalog: true}, true
+	}
+
+	kind := strings.ToLower(tokens[i])
+	name := ddlName(tokens, i+1)
+
+	switch kind {
+	case "table":
+		target := DDLTarget{Schema: qualifier(name)}
+		if verb == "alter" {
+			target.Table = name[len(name)-1]
+		}
+		return target, true
+	case "index", "trigger":
+		// indexes and triggers belong to the table named after ON.
+		for j := i + 1; j < len(tokens); j++ {
+			if strings.EqualFold(tokens[j], "on") {
+				table := ddlName(tokens, j+1)
+				return DDLTarget{Schema: qualifier(table), Table: table[len(table)-1]}, true
+			}
+		}
+	}
+
+	return DDLTarget{Schema: qualifier(name)}, true
+}
+
+// objectKindIndex function returns the index of the token naming the kind of object a DDL statement is about.
+// It returns -1 if the statement is about schemas, databases or users, or the kind is not found before the name of the object.
+func objectKindIndex(tokens []string) int {
+	for i := 1; i < len(tokens); i++ {
+		switch strings.ToLower(tokens[i]) {
+		case "table", "view", "index", "sequence", "function", "procedure", "trigger", "type":
+			return i
+		case "schema", "database", "user", "(", "on", "as":
+			return -1
+		}
+	}
+
+	return -1
+}
+
+// ddlName function reads the possibly qualified name of an object starting at the given token,
+// skipping IF [NOT] EXISTS, ONLY and CONCURRENTLY. It returns the parts of the name, e.g. [public users].
+func ddlName(tokens []string, start int) []string {
+	i := start
+	for i < len(tokens) {
+		switch strings.ToLower(tokens[i]) {
+		case "if", "not", "exists", "only", "concurrently":
+			i++
+			continue
+		}
+		break
+	}
+
+	parts := []string{""}
+	for ; i < len(tokens); i += 2 {
+		parts[len(parts)-1] = unquoteIdentifier(tokens[i])
+		if i+1 >= len(tokens) || tokens[i+1] != "." {
+			break
+		}
+		parts = append(parts, "")
+	}
+
+	return parts
+}
+
+// qualifier function returns the schema of a qualified name, or an empty string if the name is not qualified.
+func qualifier(name []string) string {
+	if len(name) < 2 {
+		return ""
+	}
+
+	return name[len(name)-2]
+}
+
+// ddlTokens function splits a statement into words, quoted identifiers and punctuation.
+func ddlTokens(query string) []string {
+	var tokens []string
+
+	runes := []rune(query)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			i++
+		case r == '"' || r == '`' || r == '[':
+			closing := r
+			if r == '[' {
+				closing = ']'
+			}
+
+			j := i + 1
+			for j < len(runes) && runes[j] != closing {
+				j++
+			}
+			tokens = append(tokens, string(runes[i:min(j+1, len(runes))]))
+			i = j + 1
+		case isIdentifierRune(r):
+			j := i
+			for j < len(runes) && isIdentifierRune(runes[j]) {
+				j++
+			}
+			tokens = append(tokens, string(runes[i:j]))
+			i = j
+		default:
+			tokens = append(tokens, string(r))
+			i++
+		}
+	}
+
+	return tokens
+}
+
+func isIdentifierRune(r rune) bool {
+	return r == '_' || r == '$' || r == '#' ||
+		('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9') || r > 127
+}
+
+// unquoteIdentifier function removes the quotes around an identifier, if any.
+func unquoteIdentifier(identifier string) string {
+	if len(identifier) >= 2 {
+		switch identifier[0] {
+		case '"', '`', '[':
+			return identifier[1 : len(identifier)-1]
+		}
+	}
+
+	return identifier
+}
+
+// CurrentSchema returns the schema the unqualified names of the statements resolve to.
+// It's empty for the databases whose catalog is not organized in schemas on the TUI.
+func (c *Client) CurrentSchema(ctx context.Context) (string, error) {
+	if c.schema != "" {
+		return c.schema, nil
+	}
+
+	query := c.dialect().CurrentSchemaQuery()
+	if query == "" {
+		return "", nil
+	}
+
+	var schema sql.NullString
+	if err := c.db.GetContext(ctx, &schema, query); err != nil {
+		return "", err
+	}
+
+	return schema.String, nil
+}
@@ -0,0 +1,42 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDDL(t *testing.T) {
+	var tests = []struct {
+		name   string
+		query  string
+		want   DDLTarget
+		wantOk bool
+	}{
+		{name: "Select", query: "SELECT * FROM users", wantOk: false},
+		{name: "Insert", query: "INSERT INTO users (id) VALUES (1)", wantOk: false},
+		{name: "Create unqualified table", query: "CREATE TABLE users (id int)", want: DDLTarget{}, wantOk: true},
+		{name: "Create qualified table", query: "create table if not exists sales.orders (id int)", want: DDLTarget{Schema: "sales"}, wantOk: true},
+		{name: "Quoted names", query: `CREATE TABLE "My Schema"."Orders" (id int)`, want: DDLTarget{Schema: "My Schema"}, wantOk: true},
+		{name: "Alter table", query: "ALTER TABLE ONLY public.users ADD COLUMN email text", want: DDLTarget{Schema: "public", Table: "users"}, wantOk: true},
+		{name: "Drop view", query: "DROP VIEW IF EXISTS reports.monthly", want: DDLTarget{Schema: "reports"}, wantOk: true},
+		{name: "Create or replace function", query: "CREATE OR REPLACE FUNCTION billing.add_one(n int) RETURNS int AS $$ SELECT n + 1 $$ LANGUAGE sql", want: DDLTarget{Schema: "billing"}, wantOk: true},
+		{name: "Materialized view", query: "CREATE MATERIALIZED VIEW sales.totals AS SELECT 1", want: DDLTarget{Schema: "sales"}, wantOk: true},
+		{name: "Create index", query: "CREATE UNIQUE INDEX CONCURRENTLY users_email_idx ON public.users (email)", want: DDLTarget{Schema: "public", Table: "users"}, wantOk: true},
+		{name: "Create trigger", query: "CREATE TRIGGER touch BEFORE UPDATE ON `shop`.`users` FOR EACH ROW SET NEW.updated_at = NOW()", want: DDLTarget{Schema: "shop", Table: "users"}, wantOk: true},
+		{name: "MySQL definer", query: "CREATE DEFINER=`root`@`localhost` PROCEDURE shop.reset() BEGIN END", want: DDLTarget{Schema: "shop"}, wantOk: true},
+		{name: "Truncate without table keyword", query: "TRUNCATE sales.orders", want: DDLTarget{Schema: "sales"}, wantOk: true},
+		{name: "Rename table", query: "RENAME TABLE shop.a TO shop.b", want: DDLTarget{Schema: "shop"}, wantOk: true},
+		{name: "Create schema", query: "CREATE SCHEMA reports", want: DDLTarget{Catalog: true}, wantOk: true},
+		{name: "Unknown object", query: "CREATE EXTENSION pgcrypto", want: DDLTarget{Catalog: true}, wantOk: true},
+		{name: "Leading comment", query: "-- add the orders table\nCREATE TABLE sales.orders (id int)", want: DDLTarget{Schema: "sales"}, wantOk: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, ok := ParseDDL(test.query)
+			require.Equal(t, test.wantOk, ok)
+			require.Equal(t, test.want, got)
+		})
+	}
+}
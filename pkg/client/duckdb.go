@@ -0,0 +1,486 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+
+	duckdbdialect "github.com/danvergara/dblab/pkg/drivers/duckdb"
+)
+
+// duckdb struct is in charge of perform all the duckdb related queries,
+// without the client knowing.
+// DuckDB can attach several databases to a connection, the catalog is limited to the current one.
+type duckdb struct {
+	db     *sqlx.DB
+	dbName string
+	schema string
+}
+
+// a validation to see if duckdb is implementing databaseQuerier.
+var _ databaseQuerier = (*duckdb)(nil)
+
+// returns a pointer to a duckdb, it receives an schema as a parameter.
+func newDuckDB(dbName, schema string, db *sqlx.DB) *duckdb {
+	d := duckdb{
+		dbName: dbName,
+		db:     db,
+		schema: schema,
+	}
+
+	return &d
+}
+
+// currentDatabase is the condition that limits the catalog functions of DuckDB to the current database.
+const currentDatabase = "database_name = current_database()"
+
+// TableStructure returns a query string to get all the relevant information of a given table,
+// under a schema.
+func (d *duckdb) TableStructure(table TableRef) (string, []any, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Question)
+
+	return psql.Select(
+		"c.column_name",
+		"c.is_nullable",
+		"c.data_type",
+		"c.column_default",
+		"c.character_maximum_length",
+		"c.numeric_precision",
+		"c.numeric_scale",
+		"c.column_index AS ordinal_position",
+		`CASE WHEN EXISTS (
+			SELECT 1 FROM duckdb_constraints() AS k
+			WHERE k.table_oid = c.table_oid AND k.constraint_type = 'PRIMARY KEY'
+				AND list_contains(k.constraint_column_names, c.column_name)
+		) THEN 'PRIMARY KEY' END AS pkey`,
+	).
+		From("duckdb_columns() AS c").
+		Where(currentDatabase).
+		Where(sq.Eq{
+			"c.schema_name": table.Schema,
+			"c.table_name":  table.Name,
+		}).
+		OrderBy("c.column_index").
+		ToSql()
+}
+
+// Constraints returns all the constraints of a given table, under a schema.
+// The NOT NULL constraints are listed as CHECK constraints, as in the information schema of Postgres.
+func (d *duckdb) Constraints(table TableRef) (string, []any, error) {
+	return sq.Select(
+		"tc.constraint_name",
+		"tc.table_name",
+		"tc.constraint_type",
+	).
+		From("information_schema.table_constraints AS tc").
+		Where("tc.table_catalog = current_database()").
+		Where(sq.Eq{
+			"tc.table_schema": table.Schema,
+			"tc.table_name":   table.Name,
+		}).
+		PlaceholderFormat(sq.Question).
+		ToSql()
+}
+
+// Indexes returns the indexes of a table, under a schema.
+// The indexes backing the primary keys and unique constraints are not listed by DuckDB.
+func (d *duckdb) Indexes(table TableRef) (string, []any, error) {
+	return sq.Select(
+		"index_name",
+		"is_unique",
+		"is_primary",
+		"sql",
+	).
+		From("duckdb_indexes()").
+		Where(currentDatabase).
+		Where(sq.Eq{
+			"schema_name": table.Schema,
+			"table_name":  table.Name,
+		}).
+		OrderBy("index_name").
+		PlaceholderFormat(sq.Question).
+		ToSql()
+}
+
+// Children returns the nodes right under the given node of the DuckDB database graph.
+// The database holds the schemas, or only the one the client is scoped to,
+// and the schemas hold the correspondent lists of tables, views, macros and sequences.
+// DuckDB topography:
+//
+//					 [Database]
+//				       |
+//				       v
+//			     [Schemas]
+//			      /     \
+//			     v       v
+//	 		 [Tables] 	[Views]	[Functions]	[Sequences]
+func (d *duckdb) Children(ctx context.Context, node *DBNode) ([]*DBNode, error) {
+	switch node.Type {
+	case "database":
+		if d.schema != "" {
+			return []*DBNode{{
+				ID:         fmt.Sprintf("%s.s:%s", node.ID, d.schema),
+				Name:       d.schema,
+				EntityName: d.schema,
+				Type:       "schema",
+				ParentID:   node.ID,
+			}}, nil
+		}
+
+		return d.fetchSchemas(ctx, node.ID)
+	case "schema":
+		psql := sq.StatementBuilder.PlaceholderFormat(sq.Question)
+
+		queries := []struct {
+			kind  nodeKind
+			query sq.SelectBuilder
+		}{
+			{
+				kind: nodeKind{Type: "table", idPrefix: "t", suffix: "t"},
+				query: psql.Select("table_name").
+					From("duckdb_tables()").
+					Where(currentDatabase).
+					Where(sq.Eq{"schema_name": node.Name}).
+					OrderBy("table_name"),
+			},
+			{
+				kind: nodeKind{Type: "view", idPrefix: "v", suffix: "v"},
+				query: psql.Select("view_name").
+					From("duckdb_views()").
+					Where(currentDatabase).
+					Where("NOT internal").
+					Where(sq.Eq{"schema_name": node.Name}).
+					OrderBy("view_name"),
+			},
+			{
+				// the macros are the functions written in SQL, both the scalar and the table ones.
+				kind: functionKind,
+				query: psql.Select("DISTINCT function_name").
+					From("duckdb_functions()").
+					Where(currentDatabase).
+					Where("NOT internal").
+					Where(sq.Eq{
+						"schema_name":   node.Name,
+						"function_type": []string{"macro", "table_macro"},
+					}).
+					OrderBy("function_name"),
+			},
+			{
+				kind: sequenceKind,
+				query: psql.Select("sequence_name").
+					From("duckdb_sequences()").
+					Where(currentDatabase).
+					Where(sq.Eq{"schema_name": node.Name}).
+					OrderBy("sequence_name"),
+			},
+		}
+
+		var children []*DBNode
+		for _, q := range queries {
+			query, args, err := q.query.ToSql()
+			if err != nil {
+				return nil, err
+			}
+
+			nodes, err := queryNodes(ctx, d.db, q.kind, node.Name, node.ID, query, args...)
+			if err != nil {
+				return nil, err
+			}
+			children = slices.Concat(children, nodes)
+		}
+
+		return children, nil
+	}
+
+	return nil, nil
+}
+
+// GetViewDefinition method returns the CREATE VIEW statement of a given view.
+func (d *duckdb) GetViewDefinition(view ViewRef) (string, []any, error) {
+	return sq.Select("sql AS view_definition").
+		From("duckdb_views()").
+		Where(currentDatabase).
+		Where(sq.Eq{
+			"schema_name": view.Schema,
+			"view_name":   view.Name,
+		}).
+		PlaceholderFormat(sq.Question).
+		ToSql()
+}
+
+// GetObjectDefinition method returns the source definition of a macro or a sequence.
+// DuckDB doesn't keep the statement that created a macro, so it's reconstructed from its parameters and definition.
+func (d *duckdb) GetObjectDefinition(obj ObjectRef) (string, []any, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Question)
+
+	var query sq.SelectBuilder
+	switch obj.Type {
+	case functionKind.Type:
+		query = psql.
+			Select(`'CREATE MACRO ' || schema_name || '.' || function_name ||
+				'(' || array_to_string(parameters, ', ') || ') AS ' ||
+				CASE WHEN function_type = 'table_macro' THEN 'TABLE ' ELSE '' END ||
+				macro_definition || ';' AS definition`).
+			From("duckdb_functions()").
+			Where(currentDatabase).
+			Where(sq.Eq{
+				"schema_name":   obj.Schema,
+				"function_name": obj.Name,
+				"function_type": []string{"macro", "table_macro"},
+			})
+	case sequenceKind.Type:
+		query = psql.
+			Select("sql AS definition").
+			From("duckdb_sequences()").
+			Where(currentDatabase).
+			Where(sq.Eq{
+				"schema_name":   obj.Schema,
+				"sequence_name": obj.Name,
+			})
+	default:
+		return "", nil, fmt.Errorf("%s objects not supported", obj.Type)
+	}
+
+	return query.ToSql()
+}
+
+// CatalogColumns returns a query to list the columns of a table, flagging nullable, primary and foreign key columns.
+func (d *duckdb) CatalogColumns(table TableRef) (string, []any, error) {
+	// hasConstraint tells whether the column is part of a constraint of the given type.
+	hasConstraint := func(constraintType string) string {
+		return fmt.Sprintf(`CASE WHEN EXISTS (
+			SELECT 1 FROM duckdb_constraints() AS k
+			WHERE k.table_oid = c.table_oid AND k.constraint_type = '%s'
+				AND list_contains(k.constraint_column_names, c.column_name)
+		) THEN 'YES' ELSE 'NO' END`, constraintType)
+	}
+
+	return sq.Select(
+		"c.column_name",
+		"c.data_type",
+		"CASE WHEN c.is_nullable THEN 'YES' ELSE 'NO' END",
+		hasConstraint("PRIMARY KEY"),
+		hasConstraint("FOREIGN KEY"),
+	).
+		From("duckdb_columns() AS c").
+		Where(currentDatabase).
+		Where(sq.Eq{
+			"c.schema_name": table.Schema,
+			"c.table_name":  table.Name,
+		}).
+		OrderBy("c.column_index").
+		PlaceholderFormat(sq.Question).
+		ToSql()
+}
+
+// CatalogIndexes returns a query to list the indexes of a table, whether they are unique and their columns,
+// read from the CREATE INDEX statement, since DuckDB leaves the expressions of duckdb_indexes() empty.
+func (d *duckdb) CatalogIndexes(table TableRef) (string, []any, error) {
+	return sq.Select(
+		"index_name",
+		"CASE WHEN is_unique THEN 'YES' ELSE 'NO' END",
+		`regexp_extract(sql, '\((.*)\)', 1)`,
+	).
+		From("duckdb_indexes()").
+		Where(currentDatabase).
+		Where(sq.Eq{
+			"schema_name": table.Schema,
+			"table_name":  table.Name,
+		}).
+		OrderBy("index_name").
+		PlaceholderFormat(sq.Question).
+		ToSql()
+}
+
+// CatalogForeignKeys returns a query to list the columns of the foreign keys of a schema,
+// along with the columns they reference.
+// DuckDB only keeps the referenced table and columns in the text of the constraint,
+// e.g. FOREIGN KEY (customer_id) REFERENCES sales.customers(id), so they are read from it.
+// The foreign keys are named as in the information schema, after the table and its columns.
+func (d *duckdb) CatalogForeignKeys(schema string) (string, []any, error) {
+	query := `
+		SELECT
+			name,
+			table_name,
+			column_name,
+			trim(CASE WHEN contains(target, '.') THEN split_part(target, '.', 1) ELSE schema_name END, '"'),
+			trim(CASE WHEN contains(target, '.') THEN split_part(target, '.', 2) ELSE target END, '"'),
+			trim(string_split(referenced_columns, ', ')[position], '"')
+		FROM (
+			SELECT
+				table_name || '_' || array_to_string(constraint_column_names, '_') || '_fkey' AS name,
+				schema_name,
+				table_name,
+				unnest(constraint_column_names) AS column_name,
+				generate_subscripts(constraint_column_names, 1) AS position,
+				regexp_extract(constraint_text, 'REFERENCES (.+)\((.*)\)$', 1) AS target,
+				regexp_extract(constraint_text, 'REFERENCES (.+)\((.*)\)$', 2) AS referenced_columns
+			FROM
+				duckdb_constraints()
+			WHERE
+				database_name = current_database() AND
+				schema_name = ? AND
+				constraint_type = 'FOREIGN KEY'
+		)
+		ORDER BY
+			table_name, name, position;`
+
+	return query, []any{schema}, nil
+}
+
+// CatalogRowCounts returns a query to list the estimated row count of the tables of a schema.
+func (d *duckdb) CatalogRowCounts(schema string) (string, []any, error) {
+	return sq.Select(
+		"table_name",
+		"estimated_size",
+	).
+		From("duckdb_tables()").
+		Where(currentDatabase).
+		Where(sq.Eq{"schema_name": schema}).
+		OrderBy("table_name").
+		PlaceholderFormat(sq.Question).
+		ToSql()
+}
+
+// TableStats returns the statistics of a table: its estimated row count and the number of its columns,
+// indexes and check constraints.
+func (d *duckdb) TableStats(ctx context.Context, table TableRef) ([]Stat, error) {
+	return queryStats(ctx, d.db, `
+		SELECT
+			estimated_size AS estimated_rows,
+			column_count AS columns,
+			index_count AS indexes,
+			check_constraint_count AS check_constraints
+		FROM duckdb_tables()
+		WHERE database_name = current_database() AND schema_name = ? AND table_name = ?`, table.Schema, table.Name)
+}
+
+// Activity is not supported, since DuckDB is embedded and has no server sessions.
+func (d *duckdb) Activity() (string, []any, error) {
+	return "", nil, fmt.Errorf("duckdb has no server sessions to monitor")
+}
+
+// CancelSession is not supported, since DuckDB has no server sessions.
+func (d *duckdb) CancelSession(id string) (string, []any, error) {
+	return "", nil, fmt.Errorf("duckdb has no server sessions to cancel")
+}
+
+// TerminateSession is not supported, since DuckDB has no server sessions.
+func (d *duckdb) TerminateSession(id string) (string, []any, error) {
+	return "", nil, fmt.Errorf("duckdb has no server sessions to terminate")
+}
+
+// Locks is not supported, since DuckDB locks the whole database file and has no server sessions.
+func (d *duckdb) Locks() (string, []any, error) {
+	return "", nil, fmt.Errorf("duckdb has no server sessions holding locks")
+}
+
+// Roles is not supported, since DuckDB has no users nor roles.
+func (d *duckdb) Roles() (string, []any, error) {
+	return "", nil, fmt.Errorf("duckdb has no users nor roles")
+}
+
+// TableGrants is not supported, since DuckDB has no privileges, the access is the one to the database file.
+func (d *duckdb) TableGrants(table TableRef) (string, []any, error) {
+	return "", nil, fmt.Errorf("duckdb has no privileges, the access to a table is the one to the database file")
+}
+
+// fetchSchemas method lists all the schemas of the current database.
+func (d *duckdb) fetchSchemas(ctx context.Context, parentID string) ([]*DBNode, error) {
+	query, args, err := sq.Select("schema_name").
+		From("information_schema.schemata").
+		Where("catalog_name = current_database()").
+		Where(sq.NotEq{
+			"schema_name": []string{"information_schema", "pg_catalog"},
+		}).
+		OrderBy("schema_name").
+		PlaceholderFormat(sq.Question).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var schemas []*DBNode
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		schemas = append(schemas, &DBNode{
+			ID:         fmt.Sprintf("%s.s:%s", parentID, name),
+			Name:       name,
+			EntityName: name,
+			Type:       "schema",
+			ParentID:   parentID,
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return schemas, nil
+}
+
+// TableDDL returns the CREATE TABLE statement of a table as kept by DuckDB,
+// followed by its indexes and the comments of the table and its columns.
+func (d *duckdb) TableDDL(ctx context.Context, table TableRef) (string, error) {
+	rows, err := queryStrings(ctx, d.db, `
+		SELECT sql FROM duckdb_tables()
+		WHERE database_name = current_database() AND schema_name = ? AND table_name = ?`, table.Schema, table.Name)
+	if err != nil {
+		return "", err
+	}
+
+	if len(rows) == 0 {
+		return "", fmt.Errorf("definition of table %s not found", table.Name)
+	}
+
+	statements := []string{rows[0][0]}
+
+	indexes, err := queryStrings(ctx, d.db, `
+		SELECT sql FROM duckdb_indexes()
+		WHERE database_name = current_database() AND schema_name = ? AND table_name = ? AND sql IS NOT NULL
+		ORDER BY index_name`, table.Schema, table.Name)
+	if err != nil {
+		return "", err
+	}
+
+	for _, row := range indexes {
+		statements = append(statements, row[0])
+	}
+
+	relation := duckdbdialect.QuoteIdentifier(table.Schema) + "." + duckdbdialect.QuoteIdentifier(table.Name)
+
+	comments, err := queryStrings(ctx, d.db, `
+		SELECT NULL, comment FROM duckdb_tables()
+		WHERE database_name = current_database() AND schema_name = ? AND table_name = ? AND comment IS NOT NULL
+		UNION ALL
+		SELECT column_name, comment FROM duckdb_columns()
+		WHERE database_name = current_database() AND schema_name = ? AND table_name = ? AND comment IS NOT NULL`,
+		table.Schema, table.Name, table.Schema, table.Name)
+	if err != nil {
+		return "", err
+	}
+
+	for _, row := range comments {
+		if row[0] == "" {
+			statements = append(statements, fmt.Sprintf("COMMENT ON TABLE %s IS %s", relation, quoteLiteral(row[1])))
+			continue
+		}
+		statements = append(statements, fmt.Sprintf(
+			"COMMENT ON COLUMN %s.%s IS %s", relation, duckdbdialect.QuoteIdentifier(row[0]), quoteLiteral(row[1]),
+		))
+	}
+
+	return joinStatements(statements), nil
+}
@@ -0,0 +1,170 @@
+//go:build cgo
+
+package client
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+
+	"github.com/danvergara/dblab/pkg/drivers"
+	"github.com/danvergara/dblab/pkg/pagination"
+)
+
+func newDuckDBClient(t *testing.T) *Client {
+	t.Helper()
+
+	dbName := filepath.Join(t.TempDir(), "analytics.duckdb")
+
+	db, err := sqlx.Open(drivers.DuckDB, dbName)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	for _, statement := range []string{
+		`CREATE SCHEMA sales`,
+		`CREATE TABLE sales.customers (id INTEGER PRIMARY KEY, email VARCHAR UNIQUE NOT NULL)`,
+		`CREATE TABLE sales."Order Lines" (
+			order_id INTEGER,
+			line INTEGER,
+			customer_id INTEGER REFERENCES sales.customers (id),
+			price DECIMAL(10, 2),
+			PRIMARY KEY (order_id, line)
+		)`,
+		`CREATE INDEX order_lines_price ON sales."Order Lines" (price)`,
+		`COMMENT ON TABLE sales.customers IS 'who buys'`,
+		`CREATE VIEW sales.big_lines AS SELECT * FROM sales."Order Lines" WHERE price > 100`,
+		`CREATE SEQUENCE sales.order_ids START 10`,
+		`CREATE MACRO sales.add(a, b) AS a + b`,
+		`INSERT INTO sales.customers VALUES (1, 'a@example.com'), (2, 'b@example.com')`,
+		`INSERT INTO sales."Order Lines" VALUES (1, 1, 1, 12.5), (1, 2, 2, 150)`,
+	} {
+		_, err := db.Exec(statement)
+		require.NoError(t, err, statement)
+	}
+
+	pm, err := pagination.New(100, 0, "")
+	require.NoError(t, err)
+
+	return &Client{
+		db:                db,
+		dbName:            dbName,
+		driver:            drivers.DuckDB,
+		databaseQuerier:   newDuckDB(dbName, "", db),
+		paginationManager: pm,
+	}
+}
+
+func TestDuckDBCatalog(t *testing.T) {
+	c := newDuckDBClient(t)
+
+	root, err := c.Catalog(context.Background())
+	require.NoError(t, err)
+
+	schemas := make(map[string]*DBNode)
+	for _, child := range root.Children {
+		require.Equal(t, "schema", child.Type, "duckdb has no security node")
+		schemas[child.Name] = child
+	}
+	require.Contains(t, schemas, "main")
+	require.Contains(t, schemas, "sales")
+
+	types := make(map[string]string)
+	for _, child := range schemas["sales"].Children {
+		types[child.EntityName] = child.Type
+	}
+
+	require.Equal(t, map[string]string{
+		"customers":   "table",
+		"Order Lines": "table",
+		"big_lines":   "view",
+		"add":         "function",
+		"order_ids":   "sequence",
+	}, types)
+
+	columns, err := c.Children(context.Background(), &DBNode{ID: "t", Type: "table", EntityName: "Order Lines", ParentName: "sales"})
+	require.NoError(t, err)
+	require.Equal(t, "order_id", columns[0].EntityName)
+
+	definition, err := c.ObjectMetadata(ObjectRef{Schema: "sales", Name: "add", Type: "function"})
+	require.NoError(t, err)
+	require.Equal(t, "CREATE MACRO sales.add(a, b) AS (a + b);", definition.Definition)
+
+	definition, err = c.ObjectMetadata(ObjectRef{Schema: "sales", Name: "order_ids", Type: "sequence"})
+	require.NoError(t, err)
+	require.Contains(t, definition.Definition, "START 10")
+}
+
+func TestDuckDBMetadata(t *testing.T) {
+	c := newDuckDBClient(t)
+	table := TableRef{Schema: "sales", Name: "Order Lines"}
+
+	m, err := c.Metadata(table)
+	require.NoError(t, err)
+
+	require.Equal(t, [][]string{{"1", "1", "1", "12.50"}, {"1", "2", "2", "150.00"}}, m.TableContent.Rows)
+	require.Len(t, m.Structure.Rows, 4)
+	require.Equal(t, "PRIMARY KEY", m.Structure.Rows[0][len(m.Structure.Columns)-1])
+	require.NotEmpty(t, m.Constraints.Rows)
+	require.Len(t, m.Indexes.Rows, 1)
+	require.Equal(t, "order_lines_price", m.Indexes.Rows[0][0])
+
+	foreignKeys, err := c.TableForeignKeys(context.Background(), table)
+	require.NoError(t, err)
+	require.Len(t, foreignKeys, 1)
+	require.Equal(t, ForeignKey{
+		Name:       "Order Lines_customer_id_fkey",
+		Table:      "Order Lines",
+		Columns:    []string{"customer_id"},
+		RefSchema:  "sales",
+		RefTable:   "customers",
+		RefColumns: []string{"id"},
+	}, foreignKeys[0])
+
+	rows, _, err := c.tableContent(table, Condition{Column: "customer_id", Value: "2"})
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+
+	ddl, err := c.TableDDL(context.Background(), TableRef{Schema: "sales", Name: "customers"})
+	require.NoError(t, err)
+	require.Contains(t, ddl, "CREATE TABLE sales.customers")
+	require.Contains(t, ddl, `COMMENT ON TABLE "sales"."customers" IS 'who buys'`)
+
+	view, err := c.ViewMetadata(ViewRef{Schema: "sales", Name: "big_lines"})
+	require.NoError(t, err)
+	require.Len(t, view.TableContent.Rows, 1)
+	require.Contains(t, view.ViewDef.Rows[0][0], "CREATE VIEW sales.big_lines")
+}
+
+func TestDuckDBFormatValue(t *testing.T) {
+	c := newDuckDBClient(t)
+
+	rows, _, err := c.Query(`SELECT
+		-0.05::DECIMAL(4, 2),
+		'8a1c0f4e-2b3d-4c5e-9f60-718293a4b5c6'::UUID,
+		INTERVAL '1 year 2 months 3 days 04:05:06.5'`)
+	require.NoError(t, err)
+	require.Equal(t, [][]string{{"-0.05", "8a1c0f4e-2b3d-4c5e-9f60-718293a4b5c6", "1 year 2 months 3 days 04:05:06.5"}}, rows)
+}
+
+func TestDuckDBAsyncQuery(t *testing.T) {
+	c := newDuckDBClient(t)
+
+	queries := []string{
+		`SELECT -0.05::DECIMAL(4, 2), INTERVAL '1 year 2 months 3 days 04:05:06.5'`,
+		`SELECT price FROM sales."Order Lines" ORDER BY line`,
+	}
+
+	results := make([]QueryResult, len(queries))
+	for result := range c.AsyncQuery(context.Background(), queries, 2) {
+		require.NoError(t, result.Error, result.Query)
+		results[result.QueryIndex] = result
+	}
+
+	require.Equal(t, [][]string{{"-0.05", "1 year 2 months 3 days 04:05:06.5"}}, results[0].ResultSet)
+	require.Equal(t, [][]string{{"12.50"}, {"150.00"}}, results[1].ResultSet)
+	require.Equal(t, []string{"price"}, results[1].Headers)
+	require.Equal(t, 2, results[1].RowCount)
+}
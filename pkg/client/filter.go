@@ -0,0 +1,40 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Condition is a column equal to a value, used to filter the rows of a table,
+// e.g. the rows referenced by a foreign key.
+type Condition struct {
+	Column string
+	Value  string
+}
+
+// String method returns the condition as it's written in SQL, e.g. customer_id = 5.
+func (c Condition) String() string {
+	return fmt.Sprintf("%s = %s", c.Column, c.Value)
+}
+
+// whereClause method returns the WHERE clause that matches all the given conditions, along with its arguments,
+// written with the placeholders of the database. It returns an empty clause if there are no conditions.
+func (c *Client) whereClause(conditions []Condition) (string, []any) {
+	if len(conditions) == 0 {
+		return "", nil
+	}
+
+	predicates := make([]string, 0, len(conditions))
+	args := make([]any, 0, len(conditions))
+	for _, condition := range conditions {
+		predicates = append(predicates, c.dialect().QuoteIdentifier(condition.Column)+" = ?")
+		args = append(args, condition.Value)
+	}
+
+	where := " WHERE " + strings.Join(predicates, " AND ")
+
+	// the conditions are built by dblab itself, so the placeholders can always be replaced.
+	where, _ = c.dialect().Placeholders().ReplacePlaceholders(where)
+
+	return where, args
+}
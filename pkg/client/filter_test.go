@@ -0,0 +1,87 @@
+package client
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+
+	"github.com/danvergara/dblab/pkg/drivers"
+	"github.com/danvergara/dblab/pkg/pagination"
+)
+
+func TestWhereClause(t *testing.T) {
+	conditions := []Condition{{Column: "sku", Value: "A1"}, {Column: "variant", Value: "red"}}
+
+	tests := []struct {
+		driver string
+		want   string
+	}{
+		{driver: drivers.Postgres, want: ` WHERE "sku" = $1 AND "variant" = $2`},
+		{driver: drivers.MySQL, want: " WHERE `sku` = ? AND `variant` = ?"},
+		{driver: drivers.SQLite, want: ` WHERE "sku" = ? AND "variant" = ?`},
+		{driver: drivers.Oracle, want: ` WHERE "sku" = :1 AND "variant" = :2`},
+		{driver: drivers.SQLServer, want: " WHERE [sku] = @p1 AND [variant] = @p2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.driver, func(t *testing.T) {
+			c := &Client{driver: tt.driver}
+
+			where, args := c.whereClause(conditions)
+			require.Equal(t, tt.want, where)
+			require.Equal(t, []any{"A1", "red"}, args)
+		})
+	}
+
+	where, args := (&Client{driver: drivers.Postgres}).whereClause(nil)
+	require.Empty(t, where)
+	require.Empty(t, args)
+}
+
+func TestSQLiteTableContentWithConditions(t *testing.T) {
+	dbName := filepath.Join(t.TempDir(), "filter.db")
+
+	db, err := sqlx.Open("sqlite", dbName)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec(`
+		CREATE TABLE orders (id INTEGER PRIMARY KEY, customer_id INTEGER);
+		INSERT INTO orders VALUES (1, 10), (2, 20), (3, 10);`)
+	require.NoError(t, err)
+
+	pm, err := pagination.New(100, 0, "")
+	require.NoError(t, err)
+
+	c := &Client{db: db, driver: drivers.SQLite, paginationManager: pm}
+
+	rows, columns, err := c.tableContent(TableRef{Name: "orders"}, Condition{Column: "customer_id", Value: "10"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"id", "customer_id"}, columns)
+	require.Equal(t, [][]string{{"1", "10"}, {"3", "10"}}, rows)
+}
+
+func TestSQLiteTableContentQuotesNames(t *testing.T) {
+	dbName := filepath.Join(t.TempDir(), "quoting.db")
+
+	db, err := sqlx.Open("sqlite", dbName)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec(`
+		CREATE TABLE "order items" ("group" TEXT, "Unit ""Price""" INTEGER);
+		INSERT INTO "order items" VALUES ('a', 1), ('b', 2);`)
+	require.NoError(t, err)
+
+	pm, err := pagination.New(100, 0, "")
+	require.NoError(t, err)
+
+	c := &Client{db: db, driver: drivers.SQLite, paginationManager: pm}
+
+	rows, columns, err := c.tableContent(TableRef{Name: "order items"}, Condition{Column: "group", Value: "b"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"group", `Unit "Price"`}, columns)
+	require.Equal(t, [][]string{{"b", "2"}}, rows)
+}
@@ -0,0 +1,51 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	pgdialect "github.com/danvergara/dblab/pkg/drivers/postgres"
+)
+
+func TestFlavorQueriers(t *testing.T) {
+	var tests = []struct {
+		flavor  string
+		querier databaseQuerier
+	}{
+		{flavor: pgdialect.CockroachDB, querier: &cockroachDB{}},
+		{flavor: pgdialect.YugabyteDB, querier: &yugabyteDB{}},
+		{flavor: pgdialect.Redshift, querier: &redshift{}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.flavor, func(t *testing.T) {
+			newQuerier, ok := flavorQueriers[tc.flavor]
+			require.True(t, ok)
+			require.IsType(t, tc.querier, newQuerier("users", "public", nil))
+		})
+	}
+}
+
+func TestFlavorSystemSchemas(t *testing.T) {
+	require.Equal(t, []string{"information_schema", "pg_catalog"}, newPostgres("users", "", nil).systemSchemas)
+	require.Contains(t, newCockroachDB("users", "", nil).systemSchemas, "crdb_internal")
+	require.Contains(t, newRedshift("users", "", nil).systemSchemas, "pg_internal")
+}
+
+func TestRedshiftLooksUpTablesByName(t *testing.T) {
+	table := TableRef{Schema: "sales", Name: "Orders"}
+
+	for name, build := range map[string]func(TableRef) (string, []any, error){
+		"indexes":         newRedshift("users", "", nil).Indexes,
+		"catalog columns": newRedshift("users", "", nil).CatalogColumns,
+		"catalog indexes": newRedshift("users", "", nil).CatalogIndexes,
+	} {
+		t.Run(name, func(t *testing.T) {
+			query, args, err := build(table)
+			require.NoError(t, err)
+			require.NotContains(t, query, "regclass")
+			require.Equal(t, []any{"sales", "Orders"}, args)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package client
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// ForeignKey describes a foreign key of a table, along with the table and the columns it references.
+type ForeignKey struct {
+	Name    string
+	Table   string
+	Columns []string
+	// RefSchema is the schema of the referenced table, empty for the databases with no schemas.
+	RefSchema  string
+	RefTable   string
+	RefColumns []string
+}
+
+// References method tells whether the foreign key references the given table, or is defined on it.
+func (fk ForeignKey) References(table string) bool {
+	return fk.Table == table || fk.RefTable == table
+}
+
+// ForeignKeys returns the foreign keys defined on the tables of the given schema.
+// If the schema is empty, the current schema is used.
+// The schema is ignored for the databases whose catalog is not organized in schemas.
+func (c *Client) ForeignKeys(ctx context.Context, schema string) ([]ForeignKey, error) {
+	if c.HasSchemas() && schema == "" {
+		var err error
+		if schema, err = c.CurrentSchema(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	query, args, err := c.databaseQuerier.CatalogForeignKeys(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	return queryForeignKeys(ctx, c.db, query, args...)
+}
+
+// TableForeignKeys returns the foreign keys of a table and the ones of other tables of its schema that reference it.
+func (c *Client) TableForeignKeys(ctx context.Context, table TableRef) ([]ForeignKey, error) {
+	foreignKeys, err := c.ForeignKeys(ctx, table.Schema)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.DeleteFunc(foreignKeys, func(fk ForeignKey) bool { return !fk.References(table.Name) }), nil
+}
+
+// queryForeignKeys runs a CatalogForeignKeys query.
+// The query returns a row per column of every foreign key: the name of the constraint, the table, the column,
+// the schema, the table and the column referenced, sorted by table, constraint and position of the column.
+// An empty query stands for a database with no foreign keys, like ClickHouse.
+func queryForeignKeys(ctx context.Context, db *sqlx.DB, query string, args ...any) ([]ForeignKey, error) {
+	if query == "" {
+		return []ForeignKey{}, nil
+	}
+
+	rows, err := queryStrings(ctx, db, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	foreignKeys := make([]ForeignKey, 0)
+	for _, row := range rows {
+		if len(row) < 6 {
+			continue
+		}
+
+		name, table, column, refSchema, refTable, refColumn := row[0], row[1], row[2], row[3], row[4], row[5]
+
+		last := len(foreignKeys) - 1
+		if last >= 0 && foreignKeys[last].Name == name && foreignKeys[last].Table == table {
+			foreignKeys[last].Columns = append(foreignKeys[last].Columns, column)
+			foreignKeys[last].RefColumns = append(foreignKeys[last].RefColumns, refColumn)
+			continue
+		}
+
+		foreignKeys = append(foreignKeys, ForeignKey{
+			Name:       name,
+			Table:      table,
+			Columns:    []string{column},
+			RefSchema:  refSchema,
+			RefTable:   refTable,
+			RefColumns: []string{refColumn},
+		})
+	}
+
+	slices.SortStableFunc(foreignKeys, func(a, b ForeignKey) int {
+		if c := strings.Compare(a.Table, b.Table); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return foreignKeys, nil
+}
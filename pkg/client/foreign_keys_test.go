@@ -0,0 +1,65 @@
+package client
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+
+	"github.com/danvergara/dblab/pkg/drivers"
+)
+
+func TestSQLiteForeignKeys(t *testing.T) {
+	sandboxDir := t.TempDir()
+	dbName := filepath.Join(sandboxDir, "fk.db")
+
+	db, err := sqlx.Open("sqlite", dbName)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec(`
+		CREATE TABLE customers (id INTEGER PRIMARY KEY);
+		CREATE TABLE products (sku TEXT, variant TEXT, PRIMARY KEY (sku, variant));
+		CREATE TABLE orders (
+			id INTEGER PRIMARY KEY,
+			customer_id INTEGER REFERENCES customers (id),
+			sku TEXT,
+			variant TEXT,
+			FOREIGN KEY (sku, variant) REFERENCES products (sku, variant)
+		);`)
+	require.NoError(t, err)
+
+	c := &Client{db: db, driver: drivers.SQLite, databaseQuerier: newSQLite(dbName, db)}
+
+	foreignKeys, err := c.ForeignKeys(context.Background(), "")
+	require.NoError(t, err)
+	require.Len(t, foreignKeys, 2)
+
+	byRefTable := make(map[string]ForeignKey)
+	for _, fk := range foreignKeys {
+		require.Equal(t, "orders", fk.Table)
+		byRefTable[fk.RefTable] = fk
+	}
+
+	require.Equal(t, []string{"customer_id"}, byRefTable["customers"].Columns)
+	require.Equal(t, []string{"id"}, byRefTable["customers"].RefColumns)
+	require.Equal(t, []string{"sku", "variant"}, byRefTable["products"].Columns)
+	require.Equal(t, []string{"sku", "variant"}, byRefTable["products"].RefColumns)
+
+	related, err := c.TableForeignKeys(context.Background(), TableRef{Name: "customers"})
+	require.NoError(t, err)
+	require.Len(t, related, 1)
+	require.Equal(t, "customers", related[0].RefTable)
+
+	snapshot, err := c.Snapshot(context.Background(), "")
+	require.NoError(t, err)
+	for _, table := range snapshot.Tables {
+		if table.Name == "orders" {
+			require.Len(t, table.ForeignKeys, 2)
+		} else {
+			require.Empty(t, table.ForeignKeys)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"slices"
+)
+
+// jsonColumnsQuerier is implemented by the queriers of the databases whose JSON columns can't be told apart by their type,
+// e.g. MariaDB, where JSON is an alias of LONGTEXT.
+type jsonColumnsQuerier interface {
+	// JSONColumns returns a query to list the names of the JSON columns of a table.
+	JSONColumns(table TableRef) (string, []any, error)
+}
+
+// formatJSONColumns method rewrites the values of the JSON columns of a table in the given rows as compact JSON,
+// the way the databases with a JSON type show them. The rows are left as they are if the columns can't be read.
+func (c *Client) formatJSONColumns(table TableRef, rows [][]string, columns []string) {
+	q, ok := c.databaseQuerier.(jsonColumnsQuerier)
+	if !ok {
+		return
+	}
+
+	query, args, err := q.JSONColumns(table)
+	if err != nil {
+		return
+	}
+
+	names, err := queryStrings(context.Background(), c.db, query, args...)
+	if err != nil {
+		return
+	}
+
+	for _, name := range names {
+		i := slices.Index(columns, name[0])
+		if i < 0 {
+			continue
+		}
+
+		for _, row := range rows {
+			row[i] = compactJSON(row[i])
+		}
+	}
+}
+
+// compactJSON function returns a JSON document without the insignificant whitespace,
+// or the text as it is if it's not a JSON document, e.g. NULL.
+func compactJSON(text string) string {
+	var b bytes.Buffer
+	if err := json.Compact(&b, []byte(text)); err != nil {
+		return text
+	}
+
+	return b.String()
+}
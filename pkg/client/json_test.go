@@ -0,0 +1,34 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompactJSON(t *testing.T) {
+	var tests = []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "object", text: `{ "a" :  1, "b": [1,  2] }`, want: `{"a":1,"b":[1,2]}`},
+		{name: "scalar", text: ` "text" `, want: `"text"`},
+		{name: "null", text: "<nil>", want: "<nil>"},
+		{name: "invalid", text: `{"a":`, want: `{"a":`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, compactJSON(tc.text))
+		})
+	}
+}
+
+func TestFormatJSONColumnsSkipsOtherQueriers(t *testing.T) {
+	c := &Client{databaseQuerier: newMySQL("users", nil)}
+	rows := [][]string{{`{ "a" : 1 }`}}
+
+	c.formatJSONColumns(TableRef{Name: "documents"}, rows, []string{"body"})
+	require.Equal(t, [][]string{{`{ "a" : 1 }`}}, rows)
+}
@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Lock is a lock held, or waited for, by a session of the server.
+type Lock struct {
+	// SessionID identifies the session, as in the activity of the server.
+	SessionID string
+	// BlockedBy are the sessions the session waits for, if any.
+	BlockedBy []string
+	User      string
+	State     string
+	Mode      string
+	// Granted tells whether the lock is held, or waited for.
+	Granted bool
+	// Object is the locked object, e.g. a table, or the type of the lock if it's not on an object.
+	Object string
+	// Wait is the time the session has been running its query, or waiting for the lock.
+	Wait  time.Duration
+	Query string
+}
+
+// Locks returns the locks held and waited for by the sessions of the server, but the ones of dblab itself,
+// along with the sessions each one is blocked by.
+func (c *Client) Locks(ctx context.Context) ([]Lock, error) {
+	query, args, err := c.databaseQuerier.Locks()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := queryStrings(ctx, c.db, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	locks := make([]Lock, 0, len(rows))
+	for _, row := range rows {
+		if len(row) < 9 {
+			return nil, fmt.Errorf("unexpected number of columns in the locks of the server: %d", len(row))
+		}
+
+		var blockedBy []string
+		for _, id := range strings.Split(row[1], ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				blockedBy = append(blockedBy, id)
+			}
+		}
+
+		seconds, _ := strconv.ParseFloat(strings.TrimSpace(row[7]), 64)
+
+		locks = append(locks, Lock{
+			SessionID: row[0],
+			BlockedBy: blockedBy,
+			User:      row[2],
+			State:     row[3],
+			Mode:      row[4],
+			Granted:   isYes(row[5]),
+			Object:    row[6],
+			Wait:      time.Duration(seconds * float64(time.Second)),
+			Query:     row[8],
+		})
+	}
+
+	return locks, nil
+}
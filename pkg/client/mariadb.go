@@ -0,0 +1,210 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+
+	mysqldialect "github.com/danvergara/dblab/pkg/drivers/mysql"
+)
+
+// temporalTableKind is the kind of the system-versioned tables of MariaDB, which keep the history of their rows.
+var temporalTableKind = nodeKind{Type: "table", idPrefix: "t", suffix: "tt"}
+
+// mariaDBJSONCheck is the condition of the check constraint MariaDB adds to the JSON columns of information_schema.COLUMNS c,
+// since JSON is an alias of LONGTEXT, checked by json_valid.
+const mariaDBJSONCheck = `EXISTS (
+	SELECT 1 FROM information_schema.CHECK_CONSTRAINTS AS cc
+	WHERE cc.CONSTRAINT_SCHEMA = c.TABLE_SCHEMA
+		AND cc.TABLE_NAME = c.TABLE_NAME
+		AND cc.CHECK_CLAUSE = CONCAT('json_valid(` + "`" + `', c.COLUMN_NAME, '` + "`" + `)')
+)`
+
+// mariaDB struct is in charge of the queries of MariaDB. It takes the mysql queries,
+// but lists the sequences and the system-versioned tables, and tells the JSON columns apart from the text ones.
+type mariaDB struct {
+	*mysql
+}
+
+// a validation to see if mariaDB is implementing databaseQuerier.
+var _ databaseQuerier = (*mariaDB)(nil)
+
+// returns a pointer to a mariaDB.
+func newMariaDB(dbName string, db *sqlx.DB) *mariaDB {
+	return &mariaDB{mysql: newMySQL(dbName, db)}
+}
+
+// TableStructure returns a query with the columns of DESCRIBE, read from information_schema.COLUMNS,
+// where the type of the JSON columns is json rather than longtext.
+func (m *mariaDB) TableStructure(table TableRef) (string, []any, error) {
+	query := `
+		SELECT
+			c.COLUMN_NAME AS Field,
+			IF(c.DATA_TYPE = 'longtext' AND ` + mariaDBJSONCheck + `, 'json', c.COLUMN_TYPE) AS Type,
+			c.IS_NULLABLE AS ` + "`Null`" + `,
+			c.COLUMN_KEY AS ` + "`Key`" + `,
+			c.COLUMN_DEFAULT AS ` + "`Default`" + `,
+			c.EXTRA AS Extra
+		FROM information_schema.COLUMNS AS c
+		WHERE c.TABLE_SCHEMA = ? AND c.TABLE_NAME = ?
+		ORDER BY c.ORDINAL_POSITION`
+
+	return query, []any{m.dbName, table.Name}, nil
+}
+
+// JSONColumns returns a query to list the names of the JSON columns of a table.
+func (m *mariaDB) JSONColumns(table TableRef) (string, []any, error) {
+	query := `
+		SELECT c.COLUMN_NAME
+		FROM information_schema.COLUMNS AS c
+		WHERE c.TABLE_SCHEMA = ? AND c.TABLE_NAME = ? AND c.DATA_TYPE = 'longtext' AND ` + mariaDBJSONCheck
+
+	return query, []any{m.dbName, table.Name}, nil
+}
+
+// Children returns the nodes right under the given node of the MariaDB database graph.
+// The database holds the tables, the system-versioned tables and the views,
+// followed by the functions, procedures, triggers and sequences.
+// MariaDB topography:
+//
+//			     [Database]
+//			      /     \
+//			     v       v
+//	 			[Tables] [Temporal Tables]	[Views]	[Functions]	[Procedures]	[Triggers]	[Sequences]
+func (m *mariaDB) Children(ctx context.Context, node *DBNode) ([]*DBNode, error) {
+	if node.Type != "database" {
+		return nil, nil
+	}
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Question)
+
+	nodes := make([]*DBNode, 0)
+	for _, q := range []struct {
+		kind      nodeKind
+		tableType string
+	}{
+		{kind: nodeKind{Type: "table", idPrefix: "t", suffix: "t"}, tableType: "BASE TABLE"},
+		{kind: temporalTableKind, tableType: "SYSTEM VERSIONED"},
+	} {
+		query, args, err := psql.Select("TABLE_NAME").
+			From("information_schema.TABLES").
+			Where(sq.Eq{"TABLE_SCHEMA": m.dbName, "TABLE_TYPE": q.tableType}).
+			OrderBy("TABLE_NAME").
+			ToSql()
+		if err != nil {
+			return nil, err
+		}
+
+		tables, err := queryNodes(ctx, m.db, q.kind, node.Name, node.ID, query, args...)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, tables...)
+	}
+
+	views, err := m.fetchViews(ctx, node.Name, node.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	objects, err := m.fetchObjects(ctx, node.Name, node.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	query, args, err := psql.Select("TABLE_NAME").
+		From("information_schema.TABLES").
+		Where(sq.Eq{"TABLE_SCHEMA": m.dbName, "TABLE_TYPE": "SEQUENCE"}).
+		OrderBy("TABLE_NAME").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	sequences, err := queryNodes(ctx, m.db, sequenceKind, node.Name, node.ID, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.Concat(nodes, views, objects, sequences), nil
+}
+
+// GetObjectDefinition method returns the source definition of a function, procedure, trigger or sequence.
+// The CREATE SEQUENCE statement is rebuilt from the sequence itself, which reads as a single row table.
+func (m *mariaDB) GetObjectDefinition(obj ObjectRef) (string, []any, error) {
+	if obj.Type != sequenceKind.Type {
+		return m.mysql.GetObjectDefinition(obj)
+	}
+
+	name := mysqldialect.QuoteIdentifier(m.dbName) + "." + mysqldialect.QuoteIdentifier(obj.Name)
+	query := fmt.Sprintf(`
+		SELECT CONCAT(
+			'CREATE SEQUENCE ', ?,
+			' START WITH ', start_value,
+			' MINVALUE ', minimum_value,
+			' MAXVALUE ', maximum_value,
+			' INCREMENT BY ', increment,
+			' CACHE ', cache_size,
+			IF(cycle_option, ' CYCLE', ' NOCYCLE'),
+			';'
+		) AS definition
+		FROM %s`, name)
+
+	return query, []any{name}, nil
+}
+
+// Locks returns a query to list the InnoDB locks of the server from information_schema.INNODB_LOCKS,
+// along with the sessions blocking each one, from information_schema.INNODB_LOCK_WAITS,
+// since MariaDB has no performance_schema.data_locks.
+func (m *mariaDB) Locks() (string, []any, error) {
+	query := `
+		SELECT
+			t.trx_mysql_thread_id,
+			COALESCE((
+				SELECT GROUP_CONCAT(DISTINCT bt.trx_mysql_thread_id)
+				FROM information_schema.INNODB_LOCK_WAITS w
+				JOIN information_schema.INNODB_TRX bt ON bt.trx_id = w.blocking_trx_id
+				WHERE w.requested_lock_id = l.lock_id
+			), ''),
+			COALESCE(p.USER, ''),
+			COALESCE(p.STATE, ''),
+			l.lock_mode,
+			IF(t.trx_requested_lock_id = l.lock_id, 'NO', 'YES'),
+			CONCAT(l.lock_table, COALESCE(CONCAT(' (', l.lock_index, ')'), '')),
+			COALESCE(p.TIME, 0),
+			COALESCE(t.trx_query, '')
+		FROM information_schema.INNODB_LOCKS l
+		JOIN information_schema.INNODB_TRX t ON t.trx_id = l.lock_trx_id
+		LEFT JOIN information_schema.PROCESSLIST p ON p.ID = t.trx_mysql_thread_id
+		WHERE t.trx_mysql_thread_id <> CONNECTION_ID()
+		ORDER BY t.trx_mysql_thread_id`
+
+	return query, nil, nil
+}
+
+// Roles returns a query to list the accounts and roles of the server from mysql.global_priv, along with their attributes
+// and the roles granted to them, from mysql.roles_mapping, since MariaDB has no mysql.role_edges.
+func (m *mariaDB) Roles() (string, []any, error) {
+	query := `
+		SELECT
+			IF(u.Host = '', u.User, CONCAT(u.User, '@', u.Host)),
+			CONCAT_WS(', ',
+				IF(JSON_VALUE(u.Priv, '$.is_role') = 'true', 'role', NULL),
+				IF(JSON_VALUE(u.Priv, '$.account_locked') = 'true', 'locked', NULL),
+				IF(JSON_VALUE(u.Priv, '$.password_last_changed') = '0', 'password expired', NULL),
+				IF(JSON_VALUE(u.Priv, '$.max_user_connections') > 0,
+					CONCAT('connection limit ', JSON_VALUE(u.Priv, '$.max_user_connections')), NULL)
+			),
+			COALESCE((
+				SELECT GROUP_CONCAT(r.Role ORDER BY r.Role)
+				FROM mysql.roles_mapping r
+				WHERE r.User = u.User AND r.Host = u.Host
+			), '')
+		FROM mysql.global_priv u
+		ORDER BY u.User, u.Host`
+
+	return query, nil, nil
+}
@@ -0,0 +1,157 @@
+package client
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+
+	"github.com/danvergara/dblab/pkg/command"
+	"github.com/danvergara/dblab/pkg/drivers"
+)
+
+// MariaDBTestSuite runs the MariaDB querier against a server container.
+// It's run with DB_DRIVER=mariadb, e.g. make test DB_DRIVER=mariadb.
+type MariaDBTestSuite struct {
+	suite.Suite
+	container testcontainers.Container
+	ctx       context.Context
+	user      string
+	password  string
+	dbName    string
+	host      string
+	port      nat.Port
+}
+
+func (suite *MariaDBTestSuite) SetupSuite() {
+	if testing.Short() {
+		suite.T().Skip("skipping integration tests in short mode.")
+	}
+
+	if os.Getenv("DB_DRIVER") != drivers.MariaDB {
+		suite.T().Skip("skipping mariadb integration tests, DB_DRIVER is not mariadb.")
+	}
+
+	suite.ctx = context.Background()
+	suite.user = os.Getenv("DB_USER")
+	suite.password = os.Getenv("DB_PASSWORD")
+	suite.dbName = os.Getenv("DB_NAME")
+
+	container, err := testcontainers.GenericContainer(suite.ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        "mariadb:11.4",
+			ExposedPorts: []string{"3306/tcp"},
+			Env: map[string]string{
+				"MARIADB_DATABASE":      suite.dbName,
+				"MARIADB_USER":          suite.user,
+				"MARIADB_PASSWORD":      suite.password,
+				"MARIADB_ROOT_PASSWORD": suite.password,
+			},
+			WaitingFor: wait.ForListeningPort("3306/tcp").WithStartupTimeout(60 * time.Second),
+		},
+		Started: true,
+	})
+	require.NoError(suite.T(), err)
+	suite.container = container
+
+	suite.host, err = container.Host(suite.ctx)
+	require.NoError(suite.T(), err)
+	suite.port, err = container.MappedPort(suite.ctx, "3306")
+	require.NoError(suite.T(), err)
+
+	c := suite.newClient(false)
+	defer c.DB().Close()
+
+	for _, statement := range []string{
+		`CREATE TABLE documents (id INT PRIMARY KEY, body JSON, note LONGTEXT)`,
+		`INSERT INTO documents VALUES (1, '{ "a" :  1, "b": [1,  2] }', '{ "a" :  1 }'), (2, NULL, NULL)`,
+		`CREATE TABLE prices (id INT PRIMARY KEY, amount DECIMAL(10, 2)) WITH SYSTEM VERSIONING`,
+		`CREATE SEQUENCE invoice_numbers START WITH 100 INCREMENT BY 10`,
+	} {
+		_, err := c.DB().Exec(statement)
+		require.NoError(suite.T(), err, statement)
+	}
+}
+
+func (suite *MariaDBTestSuite) TearDownSuite() {
+	if suite.container != nil {
+		if err := suite.container.Terminate(suite.ctx); err != nil {
+			suite.T().Fatalf("failed to terminate container: %s", err)
+		}
+	}
+}
+
+// newClient method connects to the server by the connection flags.
+func (suite *MariaDBTestSuite) newClient(readOnly bool) *Client {
+	c, err := New(command.Options{
+		Driver:   drivers.MariaDB,
+		Host:     suite.host,
+		Port:     suite.port.Port(),
+		User:     suite.user,
+		Pass:     suite.password,
+		DBName:   suite.dbName,
+		Limit:    10,
+		ReadOnly: readOnly,
+	})
+	require.NoError(suite.T(), err)
+
+	return c
+}
+
+func (suite *MariaDBTestSuite) TestChildren() {
+	c := suite.newClient(false)
+	defer c.DB().Close()
+
+	children, err := c.Children(suite.ctx, c.Root())
+	suite.NoError(err)
+
+	names := make(map[string]string)
+	for _, child := range children {
+		names[child.Name] = child.Type
+	}
+
+	suite.Equal("table", names["documents - t"])
+	suite.Equal("table", names["prices - tt"])
+	suite.Equal("sequence", names["invoice_numbers - sq"])
+
+	definition, err := c.ObjectMetadata(ObjectRef{Name: "invoice_numbers", Type: "sequence"})
+	suite.NoError(err)
+	suite.Contains(definition.Definition, "START WITH 100")
+	suite.Contains(definition.Definition, "INCREMENT BY 10")
+}
+
+func (suite *MariaDBTestSuite) TestJSONColumns() {
+	c := suite.newClient(false)
+	defer c.DB().Close()
+
+	m, err := c.Metadata(TableRef{Name: "documents"})
+	suite.NoError(err)
+
+	types := make(map[string]string)
+	for _, row := range m.Structure.Rows {
+		types[row[0]] = row[1]
+	}
+	suite.Equal("json", types["body"])
+	suite.Equal("longtext", types["note"])
+
+	// the JSON column is compacted, the text one is left as it was written.
+	suite.Equal([]string{"1", `{"a":1,"b":[1,2]}`, `{ "a" :  1 }`}, m.TableContent.Rows[0])
+}
+
+func (suite *MariaDBTestSuite) TestReadOnly() {
+	c := suite.newClient(true)
+	defer c.DB().Close()
+
+	_, err := c.DB().Exec(`INSERT INTO documents VALUES (3, NULL, NULL)`)
+	suite.Error(err)
+}
+
+func TestMariaDBTestSuite(t *testing.T) {
+	suite.Run(t, new(MariaDBTestSuite))
+}
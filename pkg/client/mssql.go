@@ -3,9 +3,12 @@ package client
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/jmoiron/sqlx"
+
+	mssqldialect "github.com/danvergara/dblab/pkg/drivers/sqlserver"
 )
 
 // mssql struct is in charge of perform all the SQL Server related queries.
@@ -28,6 +31,16 @@ func newMSSQL(dbName, schema string, db *sqlx.DB) *mssql {
 	return &m
 }
 
+// mssqlObject function returns the name of an object qualified by its schema, if any, both quoted,
+// as it's given to OBJECT_ID, so the names with spaces or brackets are found.
+func mssqlObject(schema, name string) string {
+	if schema == "" {
+		return mssqldialect.QuoteIdentifier(name)
+	}
+
+	return mssqldialect.QuoteIdentifier(schema) + "." + mssqldialect.QuoteIdentifier(name)
+}
+
 // TableStructure returns a query string to retrieve all the relevant information of a given table.
 func (m *mssql) TableStructure(table TableRef) (string, []any, error) {
 	psql := sq.StatementBuilder.PlaceholderFormat(sq.AtP)
@@ -41,7 +54,7 @@ func (m *mssql) TableStructure(table TableRef) (string, []any, error) {
 	).
 		From("sys.columns c").
 		InnerJoin("sys.types t ON c.user_type_id = t.user_type_id").
-		Where(fmt.Sprintf("c.object_id = OBJECT_ID('%s')", table.Name)).
+		Where(sq.Expr("c.object_id = OBJECT_ID(?)", mssqlObject(table.Schema, table.Name))).
 		ToSql()
 	if err != nil {
 		return "", nil, err
@@ -109,10 +122,10 @@ func (m *mssql) Indexes(table TableRef) (string, []any, error) {
 	return query, args, nil
 }
 
-// Catalog returns a the pointer to a DBNode instance,
-// which is the root of the current SQL Server database graph.
-// It starts with the database itself,
-// then the schemas and the correspondent lists of tables and views.
+// Children returns the nodes right under the given node of the SQL Server database graph.
+// The database holds the schemas, or only the one the client is scoped to,
+// and the schemas hold the correspondent lists of tables, views,
+// functions, procedures, sequences, triggers and user-defined types.
 // SQL Server topography:
 //
 //					 [Database]
@@ -121,56 +134,41 @@ func (m *mssql) Indexes(table TableRef) (string, []any, error) {
 //			     [Schemas]
 //			      /     \
 //			     v       v
-//	 		 [Tables] 	[Views]
-func (m *mssql) Catalog(ctx context.Context) (*DBNode, error) {
-	rootID := fmt.Sprintf("db:%s", m.dbName)
-	root := &DBNode{ID: rootID, Name: m.dbName, Type: "database"}
-
-	queue := []*DBNode{root}
-
-	for len(queue) > 0 {
-		current := queue[0]
-		queue = queue[1:]
-
-		var children []*DBNode
-		var err error
-		switch current.Type {
-		case "database":
-			if m.schema != "" {
-				children = append(children, &DBNode{
-					ID:         fmt.Sprintf("%s.s:%s", rootID, m.schema),
-					Name:       m.schema,
-					EntityName: m.schema,
-					Type:       "schema",
-					ParentID:   rootID,
-				})
-			} else {
-				children, err = m.fetchSchemas(ctx, current.Name)
-			}
-		case "schema":
-			tables, err := m.fetchTables(ctx, current.Name, current.ID)
-			if err != nil {
-				return nil, err
-			}
-			children = append(children, tables...)
-
-			views, err := m.fetchViews(ctx, current.Name, current.ID)
-			if err != nil {
-				return nil, err
-			}
-			children = append(children, views...)
+//	 		 [Tables] 	[Views]	[Functions]	[Procedures]	[Sequences]	[Triggers]	[Types]
+func (m *mssql) Children(ctx context.Context, node *DBNode) ([]*DBNode, error) {
+	switch node.Type {
+	case "database":
+		if m.schema != "" {
+			return []*DBNode{{
+				ID:         fmt.Sprintf("%s.s:%s", node.ID, m.schema),
+				Name:       m.schema,
+				EntityName: m.schema,
+				Type:       "schema",
+				ParentID:   node.ID,
+			}}, nil
+		}
+
+		return m.fetchSchemas(ctx, node.ID)
+	case "schema":
+		tables, err := m.fetchTables(ctx, node.Name, node.ID)
+		if err != nil {
+			return nil, err
 		}
+
+		views, err := m.fetchViews(ctx, node.Name, node.ID)
 		if err != nil {
 			return nil, err
 		}
 
-		for _, child := range children {
-			current.Children = append(current.Children, child)
-			queue = append(queue, child)
+		objects, err := m.fetchObjects(ctx, node.Name, node.ID)
+		if err != nil {
+			return nil, err
 		}
+
+		return slices.Concat(tables, views, objects), nil
 	}
 
-	return root, nil
+	return nil, nil
 }
 
 // GetViewDefinition method returns the SQL definition of a given view.
@@ -178,7 +176,7 @@ func (m *mssql) GetViewDefinition(view ViewRef) (string, []any, error) {
 	psql := sq.StatementBuilder.PlaceholderFormat(sq.AtP)
 	query, args, err := psql.
 		Select().
-		Column(sq.Expr("OBJECT_DEFINITION(OBJECT_ID(?)) AS view_definition", fmt.Sprintf("%s.%s", view.Schema, view.Name))).
+		Column(sq.Expr("OBJECT_DEFINITION(OBJECT_ID(?)) AS view_definition", mssqlObject(view.Schema, view.Name))).
 		ToSql()
 
 	if err != nil {
@@ -188,6 +186,336 @@ func (m *mssql) GetViewDefinition(view ViewRef) (string, []any, error) {
 	return query, args, nil
 }
 
+// GetObjectDefinition method returns the source definition of a function, procedure, sequence, trigger or type.
+// Sequences and types have no stored definition, so their CREATE statement is rebuilt from the catalog views.
+func (m *mssql) GetObjectDefinition(obj ObjectRef) (string, []any, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.AtP)
+
+	var query sq.SelectBuilder
+	switch obj.Type {
+	case functionKind.Type, procedureKind.Type, triggerKind.Type:
+		query = psql.
+			Select().
+			Column(sq.Expr("OBJECT_DEFINITION(OBJECT_ID(?)) AS definition", mssqlObject(obj.Schema, obj.Name)))
+	case sequenceKind.Type:
+		query = psql.
+			Select(`CONCAT(
+				'CREATE SEQUENCE ', SCHEMA_NAME(s.schema_id), '.', s.name, ' AS ', TYPE_NAME(s.user_type_id),
+				' START WITH ', CAST(s.start_value AS nvarchar(50)),
+				' INCREMENT BY ', CAST(s.increment AS nvarchar(50)),
+				' MINVALUE ', CAST(s.minimum_value AS nvarchar(50)),
+				' MAXVALUE ', CAST(s.maximum_value AS nvarchar(50)),
+				IIF(s.is_cycling = 1, ' CYCLE', ' NO CYCLE'), ';'
+			) AS definition`).
+			From("sys.sequences AS s").
+			Where(sq.Eq{
+				"SCHEMA_NAME(s.schema_id)": obj.Schema,
+				"s.name":                   obj.Name,
+			})
+	case typeKind.Type:
+		query = psql.
+			Select(`CONCAT(
+				'CREATE TYPE ', SCHEMA_NAME(t.schema_id), '.', t.name,
+				IIF(
+					t.is_table_type = 1,
+					CONCAT(' AS TABLE (', (
+						SELECT STRING_AGG(CONCAT(c.name, ' ', TYPE_NAME(c.user_type_id)), ', ') WITHIN GROUP (ORDER BY c.column_id)
+						FROM sys.columns AS c
+						JOIN sys.table_types AS tt ON tt.type_table_object_id = c.object_id
+						WHERE tt.user_type_id = t.user_type_id
+					), ')'),
+					CONCAT(' FROM ', TYPE_NAME(t.system_type_id),
+						CASE
+							WHEN TYPE_NAME(t.system_type_id) IN ('varchar', 'char', 'varbinary', 'binary')
+								THEN CONCAT('(', IIF(t.max_length = -1, 'max', CAST(t.max_length AS nvarchar(10))), ')')
+							WHEN TYPE_NAME(t.system_type_id) IN ('nvarchar', 'nchar')
+								THEN CONCAT('(', IIF(t.max_length = -1, 'max', CAST(t.max_length / 2 AS nvarchar(10))), ')')
+							WHEN TYPE_NAME(t.system_type_id) IN ('decimal', 'numeric')
+								THEN CONCAT('(', t.precision, ', ', t.scale, ')')
+							ELSE ''
+						END,
+						IIF(t.is_nullable = 1, '', ' NOT NULL'))
+				), ';'
+			) AS definition`).
+			From("sys.types AS t").
+			Where(sq.Eq{
+				"t.is_user_defined":        1,
+				"SCHEMA_NAME(t.schema_id)": obj.Schema,
+				"t.name":                   obj.Name,
+			})
+	default:
+		return "", nil, fmt.Errorf("%s objects not supported", obj.Type)
+	}
+
+	return query.ToSql()
+}
+
+// CatalogColumns returns a query to list the columns of a table, flagging nullable, primary and foreign key columns.
+func (m *mssql) CatalogColumns(table TableRef) (string, []any, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.AtP)
+	return psql.Select(
+		"c.name",
+		"TYPE_NAME(c.user_type_id)",
+		"IIF(c.is_nullable = 1, 'YES', 'NO')",
+		`IIF(EXISTS (
+			SELECT 1 FROM sys.index_columns AS ic
+			JOIN sys.indexes AS i ON i.object_id = ic.object_id AND i.index_id = ic.index_id
+			WHERE i.is_primary_key = 1 AND ic.object_id = c.object_id AND ic.column_id = c.column_id
+		), 'YES', 'NO')`,
+		`IIF(EXISTS (
+			SELECT 1 FROM sys.foreign_key_columns AS fkc
+			WHERE fkc.parent_object_id = c.object_id AND fkc.parent_column_id = c.column_id
+		), 'YES', 'NO')`,
+	).
+		From("sys.columns AS c").
+		Where(sq.Expr("c.object_id = OBJECT_ID(?)", mssqlObject(table.Schema, table.Name))).
+		OrderBy("c.column_id").
+		ToSql()
+}
+
+// CatalogIndexes returns a query to list the indexes of a table, whether they are unique and their key columns.
+func (m *mssql) CatalogIndexes(table TableRef) (string, []any, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.AtP)
+	return psql.Select(
+		"i.name",
+		"IIF(i.is_unique = 1, 'YES', 'NO')",
+		`STUFF((
+			SELECT ',' + COL_NAME(ic.object_id, ic.column_id)
+			FROM sys.index_columns AS ic
+			WHERE ic.object_id = i.object_id AND ic.index_id = i.index_id AND ic.is_included_column = 0
+			ORDER BY ic.key_ordinal
+			FOR XML PATH('')
+		), 1, 1, '')`,
+	).
+		From("sys.indexes AS i").
+		Where(sq.Expr("i.object_id = OBJECT_ID(?)", mssqlObject(table.Schema, table.Name))).
+		Where("i.name IS NOT NULL").
+		OrderBy("i.name").
+		ToSql()
+}
+
+// CatalogForeignKeys returns a query to list the columns of the foreign keys of a schema,
+// along with the columns they reference.
+func (m *mssql) CatalogForeignKeys(schema string) (string, []any, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.AtP)
+	return psql.Select(
+		"fk.name",
+		"OBJECT_NAME(fk.parent_object_id)",
+		"COL_NAME(fkc.parent_object_id, fkc.parent_column_id)",
+		"OBJECT_SCHEMA_NAME(fk.referenced_object_id)",
+		"OBJECT_NAME(fk.referenced_object_id)",
+		"COL_NAME(fkc.referenced_object_id, fkc.referenced_column_id)",
+	).
+		From("sys.foreign_keys AS fk").
+		Join("sys.foreign_key_columns AS fkc ON fkc.constraint_object_id = fk.object_id").
+		Where(sq.Eq{"SCHEMA_NAME(fk.schema_id)": schema}).
+		OrderBy("OBJECT_NAME(fk.parent_object_id)", "fk.name", "fkc.constraint_column_id").
+		ToSql()
+}
+
+// CatalogRowCounts returns a query to list the row count of the tables of a schema,
+// as kept in the partitions of their heap or clustered index.
+func (m *mssql) CatalogRowCounts(schema string) (string, []any, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.AtP)
+	return psql.Select(
+		"t.name",
+		"SUM(p.rows)",
+	).
+		From("sys.tables AS t").
+		Join("sys.partitions AS p ON p.object_id = t.object_id").
+		Where(sq.Eq{
+			"SCHEMA_NAME(t.schema_id)": schema,
+			// 0 is the heap, 1 the clustered index.
+			"p.index_id": []int{0, 1},
+		}).
+		GroupBy("t.name").
+		OrderBy("t.name").
+		ToSql()
+}
+
+// TableStats returns the statistics of a table: its row count, the space reserved and used by its data and indexes,
+// the times it was created and modified, and the last time its statistics were updated.
+// The sizes are the ones reported by sp_spaceused, read from sys.dm_db_partition_stats in bytes rather than as text,
+// which takes the VIEW DATABASE STATE permission.
+func (m *mssql) TableStats(ctx context.Context, table TableRef) ([]Stat, error) {
+	return queryStats(ctx, m.db, `
+		SELECT
+			SUM(CASE WHEN ps.index_id IN (0, 1) THEN ps.row_count ELSE 0 END) AS estimated_rows,
+			SUM(ps.reserved_page_count) * 8192 AS reserved_size_bytes,
+			SUM(CASE WHEN ps.index_id IN (0, 1)
+				THEN ps.in_row_data_page_count + ps.lob_used_page_count + ps.row_overflow_used_page_count
+				ELSE 0 END) * 8192 AS data_size_bytes,
+			SUM(CASE WHEN ps.index_id > 1 THEN ps.used_page_count ELSE 0 END) * 8192 AS indexes_size_bytes,
+			MAX(o.create_date) AS created,
+			MAX(o.modify_date) AS last_modified,
+			(SELECT MAX(STATS_DATE(s.object_id, s.stats_id)) FROM sys.stats AS s WHERE s.object_id = o.object_id) AS last_statistics_update
+		FROM sys.objects AS o
+		JOIN sys.dm_db_partition_stats AS ps ON ps.object_id = o.object_id
+		WHERE o.object_id = OBJECT_ID(@p1)
+		GROUP BY o.object_id`, mssqlObject(table.Schema, table.Name))
+}
+
+// Activity returns a query to list the user sessions of the server, along with the request they are running,
+// from sys.dm_exec_sessions and sys.dm_exec_requests. It takes the VIEW SERVER STATE permission to see the other logins.
+func (m *mssql) Activity() (string, []any, error) {
+	query := `
+		SELECT
+			s.session_id,
+			COALESCE(s.login_name, ''),
+			COALESCE(DB_NAME(COALESCE(r.database_id, s.database_id)), ''),
+			COALESCE(r.status, s.status),
+			DATEDIFF(millisecond, COALESCE(r.start_time, s.last_request_start_time), GETDATE()) / 1000.0,
+			COALESCE(r.wait_type, ''),
+			COALESCE(t.text, '')
+		FROM sys.dm_exec_sessions AS s
+		LEFT JOIN sys.dm_exec_requests AS r ON r.session_id = s.session_id
+		OUTER APPLY sys.dm_exec_sql_text(r.sql_handle) AS t
+		WHERE s.is_user_process = 1 AND s.session_id <> @@SPID
+		ORDER BY 5 DESC`
+
+	return query, nil, nil
+}
+
+// CancelSession is not supported, since SQL Server can only cancel the requests of the current session.
+func (m *mssql) CancelSession(id string) (string, []any, error) {
+	return "", nil, fmt.Errorf("sql server can't cancel the query of another session, terminate it instead")
+}
+
+// TerminateSession returns a statement to kill a session, rolling back its open transaction.
+// KILL takes no placeholders, so the session ID is written in.
+func (m *mssql) TerminateSession(id string) (string, []any, error) {
+	session, err := sessionNumber(id)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return fmt.Sprintf("KILL %d", session), nil, nil
+}
+
+// Locks returns a query to list the locks of the current database from sys.dm_tran_locks,
+// along with the session blocking each one, as given by the blocking_session_id of its request.
+// The shared locks every session holds on the database are left out.
+func (m *mssql) Locks() (string, []any, error) {
+	query := `
+		SELECT
+			l.request_session_id,
+			COALESCE(CAST(NULLIF(r.blocking_session_id, 0) AS varchar(10)), ''),
+			COALESCE(s.login_name, ''),
+			COALESCE(r.status, s.status, ''),
+			l.request_mode,
+			CASE l.request_status WHEN 'GRANT' THEN 'YES' ELSE 'NO' END,
+			CASE l.resource_type
+				WHEN 'OBJECT' THEN OBJECT_SCHEMA_NAME(l.resource_associated_entity_id) + '.' + OBJECT_NAME(l.resource_associated_entity_id)
+				ELSE l.resource_type
+			END,
+			COALESCE(r.wait_time, 0) / 1000.0,
+			COALESCE(t.text, '')
+		FROM sys.dm_tran_locks AS l
+		LEFT JOIN sys.dm_exec_sessions AS s ON s.session_id = l.request_session_id
+		LEFT JOIN sys.dm_exec_requests AS r ON r.session_id = l.request_session_id
+		OUTER APPLY sys.dm_exec_sql_text(r.sql_handle) AS t
+		WHERE l.resource_database_id = DB_ID() AND l.resource_type <> 'DATABASE' AND l.request_session_id <> @@SPID
+		ORDER BY l.request_session_id, l.request_status`
+
+	return query, nil, nil
+}
+
+// Roles returns a query to list the users and roles of the database from sys.database_principals,
+// along with their type and default schema, and the roles they are members of.
+func (m *mssql) Roles() (string, []any, error) {
+	query := `
+		SELECT
+			p.name,
+			LOWER(REPLACE(p.type_desc, '_', ' ')) + COALESCE(', default schema ' + p.default_schema_name, ''),
+			COALESCE(STUFF((
+				SELECT ',' + r.name
+				FROM sys.database_role_members AS rm
+				JOIN sys.database_principals AS r ON r.principal_id = rm.role_principal_id
+				WHERE rm.member_principal_id = p.principal_id
+				ORDER BY r.name
+				FOR XML PATH('')
+			), 1, 1, ''), '')
+		FROM sys.database_principals AS p
+		WHERE p.type IN ('S', 'U', 'G', 'R', 'E', 'X', 'C', 'K')
+			AND p.name NOT IN ('sys', 'INFORMATION_SCHEMA')
+		ORDER BY p.name`
+
+	return query, nil, nil
+}
+
+// TableGrants returns a query to list the permissions that apply to a table from sys.database_permissions:
+// the ones on the table itself, on its schema and on the database. Denied permissions are prefixed with DENY.
+func (m *mssql) TableGrants(table TableRef) (string, []any, error) {
+	query := `
+		SELECT
+			pr.name,
+			CASE pe.state WHEN 'D' THEN 'DENY ' ELSE '' END + pe.permission_name,
+			CASE pe.state WHEN 'W' THEN 'YES' ELSE 'NO' END,
+			CASE pe.class WHEN 1 THEN 'table' WHEN 3 THEN 'schema' ELSE 'database' END
+		FROM sys.database_permissions AS pe
+		JOIN sys.database_principals AS pr ON pr.principal_id = pe.grantee_principal_id
+		WHERE (pe.class = 1 AND pe.major_id = OBJECT_ID(@p1) AND pe.minor_id = 0)
+			OR (pe.class = 3 AND pe.major_id = SCHEMA_ID(@p2))
+			OR (pe.class = 0 AND pe.permission_name <> 'CONNECT')
+		ORDER BY 1, 2`
+
+	return query, []any{mssqlObject(table.Schema, table.Name), table.Schema}, nil
+}
+
+// fetchObjects method returns the functions, procedures, sequences, triggers
+// and user-defined types of a schema.
+func (m *mssql) fetchObjects(ctx context.Context, parentName, parentID string) ([]*DBNode, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.AtP)
+
+	objectsOfType := func(types ...string) sq.SelectBuilder {
+		return psql.Select("o.name").
+			From("sys.objects AS o").
+			Where(sq.Eq{"SCHEMA_NAME(o.schema_id)": parentName, "o.type": types}).
+			OrderBy("o.name")
+	}
+
+	queries := []struct {
+		kind  nodeKind
+		query sq.SelectBuilder
+	}{
+		// scalar, inline table-valued, table-valued and CLR functions.
+		{kind: functionKind, query: objectsOfType("FN", "IF", "TF", "FS", "FT")},
+		// SQL and CLR stored procedures.
+		{kind: procedureKind, query: objectsOfType("P", "PC")},
+		{
+			kind: sequenceKind,
+			query: psql.Select("name").
+				From("sys.sequences").
+				Where(sq.Eq{"SCHEMA_NAME(schema_id)": parentName}).
+				OrderBy("name"),
+		},
+		{kind: triggerKind, query: objectsOfType("TR")},
+		{
+			kind: typeKind,
+			query: psql.Select("name").
+				From("sys.types").
+				Where(sq.Eq{"SCHEMA_NAME(schema_id)": parentName, "is_user_defined": 1}).
+				OrderBy("name"),
+		},
+	}
+
+	objects := make([]*DBNode, 0)
+	for _, q := range queries {
+		query, args, err := q.query.ToSql()
+		if err != nil {
+			return nil, err
+		}
+
+		nodes, err := queryNodes(ctx, m.db, q.kind, parentName, parentID, query, args...)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, nodes...)
+	}
+
+	return objects, nil
+}
+
 // fetchSchemas method lists all the schemas of the current database.
 func (m *mssql) fetchSchemas(ctx context.Context, parentID string) ([]*DBNode, error) {
 	query, args, err := sq.Select("s.name").
@@ -311,3 +639,148 @@ func (m *mssql) fetchViews(ctx context.Context, parentName, parentID string) ([]
 
 	return views, nil
 }
+
+// TableDDL returns the CREATE TABLE statement of a table, reconstructed from the catalog,
+// since SQL Server has no native source of it.
+// It includes the defaults, the identity columns, the constraints, the indexes that don't back a constraint
+// and the descriptions of the table and its columns.
+func (m *mssql) TableDDL(ctx context.Context, table TableRef) (string, error) {
+	relation := mssqlObject(table.Schema, table.Name)
+	definition := tableDefinition{Name: relation}
+
+	// the descriptions are the extended properties named MS_Description.
+	description := func(column, text string) string {
+		statement := fmt.Sprintf(
+			"EXEC sp_addextendedproperty 'MS_Description', N%s, 'SCHEMA', N%s, 'TABLE', N%s",
+			quoteLiteral(text),
+			quoteLiteral(table.Schema),
+			quoteLiteral(table.Name),
+		)
+		if column != "" {
+			statement += ", 'COLUMN', N" + quoteLiteral(column)
+		}
+		return statement
+	}
+
+	columns, err := queryStrings(ctx, m.db, `
+		SELECT
+			c.name,
+			CASE
+				WHEN t.name IN ('varchar', 'char', 'varbinary', 'binary')
+					THEN t.name + '(' + IIF(c.max_length = -1, 'max', CAST(c.max_length AS varchar(10))) + ')'
+				WHEN t.name IN ('nvarchar', 'nchar')
+					THEN t.name + '(' + IIF(c.max_length = -1, 'max', CAST(c.max_length / 2 AS varchar(10))) + ')'
+				WHEN t.name IN ('decimal', 'numeric')
+					THEN t.name + '(' + CAST(c.precision AS varchar(10)) + ', ' + CAST(c.scale AS varchar(10)) + ')'
+				WHEN t.name IN ('datetime2', 'time', 'datetimeoffset')
+					THEN t.name + '(' + CAST(c.scale AS varchar(10)) + ')'
+				ELSE t.name
+			END,
+			IIF(c.is_nullable = 1, 'YES', 'NO'),
+			COALESCE(dc.definition, ''),
+			IIF(ic.column_id IS NULL, '', 'IDENTITY(' + CAST(ic.seed_value AS varchar(20)) + ', ' + CAST(ic.increment_value AS varchar(20)) + ')'),
+			COALESCE(CAST(ep.value AS nvarchar(4000)), '')
+		FROM sys.columns AS c
+		JOIN sys.types AS t ON t.user_type_id = c.user_type_id
+		LEFT JOIN sys.default_constraints AS dc ON dc.parent_object_id = c.object_id AND dc.parent_column_id = c.column_id
+		LEFT JOIN sys.identity_columns AS ic ON ic.object_id = c.object_id AND ic.column_id = c.column_id
+		LEFT JOIN sys.extended_properties AS ep
+			ON ep.class = 1 AND ep.major_id = c.object_id AND ep.minor_id = c.column_id AND ep.name = 'MS_Description'
+		WHERE c.object_id = OBJECT_ID(@p1)
+		ORDER BY c.column_id`, relation)
+	if err != nil {
+		return "", err
+	}
+
+	if len(columns) == 0 {
+		return "", fmt.Errorf("definition of table %s not found", table.Name)
+	}
+
+	for _, row := range columns {
+		definition.Columns = append(definition.Columns, columnDefinition{
+			Name:     mssqldialect.QuoteIdentifier(row[0]),
+			DataType: row[1],
+			Nullable: isYes(row[2]),
+			Default:  row[3],
+			Identity: row[4],
+		})
+
+		if row[5] != "" {
+			definition.Comments = append(definition.Comments, description(row[0], row[5]))
+		}
+	}
+
+	// the primary key first, then the unique, check and foreign key constraints.
+	constraints, err := queryStrings(ctx, m.db, `
+		SELECT name, definition FROM (
+			SELECT
+				QUOTENAME(kc.name) AS name,
+				IIF(kc.type = 'PK', 'PRIMARY KEY', 'UNIQUE') + ' (' +
+					STRING_AGG(QUOTENAME(col.name), ', ') WITHIN GROUP (ORDER BY ic.key_ordinal) + ')' AS definition,
+				IIF(kc.type = 'PK', 0, 1) AS position
+			FROM sys.key_constraints AS kc
+			JOIN sys.index_columns AS ic ON ic.object_id = kc.parent_object_id AND ic.index_id = kc.unique_index_id
+			JOIN sys.columns AS col ON col.object_id = ic.object_id AND col.column_id = ic.column_id
+			WHERE kc.parent_object_id = OBJECT_ID(@p1)
+			GROUP BY kc.name, kc.type
+			UNION ALL
+			SELECT QUOTENAME(name), 'CHECK ' + definition, 2
+			FROM sys.check_constraints
+			WHERE parent_object_id = OBJECT_ID(@p1)
+			UNION ALL
+			SELECT
+				QUOTENAME(fk.name),
+				'FOREIGN KEY (' + STRING_AGG(QUOTENAME(pc.name), ', ') WITHIN GROUP (ORDER BY fkc.constraint_column_id) +
+					') REFERENCES ' + QUOTENAME(SCHEMA_NAME(rt.schema_id)) + '.' + QUOTENAME(rt.name) +
+					' (' + STRING_AGG(QUOTENAME(rc.name), ', ') WITHIN GROUP (ORDER BY fkc.constraint_column_id) + ')',
+				3
+			FROM sys.foreign_keys AS fk
+			JOIN sys.foreign_key_columns AS fkc ON fkc.constraint_object_id = fk.object_id
+			JOIN sys.columns AS pc ON pc.object_id = fkc.parent_object_id AND pc.column_id = fkc.parent_column_id
+			JOIN sys.columns AS rc ON rc.object_id = fkc.referenced_object_id AND rc.column_id = fkc.referenced_column_id
+			JOIN sys.tables AS rt ON rt.object_id = fk.referenced_object_id
+			WHERE fk.parent_object_id = OBJECT_ID(@p1)
+			GROUP BY fk.name, rt.schema_id, rt.name
+		) AS constraints
+		ORDER BY position, name`, relation)
+	if err != nil {
+		return "", err
+	}
+
+	for _, row := range constraints {
+		definition.Constraints = append(definition.Constraints, constraintDefinition{Name: row[0], Definition: row[1]})
+	}
+
+	indexes, err := queryStrings(ctx, m.db, `
+		SELECT
+			'CREATE ' + IIF(i.is_unique = 1, 'UNIQUE ', '') + i.type_desc + ' INDEX ' + QUOTENAME(i.name) + ' ON ' + @p2 + ' (' +
+				STRING_AGG(QUOTENAME(c.name) + IIF(ic.is_descending_key = 1, ' DESC', ''), ', ') WITHIN GROUP (ORDER BY ic.key_ordinal) + ')'
+		FROM sys.indexes AS i
+		JOIN sys.index_columns AS ic ON ic.object_id = i.object_id AND ic.index_id = i.index_id AND ic.is_included_column = 0
+		JOIN sys.columns AS c ON c.object_id = ic.object_id AND c.column_id = ic.column_id
+		WHERE i.object_id = OBJECT_ID(@p1) AND i.is_primary_key = 0 AND i.is_unique_constraint = 0 AND i.type > 0
+		GROUP BY i.name, i.is_unique, i.type_desc
+		ORDER BY i.name`, relation, relation)
+	if err != nil {
+		return "", err
+	}
+
+	for _, row := range indexes {
+		definition.Indexes = append(definition.Indexes, row[0])
+	}
+
+	comment, err := queryStrings(ctx, m.db, `
+		SELECT CAST(value AS nvarchar(4000))
+		FROM sys.extended_properties
+		WHERE class = 1 AND major_id = OBJECT_ID(@p1) AND minor_id = 0 AND name = 'MS_Description'`, relation)
+	if err != nil {
+		return "", err
+	}
+
+	if len(comment) > 0 && comment[0][0] != "" {
+		tableComment := description("", comment[0][0])
+		definition.Comments = append([]string{tableComment}, definition.Comments...)
+	}
+
+	return definition.String(), nil
+}
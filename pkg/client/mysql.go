@@ -3,9 +3,12 @@ package client
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/jmoiron/sqlx"
+
+	mysqldialect "github.com/danvergara/dblab/pkg/drivers/mysql"
 )
 
 // mysql struct is in charge of perform all the mysql related queries.
@@ -29,7 +32,7 @@ func newMySQL(dbName string, db *sqlx.DB) *mysql {
 
 // TableStructure returns a query string to retrieve all the relevant information of a given table.
 func (m *mysql) TableStructure(table TableRef) (string, []any, error) {
-	query := fmt.Sprintf("DESCRIBE %s;", table.Name)
+	query := fmt.Sprintf("DESCRIBE %s;", mysqldialect.QuoteIdentifier(table.Name))
 	return query, nil, nil
 }
 
@@ -53,56 +56,39 @@ func (m *mysql) Constraints(table TableRef) (string, []any, error) {
 
 // Indexes returns a query to get all the indexes of a table.
 func (m *mysql) Indexes(table TableRef) (string, []any, error) {
-	query := fmt.Sprintf("SHOW INDEX FROM %s", table.Name)
+	query := fmt.Sprintf("SHOW INDEX FROM %s", mysqldialect.QuoteIdentifier(table.Name))
 	return query, nil, nil
 }
 
-// Catalog returns a the pointer to a DBNode instance,
-// which is the root of the current MySQL database graph.
-// It starts with the database itself and a list of tables a views.
+// Children returns the nodes right under the given node of the MySQL database graph.
+// The database holds the tables and views, followed by the functions, procedures and triggers.
 // MySQL topography:
 //
 //			     [Database]
 //			      /     \
 //			     v       v
-//	 			[Tables] 	[Views]
-func (m *mysql) Catalog(ctx context.Context) (*DBNode, error) {
-	rootID := fmt.Sprintf("db:%s", m.dbName)
-	root := &DBNode{ID: rootID, Name: m.dbName, Type: "database"}
-
-	queue := []*DBNode{root}
-
-	for len(queue) > 0 {
-		current := queue[0]
-		queue = queue[1:]
-
-		var children []*DBNode
-		var err error
-		switch current.Type {
-		case "database":
-			tables, err := m.fetchTables(ctx, current.Name, current.ID)
-			if err != nil {
-				return nil, err
-			}
-			children = append(children, tables...)
-
-			views, err := m.fetchViews(ctx, current.Name, current.ID)
-			if err != nil {
-				return nil, err
-			}
-			children = append(children, views...)
-		}
-		if err != nil {
-			return nil, err
-		}
+//	 			[Tables] 	[Views]	[Functions]	[Procedures]	[Triggers]
+func (m *mysql) Children(ctx context.Context, node *DBNode) ([]*DBNode, error) {
+	if node.Type != "database" {
+		return nil, nil
+	}
 
-		for _, child := range children {
-			current.Children = append(current.Children, child)
-			queue = append(queue, child)
-		}
+	tables, err := m.fetchTables(ctx, node.Name, node.ID)
+	if err != nil {
+		return nil, err
 	}
 
-	return root, nil
+	views, err := m.fetchViews(ctx, node.Name, node.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	objects, err := m.fetchObjects(ctx, node.Name, node.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.Concat(tables, views, objects), nil
 }
 
 // GetViewDefinition method returns the SQL definition of a given view.
@@ -125,6 +111,319 @@ func (m *mysql) GetViewDefinition(view ViewRef) (string, []any, error) {
 	return query, args, nil
 }
 
+// GetObjectDefinition method returns the source definition of a function, procedure or trigger.
+// The CREATE statement is rebuilt from information_schema,
+// since the SHOW CREATE statements return the definition in a different column for every object type.
+func (m *mysql) GetObjectDefinition(obj ObjectRef) (string, []any, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Question)
+
+	var query sq.SelectBuilder
+	switch obj.Type {
+	case functionKind.Type, procedureKind.Type:
+		query = psql.
+			Select(`CONCAT(
+				'CREATE ', r.ROUTINE_TYPE, ' ', r.ROUTINE_NAME, '(',
+				COALESCE((
+					SELECT GROUP_CONCAT(CONCAT_WS(' ', p.PARAMETER_MODE, p.PARAMETER_NAME, p.DTD_IDENTIFIER) ORDER BY p.ORDINAL_POSITION SEPARATOR ', ')
+					FROM information_schema.PARAMETERS AS p
+					WHERE p.SPECIFIC_SCHEMA = r.ROUTINE_SCHEMA AND p.SPECIFIC_NAME = r.SPECIFIC_NAME AND p.ORDINAL_POSITION > 0
+				), ''),
+				')',
+				IF(r.ROUTINE_TYPE = 'FUNCTION', CONCAT(' RETURNS ', r.DTD_IDENTIFIER), ''),
+				'\n',
+				r.ROUTINE_DEFINITION
+			) AS definition`).
+			From("information_schema.ROUTINES AS r").
+			Where(sq.Eq{
+				"r.ROUTINE_SCHEMA": m.dbName,
+				"r.ROUTINE_NAME":   obj.Name,
+			})
+	case triggerKind.Type:
+		query = psql.
+			Select(`CONCAT(
+				'CREATE TRIGGER ', TRIGGER_NAME, ' ', ACTION_TIMING, ' ', EVENT_MANIPULATION,
+				' ON ', EVENT_OBJECT_TABLE, ' FOR EACH ROW\n', ACTION_STATEMENT
+			) AS definition`).
+			From("information_schema.TRIGGERS").
+			Where(sq.Eq{
+				"TRIGGER_SCHEMA": m.dbName,
+				"TRIGGER_NAME":   obj.Name,
+			})
+	default:
+		return "", nil, fmt.Errorf("%s objects not supported", obj.Type)
+	}
+
+	return query.ToSql()
+}
+
+// CatalogColumns returns a query to list the columns of a table, flagging nullable, primary and foreign key columns.
+func (m *mysql) CatalogColumns(table TableRef) (string, []any, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Question)
+	return psql.Select(
+		"c.COLUMN_NAME",
+		"c.COLUMN_TYPE",
+		"c.IS_NULLABLE",
+		"IF(c.COLUMN_KEY = 'PRI', 'YES', 'NO')",
+		`IF(EXISTS (
+			SELECT 1 FROM information_schema.KEY_COLUMN_USAGE AS k
+			WHERE k.TABLE_SCHEMA = c.TABLE_SCHEMA
+				AND k.TABLE_NAME = c.TABLE_NAME
+				AND k.COLUMN_NAME = c.COLUMN_NAME
+				AND k.REFERENCED_TABLE_NAME IS NOT NULL
+		), 'YES', 'NO')`,
+	).
+		From("information_schema.COLUMNS AS c").
+		Where(sq.Eq{
+			"c.TABLE_SCHEMA": m.dbName,
+			"c.TABLE_NAME":   table.Name,
+		}).
+		OrderBy("c.ORDINAL_POSITION").
+		ToSql()
+}
+
+// CatalogIndexes returns a query to list the indexes of a table, whether they are unique and their columns.
+// The expressions of the functional indexes have no column name, they're left empty.
+func (m *mysql) CatalogIndexes(table TableRef) (string, []any, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Question)
+	return psql.Select(
+		"INDEX_NAME",
+		"IF(MIN(NON_UNIQUE) = 0, 'YES', 'NO')",
+		"GROUP_CONCAT(COALESCE(COLUMN_NAME, '') ORDER BY SEQ_IN_INDEX SEPARATOR ',')",
+	).
+		From("information_schema.STATISTICS").
+		Where(sq.Eq{
+			"TABLE_SCHEMA": m.dbName,
+			"TABLE_NAME":   table.Name,
+		}).
+		GroupBy("INDEX_NAME").
+		OrderBy("INDEX_NAME").
+		ToSql()
+}
+
+// CatalogForeignKeys returns a query to list the columns of the foreign keys of the current database,
+// along with the columns they reference.
+func (m *mysql) CatalogForeignKeys(schema string) (string, []any, error) {
+	if schema == "" {
+		schema = m.dbName
+	}
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Question)
+	return psql.Select(
+		"CONSTRAINT_NAME",
+		"TABLE_NAME",
+		"COLUMN_NAME",
+		"REFERENCED_TABLE_SCHEMA",
+		"REFERENCED_TABLE_NAME",
+		"REFERENCED_COLUMN_NAME",
+	).
+		From("information_schema.KEY_COLUMN_USAGE").
+		Where(sq.Eq{"TABLE_SCHEMA": schema}).
+		Where("REFERENCED_TABLE_NAME IS NOT NULL").
+		OrderBy("TABLE_NAME", "CONSTRAINT_NAME", "ORDINAL_POSITION").
+		ToSql()
+}
+
+// CatalogRowCounts returns a query to list the estimated row count of the tables of the current database.
+// The estimates of InnoDB tables may be off by a large margin.
+func (m *mysql) CatalogRowCounts(schema string) (string, []any, error) {
+	if schema == "" {
+		schema = m.dbName
+	}
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Question)
+	return psql.Select(
+		"TABLE_NAME",
+		"TABLE_ROWS",
+	).
+		From("information_schema.TABLES").
+		Where(sq.Eq{
+			"TABLE_SCHEMA": schema,
+			"TABLE_TYPE":   "BASE TABLE",
+		}).
+		Where("TABLE_ROWS IS NOT NULL").
+		OrderBy("TABLE_NAME").
+		ToSql()
+}
+
+// TableStats returns the statistics of a table: its estimated row count, the size of its data and indexes,
+// the free space, the next auto-increment value, the engine and the times it was created, updated and checked.
+func (m *mysql) TableStats(ctx context.Context, table TableRef) ([]Stat, error) {
+	return queryStats(ctx, m.db, `
+		SELECT
+			TABLE_ROWS AS estimated_rows,
+			DATA_LENGTH + INDEX_LENGTH AS total_size_bytes,
+			DATA_LENGTH AS data_size_bytes,
+			INDEX_LENGTH AS indexes_size_bytes,
+			DATA_FREE AS free_space_bytes,
+			AVG_ROW_LENGTH AS average_row_bytes,
+			AUTO_INCREMENT AS auto_increment,
+			ENGINE AS engine,
+			CREATE_TIME AS created,
+			UPDATE_TIME AS last_update,
+			CHECK_TIME AS last_check
+		FROM information_schema.TABLES
+		WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ?`, m.dbName, table.Name)
+}
+
+// Activity returns a query to list the threads of the server, as SHOW FULL PROCESSLIST does,
+// but from information_schema.PROCESSLIST, so they can be filtered and sorted.
+func (m *mysql) Activity() (string, []any, error) {
+	query := `
+		SELECT
+			ID,
+			COALESCE(USER, ''),
+			COALESCE(DB, ''),
+			COALESCE(COMMAND, ''),
+			TIME,
+			COALESCE(STATE, ''),
+			COALESCE(INFO, '')
+		FROM information_schema.PROCESSLIST
+		WHERE ID <> CONNECTION_ID() AND COMMAND <> 'Daemon'
+		ORDER BY TIME DESC`
+
+	return query, nil, nil
+}
+
+// CancelSession returns a statement to kill the query running on a thread, leaving the connection open.
+// KILL takes no placeholders, so the thread ID is written in.
+func (m *mysql) CancelSession(id string) (string, []any, error) {
+	thread, err := sessionNumber(id)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return fmt.Sprintf("KILL QUERY %d", thread), nil, nil
+}
+
+// TerminateSession returns a statement to kill the connection of a thread.
+func (m *mysql) TerminateSession(id string) (string, []any, error) {
+	thread, err := sessionNumber(id)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return fmt.Sprintf("KILL %d", thread), nil, nil
+}
+
+// Locks returns a query to list the InnoDB locks of the server from performance_schema.data_locks,
+// along with the sessions blocking each one, from performance_schema.data_lock_waits. It takes MySQL 8.0 or later.
+func (m *mysql) Locks() (string, []any, error) {
+	query := `
+		SELECT
+			t.PROCESSLIST_ID,
+			COALESCE((
+				SELECT GROUP_CONCAT(DISTINCT bt.PROCESSLIST_ID)
+				FROM performance_schema.data_lock_waits w
+				JOIN performance_schema.threads bt ON bt.THREAD_ID = w.BLOCKING_THREAD_ID
+				WHERE w.REQUESTING_ENGINE_LOCK_ID = l.ENGINE_LOCK_ID
+			), ''),
+			COALESCE(t.PROCESSLIST_USER, ''),
+			COALESCE(t.PROCESSLIST_STATE, ''),
+			l.LOCK_MODE,
+			CASE l.LOCK_STATUS WHEN 'GRANTED' THEN 'YES' ELSE 'NO' END,
+			CONCAT(l.OBJECT_SCHEMA, '.', l.OBJECT_NAME, COALESCE(CONCAT(' (', l.INDEX_NAME, ')'), '')),
+			COALESCE(t.PROCESSLIST_TIME, 0),
+			COALESCE(t.PROCESSLIST_INFO, '')
+		FROM performance_schema.data_locks l
+		JOIN performance_schema.threads t ON t.THREAD_ID = l.THREAD_ID
+		WHERE t.PROCESSLIST_ID <> CONNECTION_ID()
+		ORDER BY t.PROCESSLIST_ID, l.LOCK_STATUS`
+
+	return query, nil, nil
+}
+
+// Roles returns a query to list the accounts and roles of the server from mysql.user, along with their attributes
+// and the roles granted to them, from mysql.role_edges. It takes the SELECT privilege on the mysql schema.
+func (m *mysql) Roles() (string, []any, error) {
+	query := `
+		SELECT
+			CONCAT(u.User, '@', u.Host),
+			CONCAT_WS(', ',
+				IF(u.account_locked = 'Y', 'locked', NULL),
+				IF(u.password_expired = 'Y', 'password expired', NULL),
+				IF(u.Super_priv = 'Y', 'super', NULL),
+				IF(u.Grant_priv = 'Y', 'grant option', NULL),
+				IF(u.max_user_connections > 0, CONCAT('connection limit ', u.max_user_connections), NULL)
+			),
+			COALESCE((
+				SELECT GROUP_CONCAT(CONCAT(e.FROM_USER, '@', e.FROM_HOST) ORDER BY e.FROM_USER)
+				FROM mysql.role_edges e
+				WHERE e.TO_USER = u.User AND e.TO_HOST = u.Host
+			), '')
+		FROM mysql.user u
+		ORDER BY u.User, u.Host`
+
+	return query, nil, nil
+}
+
+// TableGrants returns a query to list the privileges that apply to a table, as SHOW GRANTS reports them:
+// the ones on the table itself, on its database and the global ones.
+func (m *mysql) TableGrants(table TableRef) (string, []any, error) {
+	query := `
+		SELECT GRANTEE, PRIVILEGE_TYPE, IS_GRANTABLE, 'table'
+		FROM information_schema.TABLE_PRIVILEGES
+		WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ?
+		UNION ALL
+		SELECT GRANTEE, PRIVILEGE_TYPE, IS_GRANTABLE, 'database'
+		FROM information_schema.SCHEMA_PRIVILEGES
+		WHERE TABLE_SCHEMA = ?
+		UNION ALL
+		SELECT GRANTEE, PRIVILEGE_TYPE, IS_GRANTABLE, 'global'
+		FROM information_schema.USER_PRIVILEGES
+		WHERE PRIVILEGE_TYPE IN ('SELECT', 'INSERT', 'UPDATE', 'DELETE', 'ALTER', 'DROP', 'INDEX', 'TRIGGER', 'REFERENCES')
+		ORDER BY 1, 2`
+
+	return query, []any{m.dbName, table.Name, m.dbName}, nil
+}
+
+// fetchObjects method lists the functions, procedures and triggers of the current database.
+func (m *mysql) fetchObjects(ctx context.Context, parentName, parentID string) ([]*DBNode, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Question)
+
+	queries := []struct {
+		kind  nodeKind
+		query sq.SelectBuilder
+	}{
+		{
+			kind: functionKind,
+			query: psql.Select("ROUTINE_NAME").
+				From("information_schema.ROUTINES").
+				Where(sq.Eq{"ROUTINE_SCHEMA": m.dbName, "ROUTINE_TYPE": "FUNCTION"}).
+				OrderBy("ROUTINE_NAME"),
+		},
+		{
+			kind: procedureKind,
+			query: psql.Select("ROUTINE_NAME").
+				From("information_schema.ROUTINES").
+				Where(sq.Eq{"ROUTINE_SCHEMA": m.dbName, "ROUTINE_TYPE": "PROCEDURE"}).
+				OrderBy("ROUTINE_NAME"),
+		},
+		{
+			kind: triggerKind,
+			query: psql.Select("TRIGGER_NAME").
+				From("information_schema.TRIGGERS").
+				Where(sq.Eq{"TRIGGER_SCHEMA": m.dbName}).
+				OrderBy("TRIGGER_NAME"),
+		},
+	}
+
+	objects := make([]*DBNode, 0)
+	for _, q := range queries {
+		query, args, err := q.query.ToSql()
+		if err != nil {
+			return nil, err
+		}
+
+		nodes, err := queryNodes(ctx, m.db, q.kind, parentName, parentID, query, args...)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, nodes...)
+	}
+
+	return objects, nil
+}
+
 // fetchTables method lists all the tables of the current database.
 func (m *mysql) fetchTables(ctx context.Context, parentName, parentID string) ([]*DBNode, error) {
 	query := "SHOW TABLES;"
@@ -200,3 +499,22 @@ func (m *mysql) fetchViews(ctx context.Context, parentName, parentID string) ([]
 
 	return views, nil
 }
+
+// TableDDL returns the CREATE TABLE statement of a table, as given by SHOW CREATE TABLE.
+func (m *mysql) TableDDL(ctx context.Context, table TableRef) (string, error) {
+	schema := table.Schema
+	if schema == "" {
+		schema = m.dbName
+	}
+
+	rows, err := queryStrings(ctx, m.db, fmt.Sprintf("SHOW CREATE TABLE %s.%s", mysqldialect.QuoteIdentifier(schema), mysqldialect.QuoteIdentifier(table.Name)))
+	if err != nil {
+		return "", err
+	}
+
+	if len(rows) == 0 || len(rows[0]) < 2 {
+		return "", fmt.Errorf("definition of table %s not found", table.Name)
+	}
+
+	return joinStatements([]string{rows[0][1]}), nil
+}
@@ -3,11 +3,14 @@ package client
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/jmoiron/sqlx"
 	_ "github.com/sijms/go-ora/v2"
+
+	oracledialect "github.com/danvergara/dblab/pkg/drivers/oracle"
 )
 
 // oracle struct is in charge of perform all the oracle related queries.
@@ -26,10 +29,11 @@ func newOracle(dbName, schema string, db *sqlx.DB) *oracle {
 	// where the OWNER is equal to the schema/user the dblab user has access to.
 	// Otherwise, the client will query against the USER_* tables,
 	// meaning it only cares about what the user has direct access to.
+	// the schema is typed by the user, the names read from the catalog are used as they are.
 	o := oracle{
 		dbName: dbName,
 		db:     db,
-		schema: schema,
+		schema: oracledialect.NormalizeName(schema),
 	}
 
 	return &o
@@ -41,12 +45,12 @@ func (o *oracle) TableStructure(table TableRef) (string, []any, error) {
 
 	query = sq.Select("*").
 		From("USER_TAB_COLUMNS").
-		Where(sq.Eq{"TABLE_NAME": strings.ToUpper(table.Name)})
+		Where(sq.Eq{"TABLE_NAME": table.Name})
 
 	if table.Schema != "" {
 		query = sq.Select("*").
 			From("ALL_TAB_COLUMNS").
-			Where(sq.Eq{"TABLE_NAME": strings.ToUpper(table.Name), "OWNER": strings.ToUpper(table.Schema)})
+			Where(sq.Eq{"TABLE_NAME": table.Name, "OWNER": table.Schema})
 	}
 
 	sql, args, err := query.OrderBy("1").PlaceholderFormat(sq.Colon).ToSql()
@@ -66,7 +70,7 @@ func (o *oracle) Constraints(table TableRef) (string, []any, error) {
 		`CONSTRAINT_TYPE`,
 	).
 		From("USER_CONSTRAINTS").
-		Where(sq.Eq{"TABLE_NAME": strings.ToUpper(table.Name)})
+		Where(sq.Eq{"TABLE_NAME": table.Name})
 
 	if table.Schema != "" {
 		query = sq.Select(
@@ -74,7 +78,7 @@ func (o *oracle) Constraints(table TableRef) (string, []any, error) {
 			`CONSTRAINT_TYPE`,
 		).
 			From("ALL_CONSTRAINTS").
-			Where(sq.Eq{"TABLE_NAME": strings.ToUpper(table.Name), "OWNER": strings.ToUpper(table.Schema)})
+			Where(sq.Eq{"TABLE_NAME": table.Name, "OWNER": table.Schema})
 	}
 
 	sql, args, err := query.PlaceholderFormat(sq.Colon).ToSql()
@@ -91,12 +95,12 @@ func (o *oracle) Indexes(table TableRef) (string, []any, error) {
 
 	query = sq.Select("*").
 		From("USER_INDEXES").
-		Where(sq.Eq{"TABLE_NAME": strings.ToUpper(table.Name)})
+		Where(sq.Eq{"TABLE_NAME": table.Name})
 
 	if table.Schema != "" {
 		query = sq.Select("*").
 			From("ALL_INDEXES").
-			Where(sq.Eq{"TABLE_NAME": strings.ToUpper(table.Name), "OWNER": strings.ToUpper(table.Schema)})
+			Where(sq.Eq{"TABLE_NAME": table.Name, "OWNER": table.Schema})
 	}
 
 	sql, args, err := query.PlaceholderFormat(sq.Colon).ToSql()
@@ -107,10 +111,10 @@ func (o *oracle) Indexes(table TableRef) (string, []any, error) {
 	return sql, args, nil
 }
 
-// Catalog returns a the pointer to a DBNode instance,
-// which is the root of the current Oracle database graph.
-// It starts with the database itself,
-// then the schemas and the correspondent lists of tables and views.
+// Children returns the nodes right under the given node of the Oracle database graph.
+// The database holds the schemas, or only the one the client is scoped to,
+// and the schemas hold the correspondent lists of tables, views,
+// functions, procedures, sequences, triggers and user-defined types.
 // Oracle topography:
 //
 //					 [Database]
@@ -119,54 +123,40 @@ func (o *oracle) Indexes(table TableRef) (string, []any, error) {
 //			     [Schemas]
 //			      /     \
 //			     v       v
-//	 		 [Tables] 	[Views]
-func (o *oracle) Catalog(ctx context.Context) (*DBNode, error) {
-	rootID := fmt.Sprintf("db:%s", o.dbName)
-	root := &DBNode{ID: rootID, Name: o.dbName, Type: "database"}
-	queue := []*DBNode{root}
-
-	for len(queue) > 0 {
-		current := queue[0]
-		queue = queue[1:]
-
-		var children []*DBNode
-		var err error
-		switch current.Type {
-		case "database":
-			if o.schema != "" {
-				children = append(children, &DBNode{
-					ID:       fmt.Sprintf("%s.s:%s", rootID, o.schema),
-					Name:     o.schema,
-					Type:     "schema",
-					ParentID: rootID,
-				})
-			} else {
-				children, err = o.fetchSchemas(ctx, current.Name)
-			}
-		case "schema":
-			tables, err := o.fetchTables(ctx, current.Name, current.ID)
-			if err != nil {
-				return nil, err
-			}
-			children = append(children, tables...)
-
-			views, err := o.fetchViews(ctx, current.Name, current.ID)
-			if err != nil {
-				return nil, err
-			}
-			children = append(children, views...)
+//	 		 [Tables] 	[Views]	[Functions]	[Procedures]	[Sequences]	[Triggers]	[Types]
+func (o *oracle) Children(ctx context.Context, node *DBNode) ([]*DBNode, error) {
+	switch node.Type {
+	case "database":
+		if o.schema != "" {
+			return []*DBNode{{
+				ID:       fmt.Sprintf("%s.s:%s", node.ID, o.schema),
+				Name:     o.schema,
+				Type:     "schema",
+				ParentID: node.ID,
+			}}, nil
+		}
+
+		return o.fetchSchemas(ctx, node.ID)
+	case "schema":
+		tables, err := o.fetchTables(ctx, node.Name, node.ID)
+		if err != nil {
+			return nil, err
 		}
+
+		views, err := o.fetchViews(ctx, node.Name, node.ID)
 		if err != nil {
 			return nil, err
 		}
 
-		for _, child := range children {
-			current.Children = append(current.Children, child)
-			queue = append(queue, child)
+		objects, err := o.fetchObjects(ctx, node.Name, node.ID)
+		if err != nil {
+			return nil, err
 		}
+
+		return slices.Concat(tables, views, objects), nil
 	}
 
-	return root, nil
+	return nil, nil
 }
 
 // GetViewDefinition method returns the SQL definition of a given view.
@@ -176,8 +166,8 @@ func (o *oracle) GetViewDefinition(view ViewRef) (string, []any, error) {
 		Select("TEXT AS view_definition").
 		From("ALL_VIEWS").
 		Where(sq.Eq{
-			"OWNER":     strings.ToUpper(view.Schema),
-			"VIEW_NAME": strings.ToUpper(view.Name),
+			"OWNER":     view.Schema,
+			"VIEW_NAME": view.Name,
 		}).
 		ToSql()
 
@@ -188,6 +178,332 @@ func (o *oracle) GetViewDefinition(view ViewRef) (string, []any, error) {
 	return query, args, nil
 }
 
+// GetObjectDefinition method returns the source definition of a function, procedure, sequence, trigger or type.
+// The source code of PL/SQL objects is stored one line per row in ALL_SOURCE.
+func (o *oracle) GetObjectDefinition(obj ObjectRef) (string, []any, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Colon)
+	owner := obj.Schema
+	name := obj.Name
+
+	var query sq.SelectBuilder
+	switch obj.Type {
+	case functionKind.Type, procedureKind.Type, triggerKind.Type:
+		query = psql.
+			Select("TEXT AS definition").
+			From("ALL_SOURCE").
+			Where(sq.Eq{
+				"OWNER": owner,
+				"NAME":  name,
+				"TYPE":  strings.ToUpper(obj.Type),
+			}).
+			OrderBy("LINE")
+	case typeKind.Type:
+		// the type specification goes first, followed by its body, if any.
+		query = psql.
+			Select("TEXT AS definition").
+			From("ALL_SOURCE").
+			Where(sq.Eq{
+				"OWNER": owner,
+				"NAME":  name,
+				"TYPE":  []string{"TYPE", "TYPE BODY"},
+			}).
+			OrderBy("TYPE", "LINE")
+	case sequenceKind.Type:
+		query = psql.
+			Select(`'CREATE SEQUENCE ' || SEQUENCE_OWNER || '.' || SEQUENCE_NAME ||
+				' START WITH ' || LAST_NUMBER ||
+				' INCREMENT BY ' || INCREMENT_BY ||
+				' MINVALUE ' || MIN_VALUE ||
+				' MAXVALUE ' || MAX_VALUE ||
+				CASE WHEN CACHE_SIZE > 0 THEN ' CACHE ' || CACHE_SIZE ELSE ' NOCACHE' END ||
+				CASE WHEN CYCLE_FLAG = 'Y' THEN ' CYCLE' ELSE ' NOCYCLE' END || ';' AS definition`).
+			From("ALL_SEQUENCES").
+			Where(sq.Eq{
+				"SEQUENCE_OWNER": owner,
+				"SEQUENCE_NAME":  name,
+			})
+	default:
+		return "", nil, fmt.Errorf("%s objects not supported", obj.Type)
+	}
+
+	return query.ToSql()
+}
+
+// CatalogColumns returns a query to list the columns of a table, flagging nullable, primary and foreign key columns.
+func (o *oracle) CatalogColumns(table TableRef) (string, []any, error) {
+	keyFlag := func(constraintType string) string {
+		return fmt.Sprintf(`CASE WHEN EXISTS (
+			SELECT 1 FROM ALL_CONS_COLUMNS cc
+			JOIN ALL_CONSTRAINTS k ON k.OWNER = cc.OWNER AND k.CONSTRAINT_NAME = cc.CONSTRAINT_NAME
+			WHERE k.CONSTRAINT_TYPE = '%s'
+				AND cc.OWNER = c.OWNER
+				AND cc.TABLE_NAME = c.TABLE_NAME
+				AND cc.COLUMN_NAME = c.COLUMN_NAME
+		) THEN 'YES' ELSE 'NO' END`, constraintType)
+	}
+
+	return sq.Select(
+		"c.COLUMN_NAME",
+		"c.DATA_TYPE",
+		"CASE c.NULLABLE WHEN 'Y' THEN 'YES' ELSE 'NO' END",
+		// P is for primary keys and R for referential integrity, i.e. foreign keys.
+		keyFlag("P"),
+		keyFlag("R"),
+	).
+		From("ALL_TAB_COLUMNS c").
+		Where(sq.Eq{
+			"c.OWNER":      table.Schema,
+			"c.TABLE_NAME": table.Name,
+		}).
+		OrderBy("c.COLUMN_ID").
+		PlaceholderFormat(sq.Colon).
+		ToSql()
+}
+
+// CatalogIndexes returns a query to list the indexes of a table, whether they are unique and their columns.
+func (o *oracle) CatalogIndexes(table TableRef) (string, []any, error) {
+	return sq.Select(
+		"i.INDEX_NAME",
+		"CASE i.UNIQUENESS WHEN 'UNIQUE' THEN 'YES' ELSE 'NO' END",
+		`(SELECT LISTAGG(ic.COLUMN_NAME, ',') WITHIN GROUP (ORDER BY ic.COLUMN_POSITION)
+			FROM ALL_IND_COLUMNS ic
+			WHERE ic.INDEX_OWNER = i.OWNER AND ic.INDEX_NAME = i.INDEX_NAME)`,
+	).
+		From("ALL_INDEXES i").
+		Where(sq.Eq{
+			"i.TABLE_OWNER": table.Schema,
+			"i.TABLE_NAME":  table.Name,
+		}).
+		OrderBy("i.INDEX_NAME").
+		PlaceholderFormat(sq.Colon).
+		ToSql()
+}
+
+// CatalogForeignKeys returns a query to list the columns of the foreign keys of a schema,
+// along with the columns they reference, matched by position.
+func (o *oracle) CatalogForeignKeys(schema string) (string, []any, error) {
+	return sq.Select(
+		"c.CONSTRAINT_NAME",
+		"c.TABLE_NAME",
+		"cc.COLUMN_NAME",
+		"rc.OWNER",
+		"rc.TABLE_NAME",
+		"rc.COLUMN_NAME",
+	).
+		From("ALL_CONSTRAINTS c").
+		Join("ALL_CONS_COLUMNS cc ON cc.OWNER = c.OWNER AND cc.CONSTRAINT_NAME = c.CONSTRAINT_NAME").
+		Join("ALL_CONS_COLUMNS rc ON rc.OWNER = c.R_OWNER AND rc.CONSTRAINT_NAME = c.R_CONSTRAINT_NAME AND rc.POSITION = cc.POSITION").
+		Where(sq.Eq{
+			// R is for referential integrity, i.e. foreign keys.
+			"c.CONSTRAINT_TYPE": "R",
+			"c.OWNER":           schema,
+		}).
+		OrderBy("c.TABLE_NAME", "c.CONSTRAINT_NAME", "cc.POSITION").
+		PlaceholderFormat(sq.Colon).
+		ToSql()
+}
+
+// CatalogRowCounts returns a query to list the row count of the tables of a schema,
+// as of the last time their statistics were gathered.
+func (o *oracle) CatalogRowCounts(schema string) (string, []any, error) {
+	return sq.Select(
+		"TABLE_NAME",
+		"NUM_ROWS",
+	).
+		From("ALL_TABLES").
+		Where(sq.Eq{"OWNER": schema}).
+		Where("NUM_ROWS IS NOT NULL").
+		OrderBy("TABLE_NAME").
+		PlaceholderFormat(sq.Colon).
+		ToSql()
+}
+
+// TableStats returns the statistics of a table: its row count, blocks and average row length as of the last time
+// its statistics were gathered, and the size of the segments of its data, indexes and LOBs.
+// The sizes are only visible for the tables of the current user, the others show no size.
+func (o *oracle) TableStats(ctx context.Context, table TableRef) ([]Stat, error) {
+	return queryStats(ctx, o.db, `
+		SELECT
+			t.NUM_ROWS AS estimated_rows,
+			t.BLOCKS AS blocks,
+			t.AVG_ROW_LEN AS average_row_bytes,
+			t.LAST_ANALYZED AS last_analyzed,
+			(SELECT SUM(s.BYTES) FROM USER_SEGMENTS s
+				WHERE s.SEGMENT_NAME = t.TABLE_NAME AND t.OWNER = USER) AS table_size_bytes,
+			(SELECT SUM(s.BYTES) FROM USER_SEGMENTS s JOIN USER_INDEXES i ON i.INDEX_NAME = s.SEGMENT_NAME
+				WHERE i.TABLE_NAME = t.TABLE_NAME AND t.OWNER = USER) AS indexes_size_bytes,
+			(SELECT SUM(s.BYTES) FROM USER_SEGMENTS s JOIN USER_LOBS l ON l.SEGMENT_NAME = s.SEGMENT_NAME
+				WHERE l.TABLE_NAME = t.TABLE_NAME AND t.OWNER = USER) AS lobs_size_bytes
+		FROM ALL_TABLES t
+		WHERE t.OWNER = :1 AND t.TABLE_NAME = :2`, table.Schema, table.Name)
+}
+
+// Activity returns a query to list the user sessions of the instance from v$session, identified by their sid and serial#,
+// along with the text of their current SQL. It takes the SELECT privilege on v$session and v$sql.
+func (o *oracle) Activity() (string, []any, error) {
+	query := `
+		SELECT
+			s.SID || ',' || s.SERIAL#,
+			s.USERNAME,
+			s.SCHEMANAME,
+			s.STATUS,
+			s.LAST_CALL_ET,
+			CASE WHEN s.WAIT_CLASS <> 'Idle' THEN s.EVENT END,
+			q.SQL_TEXT
+		FROM v$session s
+		LEFT JOIN v$sql q ON q.SQL_ID = s.SQL_ID AND q.CHILD_NUMBER = s.SQL_CHILD_NUMBER
+		WHERE s.TYPE = 'USER' AND s.SID <> SYS_CONTEXT('USERENV', 'SID')
+		ORDER BY s.LAST_CALL_ET DESC`
+
+	return query, nil, nil
+}
+
+// CancelSession returns a statement to cancel the SQL running on a session, given its sid and serial#.
+// ALTER SYSTEM takes no placeholders, so the session is written in.
+func (o *oracle) CancelSession(id string) (string, []any, error) {
+	session, err := oracleSession(id)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return fmt.Sprintf("ALTER SYSTEM CANCEL SQL '%s'", session), nil, nil
+}
+
+// TerminateSession returns a statement to kill a session, given its sid and serial#.
+func (o *oracle) TerminateSession(id string) (string, []any, error) {
+	session, err := oracleSession(id)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return fmt.Sprintf("ALTER SYSTEM KILL SESSION '%s' IMMEDIATE", session), nil, nil
+}
+
+// Locks returns a query to list the locks of the instance from v$lock, joined with v$session,
+// along with the session blocking each one. The sessions are identified by their sid and serial#, as in the activity.
+// Only the table locks name their table, the other ones show their type, e.g. TX for the row locks.
+func (o *oracle) Locks() (string, []any, error) {
+	query := `
+		SELECT
+			s.SID || ',' || s.SERIAL#,
+			(SELECT b.SID || ',' || b.SERIAL# FROM v$session b WHERE b.SID = s.BLOCKING_SESSION),
+			s.USERNAME,
+			s.STATUS,
+			DECODE(GREATEST(l.LMODE, l.REQUEST), 1, 'Null', 2, 'Row-S (SS)', 3, 'Row-X (SX)', 4, 'Share (S)', 5, 'S/Row-X (SSX)', 6, 'Exclusive (X)', 'None'),
+			CASE WHEN l.REQUEST = 0 THEN 'YES' ELSE 'NO' END,
+			CASE WHEN l.TYPE = 'TM' THEN (SELECT ob.OWNER || '.' || ob.OBJECT_NAME FROM ALL_OBJECTS ob WHERE ob.OBJECT_ID = l.ID1) ELSE l.TYPE END,
+			l.CTIME,
+			q.SQL_TEXT
+		FROM v$lock l
+		JOIN v$session s ON s.SID = l.SID
+		LEFT JOIN v$sql q ON q.SQL_ID = s.SQL_ID AND q.CHILD_NUMBER = s.SQL_CHILD_NUMBER
+		WHERE s.TYPE = 'USER' AND l.TYPE IN ('TM', 'TX', 'UL') AND s.SID <> SYS_CONTEXT('USERENV', 'SID')
+		ORDER BY s.SID, l.REQUEST`
+
+	return query, nil, nil
+}
+
+// oracleSession function checks the ID of an Oracle session is made of its sid and serial#, e.g. 123,4567.
+func oracleSession(id string) (string, error) {
+	sid, serial, ok := strings.Cut(strings.TrimSpace(id), ",")
+	if !ok {
+		return "", fmt.Errorf("invalid session ID %q, expected sid,serial#", id)
+	}
+
+	s, err := sessionNumber(sid)
+	if err != nil {
+		return "", fmt.Errorf("invalid session ID %q, expected sid,serial#", id)
+	}
+
+	n, err := sessionNumber(serial)
+	if err != nil {
+		return "", fmt.Errorf("invalid session ID %q, expected sid,serial#", id)
+	}
+
+	return fmt.Sprintf("%d,%d", s, n), nil
+}
+
+// Roles returns a query to list the users and roles of the database, but the ones maintained by Oracle,
+// along with the status of the users and the roles granted to them. It takes the SELECT privilege on the DBA views.
+func (o *oracle) Roles() (string, []any, error) {
+	query := `
+		SELECT
+			u.USERNAME,
+			'user, ' || LOWER(u.ACCOUNT_STATUS),
+			(SELECT LISTAGG(p.GRANTED_ROLE, ',') WITHIN GROUP (ORDER BY p.GRANTED_ROLE)
+				FROM DBA_ROLE_PRIVS p WHERE p.GRANTEE = u.USERNAME)
+		FROM DBA_USERS u
+		WHERE u.ORACLE_MAINTAINED = 'N'
+		UNION ALL
+		SELECT
+			r.ROLE,
+			'role',
+			(SELECT LISTAGG(p.GRANTED_ROLE, ',') WITHIN GROUP (ORDER BY p.GRANTED_ROLE)
+				FROM DBA_ROLE_PRIVS p WHERE p.GRANTEE = r.ROLE)
+		FROM DBA_ROLES r
+		WHERE r.ORACLE_MAINTAINED = 'N'
+		ORDER BY 1`
+
+	return query, nil, nil
+}
+
+// TableGrants returns a query to list the privileges granted on a table from DBA_TAB_PRIVS.
+func (o *oracle) TableGrants(table TableRef) (string, []any, error) {
+	return sq.Select(
+		"GRANTEE",
+		"PRIVILEGE",
+		"GRANTABLE",
+		"'table'",
+	).
+		From("DBA_TAB_PRIVS").
+		Where(sq.Eq{
+			"OWNER":      table.Schema,
+			"TABLE_NAME": table.Name,
+		}).
+		OrderBy("GRANTEE", "PRIVILEGE").
+		PlaceholderFormat(sq.Colon).
+		ToSql()
+}
+
+// fetchObjects method returns the functions, procedures, sequences, triggers
+// and user-defined types of a schema.
+func (o *oracle) fetchObjects(ctx context.Context, parentName, parentID string) ([]*DBNode, error) {
+	kinds := []struct {
+		kind       nodeKind
+		objectType string
+	}{
+		{kind: functionKind, objectType: "FUNCTION"},
+		{kind: procedureKind, objectType: "PROCEDURE"},
+		{kind: sequenceKind, objectType: "SEQUENCE"},
+		{kind: triggerKind, objectType: "TRIGGER"},
+		{kind: typeKind, objectType: "TYPE"},
+	}
+
+	objects := make([]*DBNode, 0)
+	for _, k := range kinds {
+		query, args, err := sq.Select("OBJECT_NAME").
+			From("ALL_OBJECTS").
+			Where(sq.Eq{
+				"OWNER":       parentName,
+				"OBJECT_TYPE": k.objectType,
+			}).
+			OrderBy("OBJECT_NAME ASC").
+			PlaceholderFormat(sq.Colon).
+			ToSql()
+		if err != nil {
+			return nil, err
+		}
+
+		nodes, err := queryNodes(ctx, o.db, k.kind, parentName, parentID, query, args...)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, nodes...)
+	}
+
+	return objects, nil
+}
+
 // fetchSchemas method lists all the schemas of the current database.
 func (o *oracle) fetchSchemas(ctx context.Context, parentID string) ([]*DBNode, error) {
 	query := `
@@ -227,7 +543,7 @@ func (o *oracle) fetchSchemas(ctx context.Context, parentID string) ([]*DBNode,
 func (o *oracle) fetchTables(ctx context.Context, parentName, parentID string) ([]*DBNode, error) {
 	query, args, err := sq.Select("TABLE_NAME").
 		From("ALL_TABLES").
-		Where(sq.Eq{"OWNER": strings.ToUpper(parentName)}).
+		Where(sq.Eq{"OWNER": parentName}).
 		OrderBy("TABLE_NAME ASC").
 		PlaceholderFormat(sq.Colon).
 		ToSql()
@@ -268,7 +584,7 @@ func (o *oracle) fetchTables(ctx context.Context, parentName, parentID string) (
 func (o *oracle) fetchViews(ctx context.Context, parentName, parentID string) ([]*DBNode, error) {
 	query, args, err := sq.Select("VIEW_NAME").
 		From("ALL_VIEWS").
-		Where(sq.Eq{"OWNER": strings.ToUpper(parentName)}).
+		Where(sq.Eq{"OWNER": parentName}).
 		OrderBy("VIEW_NAME ASC").
 		PlaceholderFormat(sq.Colon).
 		ToSql()
@@ -304,3 +620,65 @@ func (o *oracle) fetchViews(ctx context.Context, parentName, parentID string) ([
 
 	return views, nil
 }
+
+// TableDDL returns the CREATE TABLE statement of a table as given by DBMS_METADATA,
+// followed by the indexes that don't back a constraint and the comments of the table and its columns.
+func (o *oracle) TableDDL(ctx context.Context, table TableRef) (string, error) {
+	// the owner defaults to the current schema.
+	const owner = "NVL(:2, SYS_CONTEXT('USERENV', 'CURRENT_SCHEMA'))"
+	name, schema := table.Name, table.Schema
+
+	rows, err := queryStrings(ctx, o.db, "SELECT DBMS_METADATA.GET_DDL('TABLE', :1, "+owner+") FROM DUAL", name, schema)
+	if err != nil {
+		return "", err
+	}
+
+	if len(rows) == 0 {
+		return "", fmt.Errorf("definition of table %s not found", table.Name)
+	}
+
+	statements := []string{rows[0][0]}
+
+	indexes, err := queryStrings(ctx, o.db, `
+		SELECT DBMS_METADATA.GET_DDL('INDEX', i.INDEX_NAME, i.OWNER)
+		FROM ALL_INDEXES i
+		WHERE i.TABLE_NAME = :1
+			AND i.TABLE_OWNER = `+owner+`
+			AND NOT EXISTS (
+				SELECT 1 FROM ALL_CONSTRAINTS c WHERE c.OWNER = i.TABLE_OWNER AND c.INDEX_NAME = i.INDEX_NAME
+			)
+		ORDER BY i.INDEX_NAME`, name, schema)
+	if err != nil {
+		return "", err
+	}
+
+	for _, row := range indexes {
+		statements = append(statements, row[0])
+	}
+
+	qualifiedName := oracledialect.QuoteIdentifier(table.Name)
+	if table.Schema != "" {
+		qualifiedName = oracledialect.QuoteIdentifier(table.Schema) + "." + qualifiedName
+	}
+
+	comments, err := queryStrings(ctx, o.db, `
+		SELECT NULL, COMMENTS FROM ALL_TAB_COMMENTS
+		WHERE TABLE_NAME = :1 AND OWNER = `+owner+` AND COMMENTS IS NOT NULL
+		UNION ALL
+		SELECT COLUMN_NAME, COMMENTS FROM ALL_COL_COMMENTS
+		WHERE TABLE_NAME = :3 AND OWNER = NVL(:4, SYS_CONTEXT('USERENV', 'CURRENT_SCHEMA')) AND COMMENTS IS NOT NULL`,
+		name, schema, name, schema)
+	if err != nil {
+		return "", err
+	}
+
+	for _, row := range comments {
+		if row[0] == "" {
+			statements = append(statements, fmt.Sprintf("COMMENT ON TABLE %s IS %s", qualifiedName, quoteLiteral(row[1])))
+			continue
+		}
+		statements = append(statements, fmt.Sprintf("COMMENT ON COLUMN %s.%s IS %s", qualifiedName, oracledialect.QuoteIdentifier(row[0]), quoteLiteral(row[1])))
+	}
+
+	return joinStatements(statements), nil
+}
@@ -3,9 +3,13 @@ package client
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/jmoiron/sqlx"
+
+	pgdialect "github.com/danvergara/dblab/pkg/drivers/postgres"
 )
 
 // postgres struct is in charge of perform all the postgres related queries,
@@ -14,6 +18,8 @@ type postgres struct {
 	db     *sqlx.DB
 	dbName string
 	schema string
+	// systemSchemas are the schemas of the catalog of the server, left out of the tree.
+	systemSchemas []string
 }
 
 // a validation to see if postgres is implementing databaseQuerier.
@@ -22,9 +28,10 @@ var _ databaseQuerier = (*postgres)(nil)
 // returns a pointer to a postgres, it receives an schema as a parameter.
 func newPostgres(dbName, schema string, db *sqlx.DB) *postgres {
 	p := postgres{
-		dbName: dbName,
-		db:     db,
-		schema: schema,
+		dbName:        dbName,
+		db:            db,
+		schema:        schema,
+		systemSchemas: []string{"information_schema", "pg_catalog"},
 	}
 
 	return &p
@@ -110,10 +117,10 @@ func (p *postgres) Indexes(table TableRef) (string, []any, error) {
 	return sql, args, err
 }
 
-// Catalog returns a the pointer to a DBNode instance,
-// which is the root of the current PostgreSQL database graph.
-// It starts with the database itself,
-// then the schemas and the correspondent lists of tables and views.
+// Children returns the nodes right under the given node of the PostgreSQL database graph.
+// The database holds the schemas, or only the one the client is scoped to,
+// and the schemas hold the correspondent lists of tables, views, materialized views,
+// functions, procedures, sequences, triggers and user-defined types.
 // PostgreSQL topography:
 //
 //					 [Database]
@@ -122,55 +129,63 @@ func (p *postgres) Indexes(table TableRef) (string, []any, error) {
 //			     [Schemas]
 //			      /     \
 //			     v       v
-//	 		 [Tables] 	[Views]
-func (p *postgres) Catalog(ctx context.Context) (*DBNode, error) {
-	rootID := fmt.Sprintf("db:%s", p.dbName)
-	root := &DBNode{ID: rootID, Name: p.dbName, Type: "database"}
-	queue := []*DBNode{root}
-
-	for len(queue) > 0 {
-		current := queue[0]
-		queue = queue[1:]
-
-		children := make([]*DBNode, 0)
-		var err error
-		switch current.Type {
-		case "database":
-			if p.schema != "" {
-				children = append(children, &DBNode{
-					ID:         fmt.Sprintf("%s.s:%s", rootID, p.schema),
-					Name:       p.schema,
-					EntityName: p.schema,
-					Type:       "schema",
-					ParentID:   rootID,
-				})
-			} else {
-				children, err = p.fetchSchemas(ctx, current.Name)
-			}
-		case "schema":
-			tables, err := p.fetchTables(ctx, current.Name, current.ID)
-			if err != nil {
-				return nil, err
-			}
-			children = append(children, tables...)
-
-			views, err := p.fetchViews(ctx, current.Name, current.ID)
-			if err != nil {
-				return nil, err
-			}
-			children = append(children, views...)
+//	 		 [Tables] 	[Views]	[Materialized Views]	[Functions]	[Procedures]	[Sequences]	[Triggers]	[Types]
+func (p *postgres) Children(ctx context.Context, node *DBNode) ([]*DBNode, error) {
+	switch node.Type {
+	case "database":
+		if p.schema != "" {
+			return []*DBNode{{
+				ID:         fmt.Sprintf("%s.s:%s", node.ID, p.schema),
+				Name:       p.schema,
+				EntityName: p.schema,
+				Type:       "schema",
+				ParentID:   node.ID,
+			}}, nil
 		}
+
+		return p.fetchSchemas(ctx, node.ID)
+	case "schema":
+		tables, err := p.fetchTables(ctx, node.Name, node.ID)
 		if err != nil {
 			return nil, err
 		}
 
-		for _, child := range children {
-			current.Children = append(current.Children, child)
-			queue = append(queue, child)
+		views, err := p.fetchViews(ctx, node.Name, node.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		objects, err := p.fetchObjects(ctx, node.Name, node.ID)
+		if err != nil {
+			return nil, err
 		}
+
+		return slices.Concat(tables, views, objects), nil
+	}
+
+	return nil, nil
+}
+
+// pgRelation function returns the name of a table or view qualified by its schema, if any, both quoted,
+// as it's given to regclass, so the names with upper case letters or spaces are found.
+func pgRelation(schema, name string) string {
+	if schema == "" {
+		return pgdialect.QuoteIdentifier(name)
+	}
+
+	return pgdialect.QuoteIdentifier(schema) + "." + pgdialect.QuoteIdentifier(name)
+}
+
+// pgFunction function returns the signature of a function or procedure, e.g. add(integer, integer),
+// as it's given to regprocedure: its name quoted and qualified by its schema, if any, followed by the argument types.
+func pgFunction(schema, signature string) string {
+	name, args := signature, ""
+	// the argument types hold no parentheses, unlike the name may.
+	if i := strings.LastIndex(signature, "("); i >= 0 {
+		name, args = signature[:i], signature[i:]
 	}
 
-	return root, nil
+	return pgRelation(schema, name) + args
 }
 
 // GetViewDefinition method returns the SQL definition of a given view.
@@ -178,7 +193,7 @@ func (p *postgres) GetViewDefinition(view ViewRef) (string, []any, error) {
 	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
 	query, args, err := psql.
 		Select().
-		Column(sq.Expr("pg_get_viewdef(?::text::regclass, true) AS view_definition", fmt.Sprintf("%s.%s", view.Schema, view.Name))).
+		Column(sq.Expr("pg_get_viewdef(?::text::regclass, true) AS view_definition", pgRelation(view.Schema, view.Name))).
 		ToSql()
 	if err != nil {
 		return "", nil, err
@@ -187,12 +202,303 @@ func (p *postgres) GetViewDefinition(view ViewRef) (string, []any, error) {
 	return query, args, nil
 }
 
+// GetObjectDefinition method returns the source definition of a function, procedure, sequence, trigger or type.
+// Functions and procedures are identified by their name and their arguments, e.g. add(integer, integer).
+func (p *postgres) GetObjectDefinition(obj ObjectRef) (string, []any, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	var query sq.SelectBuilder
+	switch obj.Type {
+	case functionKind.Type, procedureKind.Type:
+		query = psql.
+			Select().
+			Column(sq.Expr("pg_get_functiondef(?::text::regprocedure) AS definition", pgFunction(obj.Schema, obj.Name)))
+	case sequenceKind.Type:
+		query = psql.
+			Select().
+			Column(`format(
+				'CREATE SEQUENCE %I.%I AS %s INCREMENT BY %s MINVALUE %s MAXVALUE %s START WITH %s CACHE %s%s;',
+				schemaname, sequencename, data_type, increment_by, min_value, max_value, start_value, cache_size,
+				CASE WHEN cycle THEN ' CYCLE' ELSE '' END
+			) AS definition`).
+			From("pg_sequences").
+			Where(sq.Eq{
+				"schemaname":   obj.Schema,
+				"sequencename": obj.Name,
+			})
+	case triggerKind.Type:
+		// Trigger names are unique per table, so the definitions of every trigger sharing the name are returned.
+		query = psql.
+			Select("pg_get_triggerdef(t.oid, true) || ';' AS definition").
+			From("pg_trigger t").
+			Join("pg_class c ON c.oid = t.tgrelid").
+			Join("pg_namespace n ON n.oid = c.relnamespace").
+			Where(sq.Eq{
+				"n.nspname": obj.Schema,
+				"t.tgname":  obj.Name,
+			}).
+			Where("NOT t.tgisinternal").
+			OrderBy("c.relname")
+	case typeKind.Type:
+		query = psql.
+			Select(`CASE t.typtype
+				WHEN 'e' THEN format('CREATE TYPE %I.%I AS ENUM (%s);', n.nspname, t.typname,
+					(SELECT string_agg(quote_literal(e.enumlabel), ', ' ORDER BY e.enumsortorder)
+					FROM pg_enum e WHERE e.enumtypid = t.oid))
+				WHEN 'c' THEN format('CREATE TYPE %I.%I AS (%s);', n.nspname, t.typname,
+					(SELECT string_agg(quote_ident(a.attname) || ' ' || format_type(a.atttypid, a.atttypmod), ', ' ORDER BY a.attnum)
+					FROM pg_attribute a WHERE a.attrelid = t.typrelid AND a.attnum > 0 AND NOT a.attisdropped))
+				WHEN 'd' THEN format('CREATE DOMAIN %I.%I AS %s%s%s;', n.nspname, t.typname,
+					format_type(t.typbasetype, t.typtypmod),
+					CASE WHEN t.typnotnull THEN ' NOT NULL' ELSE '' END,
+					COALESCE((SELECT ' ' || string_agg(pg_get_constraintdef(c.oid), ' ')
+					FROM pg_constraint c WHERE c.contypid = t.oid), ''))
+				WHEN 'r' THEN format('CREATE TYPE %I.%I AS RANGE (SUBTYPE = %s);', n.nspname, t.typname,
+					(SELECT format_type(r.rngsubtype, NULL) FROM pg_range r WHERE r.rngtypid = t.oid))
+			END AS definition`).
+			From("pg_type t").
+			Join("pg_namespace n ON n.oid = t.typnamespace").
+			Where(sq.Eq{
+				"n.nspname": obj.Schema,
+				"t.typname": obj.Name,
+			})
+	default:
+		return "", nil, fmt.Errorf("%s objects not supported", obj.Type)
+	}
+
+	return query.ToSql()
+}
+
+// CatalogColumns returns a query to list the columns of a table, flagging nullable, primary and foreign key columns.
+func (p *postgres) CatalogColumns(table TableRef) (string, []any, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	return psql.Select(
+		"a.attname",
+		"format_type(a.atttypid, a.atttypmod)",
+		"CASE WHEN a.attnotnull THEN 'NO' ELSE 'YES' END",
+		`CASE WHEN EXISTS (
+			SELECT 1 FROM pg_constraint c WHERE c.conrelid = a.attrelid AND c.contype = 'p' AND a.attnum = ANY(c.conkey)
+		) THEN 'YES' ELSE 'NO' END`,
+		`CASE WHEN EXISTS (
+			SELECT 1 FROM pg_constraint c WHERE c.conrelid = a.attrelid AND c.contype = 'f' AND a.attnum = ANY(c.conkey)
+		) THEN 'YES' ELSE 'NO' END`,
+	).
+		From("pg_attribute a").
+		Where(sq.Expr("a.attrelid = ?::text::regclass", pgRelation(table.Schema, table.Name))).
+		Where("a.attnum > 0 AND NOT a.attisdropped").
+		OrderBy("a.attnum").
+		ToSql()
+}
+
+// CatalogIndexes returns a query to list the indexes of a table, whether they are unique and their columns.
+// The expressions an index is built on, whose attribute number is 0, are written out by pg_get_indexdef.
+func (p *postgres) CatalogIndexes(table TableRef) (string, []any, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	return psql.Select(
+		"i.relname",
+		"CASE WHEN ix.indisunique THEN 'YES' ELSE 'NO' END",
+		`array_to_string(ARRAY(
+			SELECT COALESCE(a.attname, pg_get_indexdef(ix.indexrelid, k.position::int, true))
+			FROM unnest(ix.indkey) WITH ORDINALITY AS k(attnum, position)
+			LEFT JOIN pg_attribute a ON a.attrelid = ix.indrelid AND a.attnum = k.attnum AND k.attnum > 0
+			ORDER BY k.position
+		), ',')`,
+	).
+		From("pg_index ix").
+		Join("pg_class i ON i.oid = ix.indexrelid").
+		Where(sq.Expr("ix.indrelid = ?::text::regclass", pgRelation(table.Schema, table.Name))).
+		OrderBy("i.relname").
+		ToSql()
+}
+
+// CatalogForeignKeys returns a query to list the columns of the foreign keys of a schema,
+// along with the columns they reference.
+func (p *postgres) CatalogForeignKeys(schema string) (string, []any, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	return psql.Select(
+		"c.conname",
+		"t.relname",
+		"a.attname",
+		"rn.nspname",
+		"rt.relname",
+		"ra.attname",
+	).
+		From("pg_constraint c").
+		Join("pg_class t ON t.oid = c.conrelid").
+		Join("pg_namespace n ON n.oid = t.relnamespace").
+		Join("pg_class rt ON rt.oid = c.confrelid").
+		Join("pg_namespace rn ON rn.oid = rt.relnamespace").
+		Join("LATERAL unnest(c.conkey, c.confkey) WITH ORDINALITY AS k(attnum, refattnum, position) ON true").
+		Join("pg_attribute a ON a.attrelid = c.conrelid AND a.attnum = k.attnum").
+		Join("pg_attribute ra ON ra.attrelid = c.confrelid AND ra.attnum = k.refattnum").
+		Where("c.contype = 'f'").
+		Where(sq.Eq{"n.nspname": schema}).
+		OrderBy("t.relname", "c.conname", "k.position").
+		ToSql()
+}
+
+// CatalogRowCounts returns a query to list the estimated row count of the tables of a schema,
+// as kept by the planner.
+func (p *postgres) CatalogRowCounts(schema string) (string, []any, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	return psql.Select(
+		"c.relname",
+		"c.reltuples::bigint",
+	).
+		From("pg_class c").
+		Join("pg_namespace n ON n.oid = c.relnamespace").
+		Where(sq.Eq{
+			"c.relkind": []string{"r", "p"},
+			"n.nspname": schema,
+		}).
+		OrderBy("c.relname").
+		ToSql()
+}
+
+// TableStats returns the statistics of a table: its estimated row count, the size of its data, indexes and TOAST,
+// the live and dead tuples, the scans and the last vacuum and analyze runs.
+// A negative estimate means the table was never analyzed.
+func (p *postgres) TableStats(ctx context.Context, table TableRef) ([]Stat, error) {
+	return queryStats(ctx, p.db, `
+		SELECT
+			CASE WHEN c.reltuples < 0 THEN NULL ELSE c.reltuples::bigint END AS estimated_rows,
+			pg_total_relation_size(c.oid) AS total_size_bytes,
+			pg_table_size(c.oid) AS table_size_bytes,
+			pg_indexes_size(c.oid) AS indexes_size_bytes,
+			COALESCE(pg_total_relation_size(NULLIF(c.reltoastrelid, 0)), 0) AS toast_size_bytes,
+			s.n_live_tup AS live_tuples,
+			s.n_dead_tup AS dead_tuples,
+			s.seq_scan AS sequential_scans,
+			s.idx_scan AS index_scans,
+			s.last_vacuum,
+			s.last_autovacuum,
+			s.vacuum_count,
+			s.autovacuum_count,
+			s.last_analyze,
+			s.last_autoanalyze,
+			s.analyze_count,
+			s.autoanalyze_count
+		FROM pg_class c
+		LEFT JOIN pg_stat_user_tables s ON s.relid = c.oid
+		WHERE c.oid = $1::text::regclass`, pgRelation(table.Schema, table.Name))
+}
+
+// Activity returns a query to list the client sessions of the server from pg_stat_activity,
+// with the time spent on the current query, or in the current state if idle.
+func (p *postgres) Activity() (string, []any, error) {
+	query := `
+		SELECT
+			pid,
+			COALESCE(usename, ''),
+			COALESCE(datname, ''),
+			COALESCE(state, ''),
+			EXTRACT(EPOCH FROM now() - COALESCE(CASE WHEN state = 'active' THEN query_start ELSE state_change END, backend_start)),
+			COALESCE(wait_event_type || ': ' || wait_event, ''),
+			COALESCE(query, '')
+		FROM pg_stat_activity
+		WHERE backend_type = 'client backend' AND pid <> pg_backend_pid()
+		ORDER BY 5 DESC`
+
+	return query, nil, nil
+}
+
+// CancelSession returns a query to cancel the query running on a session, given its pid.
+func (p *postgres) CancelSession(id string) (string, []any, error) {
+	pid, err := sessionNumber(id)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return "SELECT pg_cancel_backend($1)", []any{pid}, nil
+}
+
+// TerminateSession returns a query to terminate a session, given its pid.
+func (p *postgres) TerminateSession(id string) (string, []any, error) {
+	pid, err := sessionNumber(id)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return "SELECT pg_terminate_backend($1)", []any{pid}, nil
+}
+
+// Locks returns a query to list the locks of the server from pg_locks, joined with pg_stat_activity,
+// along with the sessions blocking each one, as given by pg_blocking_pids.
+// The virtual transaction IDs every transaction holds on itself are left out.
+func (p *postgres) Locks() (string, []any, error) {
+	query := `
+		SELECT
+			l.pid,
+			array_to_string(pg_blocking_pids(l.pid), ','),
+			COALESCE(a.usename, ''),
+			COALESCE(a.state, ''),
+			l.mode,
+			CASE WHEN l.granted THEN 'YES' ELSE 'NO' END,
+			COALESCE(l.relation::regclass::text, l.locktype),
+			COALESCE(EXTRACT(EPOCH FROM now() - COALESCE(a.query_start, a.backend_start)), 0),
+			COALESCE(a.query, '')
+		FROM pg_locks l
+		JOIN pg_stat_activity a ON a.pid = l.pid
+		WHERE l.locktype <> 'virtualxid' AND l.pid <> pg_backend_pid()
+		ORDER BY l.pid, l.granted DESC`
+
+	return query, nil, nil
+}
+
+// Roles returns a query to list the roles of the server, but the predefined ones, along with their attributes
+// and the roles they are members of.
+func (p *postgres) Roles() (string, []any, error) {
+	query := `
+		SELECT
+			r.rolname,
+			concat_ws(', ',
+				CASE WHEN r.rolcanlogin THEN 'login' END,
+				CASE WHEN r.rolsuper THEN 'superuser' END,
+				CASE WHEN r.rolcreatedb THEN 'create db' END,
+				CASE WHEN r.rolcreaterole THEN 'create role' END,
+				CASE WHEN r.rolreplication THEN 'replication' END,
+				CASE WHEN r.rolbypassrls THEN 'bypass rls' END,
+				CASE WHEN r.rolconnlimit >= 0 THEN 'connection limit ' || r.rolconnlimit END,
+				CASE WHEN r.rolvaliduntil IS NOT NULL THEN 'valid until ' || r.rolvaliduntil END
+			),
+			COALESCE((
+				SELECT string_agg(g.rolname, ',' ORDER BY g.rolname)
+				FROM pg_auth_members m
+				JOIN pg_roles g ON g.oid = m.roleid
+				WHERE m.member = r.oid
+			), '')
+		FROM pg_roles r
+		WHERE r.rolname !~ '^pg_'
+		ORDER BY r.rolname`
+
+	return query, nil, nil
+}
+
+// TableGrants returns a query to list the privileges granted on a table, read from its access privileges
+// rather than information_schema.table_privileges, which only shows the grants the current user takes part in.
+// A table with no explicit grants gets the default privileges of its owner.
+func (p *postgres) TableGrants(table TableRef) (string, []any, error) {
+	query := `
+		SELECT
+			COALESCE(g.rolname, 'PUBLIC'),
+			a.privilege_type,
+			CASE WHEN a.is_grantable THEN 'YES' ELSE 'NO' END,
+			'table'
+		FROM pg_class c
+		CROSS JOIN LATERAL aclexplode(COALESCE(c.relacl, acldefault('r', c.relowner))) a
+		LEFT JOIN pg_roles g ON g.oid = a.grantee
+		WHERE c.oid = $1::text::regclass
+		ORDER BY 1, 2`
+
+	return query, []any{pgRelation(table.Schema, table.Name)}, nil
+}
+
 // fetchSchemas method lists all the schemas of the current database.
 func (p *postgres) fetchSchemas(ctx context.Context, parentID string) ([]*DBNode, error) {
 	query, args, err := sq.Select("schema_name").
 		From("information_schema.schemata").
 		Where(sq.NotEq{
-			"schema_name ": []string{"information_schema", "pg_catalog"},
+			"schema_name ": p.systemSchemas,
 		}).
 		PlaceholderFormat(sq.Dollar).
 		ToSql()
@@ -272,13 +578,13 @@ func (p *postgres) fetchTables(ctx context.Context, parentName, parentID string)
 
 // fetchViews method returns a list of views filtered by schema.
 func (p *postgres) fetchViews(ctx context.Context, parentName, parentID string) ([]*DBNode, error) {
-	// 'v' is View, 'm' is Materialized View.
+	// 'v' is View, materialized views are listed by fetchObjects.
 	query, args, err := sq.Select("c.relname AS view_name").
 		From("pg_class c").
 		Join("pg_namespace n ON n.oid = c.relnamespace").
 		Where(sq.Eq{
 			"n.nspname": parentName,
-			"c.relkind": []string{"v", "m"},
+			"c.relkind": "v",
 		}).
 		OrderBy("c.relname ASC").
 		PlaceholderFormat(sq.Dollar).
@@ -315,3 +621,170 @@ func (p *postgres) fetchViews(ctx context.Context, parentName, parentID string)
 
 	return views, nil
 }
+
+// fetchObjects method returns the materialized views, functions, procedures,
+// sequences, triggers and user-defined types of a schema.
+func (p *postgres) fetchObjects(ctx context.Context, parentName, parentID string) ([]*DBNode, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	queries := []struct {
+		kind  nodeKind
+		query sq.SelectBuilder
+	}{
+		{
+			kind: materializedViewKind,
+			query: psql.Select("c.relname").
+				From("pg_class c").
+				Join("pg_namespace n ON n.oid = c.relnamespace").
+				Where(sq.Eq{"n.nspname": parentName, "c.relkind": "m"}).
+				OrderBy("c.relname"),
+		},
+		{
+			// the argument types are part of the name, since functions can be overloaded.
+			kind: functionKind,
+			query: psql.Select("p.proname || '(' || oidvectortypes(p.proargtypes) || ')'").
+				From("pg_proc p").
+				Join("pg_namespace n ON n.oid = p.pronamespace").
+				Where(sq.Eq{"n.nspname": parentName, "p.prokind": "f"}).
+				OrderBy("1"),
+		},
+		{
+			kind: procedureKind,
+			query: psql.Select("p.proname || '(' || oidvectortypes(p.proargtypes) || ')'").
+				From("pg_proc p").
+				Join("pg_namespace n ON n.oid = p.pronamespace").
+				Where(sq.Eq{"n.nspname": parentName, "p.prokind": "p"}).
+				OrderBy("1"),
+		},
+		{
+			kind: sequenceKind,
+			query: psql.Select("sequence_name").
+				From("information_schema.sequences").
+				Where(sq.Eq{"sequence_schema": parentName}).
+				OrderBy("sequence_name"),
+		},
+		{
+			kind: triggerKind,
+			query: psql.Select("DISTINCT t.tgname").
+				From("pg_trigger t").
+				Join("pg_class c ON c.oid = t.tgrelid").
+				Join("pg_namespace n ON n.oid = c.relnamespace").
+				Where(sq.Eq{"n.nspname": parentName}).
+				Where("NOT t.tgisinternal").
+				OrderBy("t.tgname"),
+		},
+		{
+			// composite types created along with tables, views and so on are left out.
+			kind: typeKind,
+			query: psql.Select("t.typname").
+				From("pg_type t").
+				Join("pg_namespace n ON n.oid = t.typnamespace").
+				LeftJoin("pg_class c ON c.oid = t.typrelid").
+				Where(sq.Eq{"n.nspname": parentName, "t.typtype": []string{"c", "d", "e", "r"}}).
+				Where(sq.Or{sq.Eq{"t.typrelid": 0}, sq.Eq{"c.relkind": "c"}}).
+				OrderBy("t.typname"),
+		},
+	}
+
+	objects := make([]*DBNode, 0)
+	for _, q := range queries {
+		query, args, err := q.query.ToSql()
+		if err != nil {
+			return nil, err
+		}
+
+		nodes, err := queryNodes(ctx, p.db, q.kind, parentName, parentID, query, args...)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, nodes...)
+	}
+
+	return objects, nil
+}
+
+// TableDDL returns the CREATE TABLE statement of a table, reconstructed from the catalog,
+// since Postgres has no native source of it.
+// It includes the defaults, the constraints, the indexes that don't back a constraint and the comments.
+func (p *postgres) TableDDL(ctx context.Context, table TableRef) (string, error) {
+	relation := pgRelation(table.Schema, table.Name)
+	definition := tableDefinition{Name: relation}
+
+	columns, err := queryStrings(ctx, p.db, `
+		SELECT
+			quote_ident(a.attname),
+			format_type(a.atttypid, a.atttypmod),
+			CASE WHEN a.attnotnull THEN 'NO' ELSE 'YES' END,
+			COALESCE(pg_get_expr(d.adbin, d.adrelid), ''),
+			CASE a.attidentity
+				WHEN 'a' THEN 'GENERATED ALWAYS AS IDENTITY'
+				WHEN 'd' THEN 'GENERATED BY DEFAULT AS IDENTITY'
+				ELSE ''
+			END,
+			COALESCE(col_description(a.attrelid, a.attnum), '')
+		FROM pg_attribute a
+		LEFT JOIN pg_attrdef d ON d.adrelid = a.attrelid AND d.adnum = a.attnum
+		WHERE a.attrelid = $1::text::regclass AND a.attnum > 0 AND NOT a.attisdropped
+		ORDER BY a.attnum`, relation)
+	if err != nil {
+		return "", err
+	}
+
+	if len(columns) == 0 {
+		return "", fmt.Errorf("definition of table %s not found", table.Name)
+	}
+
+	for _, row := range columns {
+		definition.Columns = append(definition.Columns, columnDefinition{
+			Name:     row[0],
+			DataType: row[1],
+			Nullable: isYes(row[2]),
+			Default:  row[3],
+			Identity: row[4],
+		})
+
+		if row[5] != "" {
+			definition.Comments = append(definition.Comments, fmt.Sprintf("COMMENT ON COLUMN %s.%s IS %s", relation, row[0], quoteLiteral(row[5])))
+		}
+	}
+
+	// the primary key first, then the unique, check and foreign key constraints.
+	constraints, err := queryStrings(ctx, p.db, `
+		SELECT quote_ident(conname), pg_get_constraintdef(oid, true)
+		FROM pg_constraint
+		WHERE conrelid = $1::text::regclass AND contype IN ('p', 'u', 'c', 'f', 'x')
+		ORDER BY CASE contype WHEN 'p' THEN 0 WHEN 'u' THEN 1 WHEN 'c' THEN 2 ELSE 3 END, conname`, relation)
+	if err != nil {
+		return "", err
+	}
+
+	for _, row := range constraints {
+		definition.Constraints = append(definition.Constraints, constraintDefinition{Name: row[0], Definition: row[1]})
+	}
+
+	indexes, err := queryStrings(ctx, p.db, `
+		SELECT pg_get_indexdef(i.indexrelid)
+		FROM pg_index i
+		WHERE i.indrelid = $1::text::regclass
+			AND NOT EXISTS (SELECT 1 FROM pg_constraint c WHERE c.conindid = i.indexrelid AND c.conrelid = i.indrelid)
+		ORDER BY i.indexrelid::regclass::text`, relation)
+	if err != nil {
+		return "", err
+	}
+
+	for _, row := range indexes {
+		definition.Indexes = append(definition.Indexes, row[0])
+	}
+
+	comment, err := queryStrings(ctx, p.db, `SELECT COALESCE(obj_description($1::text::regclass, 'pg_class'), '')`, relation)
+	if err != nil {
+		return "", err
+	}
+
+	if len(comment) > 0 && comment[0][0] != "" {
+		tableComment := fmt.Sprintf("COMMENT ON TABLE %s IS %s", relation, quoteLiteral(comment[0][0]))
+		definition.Comments = append([]string{tableComment}, definition.Comments...)
+	}
+
+	return definition.String(), nil
+}
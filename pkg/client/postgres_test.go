@@ -0,0 +1,31 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPGRelation(t *testing.T) {
+	require.Equal(t, `"public"."Order Lines"`, pgRelation("public", "Order Lines"))
+	require.Equal(t, `"users"`, pgRelation("", "users"))
+}
+
+func TestPGFunction(t *testing.T) {
+	var tests = []struct {
+		schema    string
+		signature string
+		want      string
+	}{
+		{schema: "public", signature: "add(integer, integer)", want: `"public"."add"(integer, integer)`},
+		{schema: "Sales", signature: "Total Price()", want: `"Sales"."Total Price"()`},
+		{schema: "", signature: "now()", want: `"now"()`},
+		{schema: "public", signature: `odd"name(text)`, want: `"public"."odd""name"(text)`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.signature, func(t *testing.T) {
+			require.Equal(t, tt.want, pgFunction(tt.schema, tt.signature))
+		})
+	}
+}
@@ -0,0 +1,281 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+// redshift struct is in charge of the queries of Amazon Redshift, which speaks the wire protocol of Postgres 8.
+// Its catalog has no regclass casts from text, no LATERAL joins, no aclexplode and no indexes,
+// so the tables are looked up by name, and the distribution and sort keys are shown in place of the indexes.
+type redshift struct {
+	*postgres
+}
+
+// a validation to see if redshift is implementing databaseQuerier.
+var _ databaseQuerier = (*redshift)(nil)
+
+// returns a pointer to a redshift, it receives an schema as a parameter.
+func newRedshift(dbName, schema string, db *sqlx.DB) *redshift {
+	p := newPostgres(dbName, schema, db)
+	p.systemSchemas = append(p.systemSchemas, "pg_internal", "pg_automv")
+
+	return &redshift{postgres: p}
+}
+
+// redshiftTable is the join of pg_class to the namespace of a table, looked up by the schema and name in the where clause.
+const redshiftTable = "pg_class c JOIN pg_namespace n ON n.oid = c.relnamespace"
+
+// Indexes returns the distribution key and the sort key columns of a table, in the order of the sort key,
+// since Redshift has no indexes. A negative position is a column of an interleaved sort key.
+func (r *redshift) Indexes(table TableRef) (string, []any, error) {
+	query := `
+		SELECT
+			a.attname AS column_name,
+			format_type(a.atttypid, a.atttypmod) AS data_type,
+			CASE WHEN a.attisdistkey THEN 'YES' ELSE 'NO' END AS distkey,
+			a.attsortkeyord AS sortkey
+		FROM pg_attribute a
+		JOIN ` + redshiftTable + ` ON c.oid = a.attrelid
+		WHERE n.nspname = $1 AND c.relname = $2 AND a.attnum > 0
+			AND (a.attisdistkey OR a.attsortkeyord <> 0)
+		ORDER BY abs(a.attsortkeyord) = 0, abs(a.attsortkeyord)`
+
+	return query, []any{table.Schema, table.Name}, nil
+}
+
+// Children returns the nodes right under the given node of the Redshift database graph,
+// which are the ones of the postgres querier but the schemas hold only tables, views and functions.
+func (r *redshift) Children(ctx context.Context, node *DBNode) ([]*DBNode, error) {
+	if node.Type != "schema" {
+		return r.postgres.Children(ctx, node)
+	}
+
+	tables, err := r.fetchTables(ctx, node.Name, node.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	views, err := r.fetchViews(ctx, node.Name, node.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Redshift has no prokind, the procedures are listed along with the functions.
+	query, args, err := sq.Select("p.proname || '(' || oidvectortypes(p.proargtypes) || ')'").
+		From("pg_proc p").
+		Join("pg_namespace n ON n.oid = p.pronamespace").
+		Where(sq.Eq{"n.nspname": node.Name}).
+		OrderBy("1").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	functions, err := queryNodes(ctx, r.db, functionKind, node.Name, node.ID, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.Concat(tables, views, functions), nil
+}
+
+// GetViewDefinition method returns the SQL definition of a given view, from pg_views.
+func (r *redshift) GetViewDefinition(view ViewRef) (string, []any, error) {
+	return "SELECT definition AS view_definition FROM pg_views WHERE schemaname = $1 AND viewname = $2",
+		[]any{view.Schema, view.Name}, nil
+}
+
+// GetObjectDefinition method returns the source of a function, since Redshift has no pg_get_functiondef.
+// Functions are identified by their name and their arguments, e.g. add(integer, integer).
+func (r *redshift) GetObjectDefinition(obj ObjectRef) (string, []any, error) {
+	if obj.Type != functionKind.Type {
+		return "", nil, fmt.Errorf("%s objects not supported", obj.Type)
+	}
+
+	query := `
+		SELECT p.prosrc AS definition
+		FROM pg_proc p
+		JOIN pg_namespace n ON n.oid = p.pronamespace
+		WHERE n.nspname = $1 AND p.proname || '(' || oidvectortypes(p.proargtypes) || ')' = $2`
+
+	return query, []any{obj.Schema, obj.Name}, nil
+}
+
+// CatalogColumns returns a query to list the columns of a table, flagging nullable, primary and foreign key columns.
+func (r *redshift) CatalogColumns(table TableRef) (string, []any, error) {
+	query := `
+		SELECT
+			a.attname,
+			format_type(a.atttypid, a.atttypmod),
+			CASE WHEN a.attnotnull THEN 'NO' ELSE 'YES' END,
+			CASE WHEN EXISTS (
+				SELECT 1 FROM pg_constraint k WHERE k.conrelid = a.attrelid AND k.contype = 'p' AND a.attnum = ANY(k.conkey)
+			) THEN 'YES' ELSE 'NO' END,
+			CASE WHEN EXISTS (
+				SELECT 1 FROM pg_constraint k WHERE k.conrelid = a.attrelid AND k.contype = 'f' AND a.attnum = ANY(k.conkey)
+			) THEN 'YES' ELSE 'NO' END
+		FROM pg_attribute a
+		JOIN ` + redshiftTable + ` ON c.oid = a.attrelid
+		WHERE n.nspname = $1 AND c.relname = $2 AND a.attnum > 0 AND NOT a.attisdropped
+		ORDER BY a.attnum`
+
+	return query, []any{table.Schema, table.Name}, nil
+}
+
+// CatalogIndexes returns a query to list the indexes of a table, which is always empty on Redshift.
+func (r *redshift) CatalogIndexes(table TableRef) (string, []any, error) {
+	query := `
+		SELECT i.relname, CASE WHEN ix.indisunique THEN 'YES' ELSE 'NO' END, ''
+		FROM pg_index ix
+		JOIN pg_class i ON i.oid = ix.indexrelid
+		JOIN ` + redshiftTable + ` ON c.oid = ix.indrelid
+		WHERE n.nspname = $1 AND c.relname = $2
+		ORDER BY i.relname`
+
+	return query, []any{table.Schema, table.Name}, nil
+}
+
+// CatalogForeignKeys returns a query to list the columns of the foreign keys of a schema,
+// along with the columns they reference, from information_schema, since the key columns can't be unnested.
+// The foreign keys of Redshift are informational, they are not enforced.
+func (r *redshift) CatalogForeignKeys(schema string) (string, []any, error) {
+	query := `
+		SELECT
+			k.constraint_name,
+			k.table_name,
+			k.column_name,
+			rk.table_schema,
+			rk.table_name,
+			rk.column_name
+		FROM information_schema.referential_constraints rc
+		JOIN information_schema.key_column_usage k
+			ON k.constraint_schema = rc.constraint_schema AND k.constraint_name = rc.constraint_name
+		JOIN information_schema.key_column_usage rk
+			ON rk.constraint_schema = rc.unique_constraint_schema AND rk.constraint_name = rc.unique_constraint_name
+			AND rk.ordinal_position = k.ordinal_position
+		WHERE k.table_schema = $1
+		ORDER BY k.table_name, k.constraint_name, k.ordinal_position`
+
+	return query, []any{schema}, nil
+}
+
+// CatalogRowCounts returns a query to list the row count of the tables of a schema, from svv_table_info,
+// which leaves the empty tables out.
+func (r *redshift) CatalogRowCounts(schema string) (string, []any, error) {
+	return `SELECT "table", tbl_rows FROM svv_table_info WHERE "schema" = $1 ORDER BY "table"`, []any{schema}, nil
+}
+
+// TableStats returns the statistics of a table from svv_table_info: its rows, its size in 1 MB blocks,
+// its distribution style and sort key, and how unsorted, skewed and stale its statistics are.
+func (r *redshift) TableStats(ctx context.Context, table TableRef) ([]Stat, error) {
+	return queryStats(ctx, r.db, `
+		SELECT
+			tbl_rows AS estimated_rows,
+			size::bigint * 1048576 AS total_size_bytes,
+			pct_used AS percent_of_cluster,
+			diststyle AS distribution_style,
+			sortkey1 AS first_sort_key,
+			sortkey_num AS sort_key_columns,
+			unsorted AS unsorted_percent,
+			skew_rows AS row_skew,
+			stats_off AS stale_statistics_percent,
+			encoded
+		FROM svv_table_info
+		WHERE "schema" = $1 AND "table" = $2`, table.Schema, table.Name)
+}
+
+// Activity returns a query to list the queries running on the cluster from stv_recents,
+// since the pg_stat_activity of Redshift is the one of Postgres 8.
+func (r *redshift) Activity() (string, []any, error) {
+	query := `
+		SELECT
+			pid,
+			trim(user_name),
+			trim(db_name),
+			trim(status),
+			duration / 1000000.0,
+			'',
+			trim(query)
+		FROM stv_recents
+		WHERE status = 'Running' AND pid <> pg_backend_pid()
+		ORDER BY 5 DESC`
+
+	return query, nil, nil
+}
+
+// Locks returns a query to list the locks of the transactions on the cluster from svv_transactions.
+// Redshift doesn't tell the sessions blocking a lock apart.
+func (r *redshift) Locks() (string, []any, error) {
+	query := `
+		SELECT
+			pid,
+			'',
+			trim(txn_owner),
+			'',
+			trim(lock_mode),
+			CASE WHEN granted THEN 'YES' ELSE 'NO' END,
+			COALESCE(relation::varchar, trim(lockable_object_type)),
+			datediff(second, txn_start, getdate()),
+			''
+		FROM svv_transactions
+		WHERE pid <> pg_backend_pid()
+		ORDER BY pid, granted DESC`
+
+	return query, nil, nil
+}
+
+// Roles returns a query to list the users of the cluster along with their attributes, from pg_user.
+// The groups they are members of are left out, since the catalog of Redshift can't aggregate them.
+func (r *redshift) Roles() (string, []any, error) {
+	query := `
+		SELECT
+			usename,
+			trim(trailing ', ' FROM
+				CASE WHEN usesuper THEN 'superuser, ' ELSE '' END ||
+				CASE WHEN usecreatedb THEN 'create db, ' ELSE '' END ||
+				CASE WHEN valuntil IS NOT NULL THEN 'valid until ' || valuntil::varchar ELSE '' END
+			),
+			''
+		FROM pg_user
+		WHERE usename <> 'rdsdb'
+		ORDER BY usename`
+
+	return query, nil, nil
+}
+
+// TableGrants returns a query to list the privileges the users hold on a table, as told by has_table_privilege,
+// since Redshift has no aclexplode.
+func (r *redshift) TableGrants(table TableRef) (string, []any, error) {
+	query := `
+		SELECT u.usename, p.privilege, 'NO', 'table'
+		FROM pg_user u
+		CROSS JOIN (
+			SELECT 'SELECT' AS privilege UNION ALL SELECT 'INSERT' UNION ALL SELECT 'UPDATE'
+			UNION ALL SELECT 'DELETE' UNION ALL SELECT 'REFERENCES'
+		) p
+		WHERE u.usename <> 'rdsdb' AND has_table_privilege(u.usename, $1, p.privilege)
+		ORDER BY 1, 2`
+
+	return query, []any{pgRelation(table.Schema, table.Name)}, nil
+}
+
+// TableDDL returns the CREATE TABLE statement of a table, as given by SHOW TABLE,
+// which includes its distribution style, its sort key and the encoding of its columns.
+func (r *redshift) TableDDL(ctx context.Context, table TableRef) (string, error) {
+	rows, err := queryStrings(ctx, r.db, fmt.Sprintf("SHOW TABLE %s", pgRelation(table.Schema, table.Name)))
+	if err != nil {
+		return "", err
+	}
+
+	if len(rows) == 0 {
+		return "", fmt.Errorf("definition of table %s not found", table.Name)
+	}
+
+	return joinStatements([]string{rows[0][0]}), nil
+}
@@ -0,0 +1,173 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Grant is a privilege granted to a user or role on a table.
+type Grant struct {
+	Grantee   string
+	Privilege string
+	// Grantable tells whether the grantee can grant the privilege to others.
+	Grantable bool
+	// Level is where the privilege is granted, e.g. on the table itself, its schema or database, or globally.
+	Level string
+}
+
+// writePrivileges are the privileges that let their grantees change the rows of a table.
+var writePrivileges = []string{"INSERT", "UPDATE", "DELETE", "TRUNCATE", "MERGE", "ALL", "ALL PRIVILEGES", "CONTROL"}
+
+// securityNode function returns the node grouping the roles of the server under the database node.
+// It's loaded on demand, like the tables.
+func securityNode(database *DBNode) *DBNode {
+	return &DBNode{
+		ID:         database.ID + ".security",
+		Name:       "Security",
+		EntityName: "security",
+		Type:       "security",
+		ParentID:   database.ID,
+		ParentName: database.Name,
+	}
+}
+
+// RoleNodes returns the users and roles of the server as catalog nodes, given the ID of the security node,
+// with their attributes in their names and the roles they are members of as their children.
+func (c *Client) RoleNodes(ctx context.Context, parentID string) ([]*DBNode, error) {
+	query, args, err := c.databaseQuerier.Roles()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := queryStrings(ctx, c.db, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]*DBNode, 0, len(rows))
+	for _, row := range rows {
+		if len(row) < 3 {
+			return nil, fmt.Errorf("unexpected number of columns in the roles of the server: %d", len(row))
+		}
+
+		name := row[0]
+		if row[1] != "" {
+			name = fmt.Sprintf("%s (%s)", row[0], row[1])
+		}
+
+		role := &DBNode{
+			ID:         fmt.Sprintf("%s.%s", parentID, row[0]),
+			Name:       name,
+			EntityName: row[0],
+			Type:       "role",
+			ParentID:   parentID,
+			ParentName: "security",
+			Loaded:     true,
+		}
+
+		for _, member := range strings.Split(row[2], ",") {
+			if member = strings.TrimSpace(member); member == "" {
+				continue
+			}
+
+			role.Children = append(role.Children, &DBNode{
+				ID:         fmt.Sprintf("%s.member_of.%s", role.ID, member),
+				Name:       fmt.Sprintf("member of %s", member),
+				EntityName: member,
+				Type:       "membership",
+				ParentID:   role.ID,
+				ParentName: row[0],
+				Loaded:     true,
+			})
+		}
+
+		nodes = append(nodes, role)
+	}
+
+	return nodes, nil
+}
+
+// TableGrants returns the privileges on a table as a table of one row per grantee, with the privileges
+// it holds, whether it can change the rows of the table and the privileges it can grant to others.
+func (c *Client) TableGrants(ctx context.Context, table TableRef) (Table, error) {
+	query, args, err := c.databaseQuerier.TableGrants(table)
+	if err != nil {
+		return Table{}, err
+	}
+
+	rows, err := queryStrings(ctx, c.db, query, args...)
+	if err != nil {
+		return Table{}, err
+	}
+
+	grants := make([]Grant, 0, len(rows))
+	for _, row := range rows {
+		if len(row) < 4 {
+			return Table{}, fmt.Errorf("unexpected number of columns in the privileges on %s: %d", table.Name, len(row))
+		}
+
+		grants = append(grants, Grant{
+			Grantee:   row[0],
+			Privilege: row[1],
+			Grantable: isYes(row[2]),
+			Level:     row[3],
+		})
+	}
+
+	return grantsTable(grants), nil
+}
+
+// grantsTable function sums up the grants per grantee, in the order they come in.
+// The privileges not granted on the table itself are followed by their level, e.g. SELECT (database).
+func grantsTable(grants []Grant) Table {
+	type summary struct {
+		privileges []string
+		grantable  []string
+		canWrite   bool
+	}
+
+	summaries := make(map[string]*summary)
+	var grantees []string
+	for _, g := range grants {
+		s, ok := summaries[g.Grantee]
+		if !ok {
+			s = &summary{}
+			summaries[g.Grantee] = s
+			grantees = append(grantees, g.Grantee)
+		}
+
+		privilege := g.Privilege
+		if g.Level != "" && g.Level != "table" {
+			privilege = fmt.Sprintf("%s (%s)", g.Privilege, g.Level)
+		}
+
+		if !slices.Contains(s.privileges, privilege) {
+			s.privileges = append(s.privileges, privilege)
+		}
+		if g.Grantable && !slices.Contains(s.grantable, privilege) {
+			s.grantable = append(s.grantable, privilege)
+		}
+		if slices.Contains(writePrivileges, strings.ToUpper(g.Privilege)) {
+			s.canWrite = true
+		}
+	}
+
+	t := Table{
+		Columns: []string{"grantee", "privileges", "can write", "with grant option"},
+		Rows:    make([][]string, 0, len(grantees)),
+	}
+	for _, grantee := range grantees {
+		s := summaries[grantee]
+
+		canWrite := "NO"
+		if s.canWrite {
+			canWrite = "YES"
+		}
+
+		t.Rows = append(t.Rows, []string{grantee, strings.Join(s.privileges, ", "), canWrite, strings.Join(s.grantable, ", ")})
+	}
+
+	return t
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+)
+
+// rolesQuerier is a sqlite querier listing made-up roles, since SQLite has none.
+type rolesQuerier struct {
+	*sqlite
+}
+
+func (rolesQuerier) Roles() (string, []any, error) {
+	return `
+		SELECT 'app', 'login', 'readers,writers'
+		UNION ALL
+		SELECT 'readers', NULL, NULL`, nil, nil
+}
+
+func TestRoleNodes(t *testing.T) {
+	dbName := filepath.Join(t.TempDir(), "roles.db")
+
+	db, err := sqlx.Open("sqlite", dbName)
+	require.NoError(t, err)
+	defer db.Close()
+
+	c := &Client{db: db, dbName: dbName, databaseQuerier: rolesQuerier{newSQLite(dbName, db)}}
+
+	security := securityNode(c.Root())
+	roles, err := c.Children(context.Background(), security)
+	require.NoError(t, err)
+	require.Len(t, roles, 2)
+
+	require.Equal(t, "app (login)", roles[0].Name)
+	require.Equal(t, "app", roles[0].EntityName)
+	require.Equal(t, "role", roles[0].Type)
+	require.True(t, roles[0].Loaded)
+	require.Len(t, roles[0].Children, 2)
+	require.Equal(t, "member of readers", roles[0].Children[0].Name)
+	require.Equal(t, "member of writers", roles[0].Children[1].Name)
+
+	require.Equal(t, "readers", roles[1].Name)
+	require.Empty(t, roles[1].Children)
+}
+
+func TestGrantsTable(t *testing.T) {
+	grants := []Grant{
+		{Grantee: "app", Privilege: "SELECT", Level: "table"},
+		{Grantee: "app", Privilege: "INSERT", Level: "table"},
+		{Grantee: "reporting", Privilege: "SELECT", Grantable: true, Level: "table"},
+		{Grantee: "reporting", Privilege: "SELECT", Level: "database"},
+		{Grantee: "auditor", Privilege: "DENY DELETE", Level: "schema"},
+	}
+
+	require.Equal(t, Table{
+		Columns: []string{"grantee", "privileges", "can write", "with grant option"},
+		Rows: [][]string{
+			{"app", "SELECT, INSERT", "YES", ""},
+			{"reporting", "SELECT, SELECT (database)", "NO", "SELECT"},
+			{"auditor", "DENY DELETE (schema)", "NO", ""},
+		},
+	}, grantsTable(grants))
+}
+
+func TestSQLiteHasNoGrants(t *testing.T) {
+	_, _, err := newSQLite("test.db", nil).TableGrants(TableRef{Name: "users"})
+	require.Error(t, err)
+}
@@ -0,0 +1,219 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Snapshot holds the structure of the tables and views of a schema,
+// so the structure of two databases can be compared.
+type Snapshot struct {
+	Driver string
+	// Schema is empty for the databases whose catalog is not organized in schemas, like MySQL and SQLite.
+	Schema string
+	Tables []TableSnapshot
+	Views  []ViewSnapshot
+}
+
+// TableSnapshot holds the columns, indexes, constraints and foreign keys of a table.
+type TableSnapshot struct {
+	Name        string
+	Columns     []Column
+	Indexes     []Index
+	Constraints []Constraint
+	ForeignKeys []ForeignKey
+}
+
+// ViewSnapshot holds the definition of a view.
+type ViewSnapshot struct {
+	Name       string
+	Definition string
+}
+
+// Column describes a column of a table.
+type Column struct {
+	Name       string
+	DataType   string
+	Nullable   bool
+	PrimaryKey bool
+	ForeignKey bool
+}
+
+// Index describes an index of a table.
+type Index struct {
+	Name   string
+	Unique bool
+	// Columns are the columns or expressions the index is built on, in order.
+	// The expressions the database doesn't tell are left empty.
+	Columns []string
+}
+
+// Constraint describes a constraint of a table, e.g. PRIMARY KEY, FOREIGN KEY, UNIQUE or CHECK.
+type Constraint struct {
+	Name string
+	Type string
+}
+
+// oracleConstraintTypes maps the constraint type codes of Oracle to the names used by the rest of the databases.
+var oracleConstraintTypes = map[string]string{
+	"P": "PRIMARY KEY",
+	"R": "FOREIGN KEY",
+	"U": "UNIQUE",
+	"C": "CHECK",
+}
+
+// Snapshot returns the structure of the tables and views of the given schema.
+// If the schema is empty, the current schema is used.
+// The schema is ignored for the databases whose catalog is not organized in schemas.
+func (c *Client) Snapshot(ctx context.Context, schema string) (*Snapshot, error) {
+	root := c.Root()
+	parent := root
+
+	if c.HasSchemas() {
+		if schema == "" {
+			var err error
+			if schema, err = c.CurrentSchema(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		if schema == "" {
+			return nil, errors.New("the schema to compare could not be figured out, set it explicitly")
+		}
+
+		parent = &DBNode{
+			ID:         fmt.Sprintf("%s.s:%s", root.ID, schema),
+			Name:       schema,
+			EntityName: schema,
+			Type:       "schema",
+			ParentID:   root.ID,
+		}
+	} else {
+		schema = ""
+	}
+
+	children, err := c.databaseQuerier.Children(ctx, parent)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &Snapshot{Driver: c.dialect().Name(), Schema: schema}
+
+	query, args, err := c.databaseQuerier.CatalogForeignKeys(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	foreignKeys, err := queryForeignKeys(ctx, c.db, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, child := range children {
+		switch child.Type {
+		case "table":
+			table, err := c.tableSnapshot(ctx, TableRef{Schema: child.ParentName, Name: child.EntityName})
+			if err != nil {
+				return nil, err
+			}
+			for _, fk := range foreignKeys {
+				if fk.Table == table.Name {
+					table.ForeignKeys = append(table.ForeignKeys, fk)
+				}
+			}
+			snapshot.Tables = append(snapshot.Tables, table)
+		case "view":
+			rows, _, err := c.viewDefintion(ViewRef{Schema: child.ParentName, Name: child.EntityName})
+			if err != nil {
+				return nil, err
+			}
+			snapshot.Views = append(snapshot.Views, ViewSnapshot{Name: child.EntityName, Definition: definitionText(rows)})
+		}
+	}
+
+	slices.SortFunc(snapshot.Tables, func(a, b TableSnapshot) int { return strings.Compare(a.Name, b.Name) })
+	slices.SortFunc(snapshot.Views, func(a, b ViewSnapshot) int { return strings.Compare(a.Name, b.Name) })
+
+	return snapshot, nil
+}
+
+// tableSnapshot returns the columns, indexes and constraints of a table.
+func (c *Client) tableSnapshot(ctx context.Context, table TableRef) (TableSnapshot, error) {
+	snapshot := TableSnapshot{Name: table.Name}
+
+	query, args, err := c.databaseQuerier.CatalogColumns(table)
+	if err != nil {
+		return snapshot, err
+	}
+
+	if snapshot.Columns, err = queryColumns(ctx, c.db, query, args...); err != nil {
+		return snapshot, err
+	}
+
+	query, args, err = c.databaseQuerier.CatalogIndexes(table)
+	if err != nil {
+		return snapshot, err
+	}
+
+	if snapshot.Indexes, err = queryIndexes(ctx, c.db, query, args...); err != nil {
+		return snapshot, err
+	}
+
+	rows, headers, err := c.constraints(table)
+	if err != nil {
+		return snapshot, err
+	}
+
+	snapshot.Constraints = constraintsFromRows(rows, headers)
+
+	return snapshot, nil
+}
+
+// constraintsFromRows picks the name and the type of the constraints out of the result set of a Constraints query,
+// since the columns returned vary from one database to another.
+// It returns no constraints if the result set has no constraint_name column, like the one of SQLite.
+func constraintsFromRows(rows [][]string, headers []string) []Constraint {
+	nameIndex := slices.IndexFunc(headers, func(h string) bool { return strings.EqualFold(h, "constraint_name") })
+	typeIndex := slices.IndexFunc(headers, func(h string) bool { return strings.EqualFold(h, "constraint_type") })
+	if nameIndex < 0 || typeIndex < 0 {
+		return nil
+	}
+
+	constraints := make([]Constraint, 0, len(rows))
+	for _, row := range rows {
+		if len(row) <= nameIndex || len(row) <= typeIndex {
+			continue
+		}
+
+		constraintType := strings.ToUpper(strings.TrimSpace(row[typeIndex]))
+		if t, ok := oracleConstraintTypes[constraintType]; ok {
+			constraintType = t
+		}
+
+		// the NOT NULL checks Postgres and Oracle list along with the constraints have names generated
+		// by the database, the nullability is compared per column instead.
+		if constraintType == "CHECK" && isNotNullCheck(row[nameIndex]) {
+			continue
+		}
+
+		constraints = append(constraints, Constraint{Name: row[nameIndex], Type: constraintType})
+	}
+
+	slices.SortFunc(constraints, func(a, b Constraint) int { return strings.Compare(a.Name, b.Name) })
+
+	return constraints
+}
+
+// isNotNullCheck reports whether a check constraint is the one a database generates for a NOT NULL column,
+// named like "users_email_not_null" by Postgres or "SYS_C001" by Oracle.
+func isNotNullCheck(name string) bool {
+	return strings.HasSuffix(name, "_not_null") || strings.HasPrefix(name, "SYS_C")
+}
+
+// HasSchemas reports whether the catalog of the database is organized in schemas.
+func (c *Client) HasSchemas() bool {
+	return c.dialect().HasSchemas()
+}
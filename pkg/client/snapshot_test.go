@@ -0,0 +1,88 @@
+package client
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+
+	"github.com/danvergara/dblab/pkg/drivers"
+)
+
+func TestSQLiteSnapshot(t *testing.T) {
+	sandboxDir := t.TempDir()
+	dbName := filepath.Join(sandboxDir, "snapshot.db")
+
+	db, err := sqlx.Open("sqlite", dbName)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec(`
+		CREATE TABLE users (id INTEGER PRIMARY KEY, email TEXT NOT NULL);
+		CREATE UNIQUE INDEX users_email_idx ON users (email);
+		CREATE TABLE accounts (id INTEGER PRIMARY KEY, user_id INTEGER REFERENCES users(id));
+		CREATE VIEW emails AS SELECT email FROM users;`)
+	require.NoError(t, err)
+
+	c := &Client{db: db, dbName: dbName, driver: drivers.SQLite, databaseQuerier: newSQLite(dbName, db)}
+
+	snapshot, err := c.Snapshot(context.Background(), "ignored")
+	require.NoError(t, err)
+
+	require.Equal(t, drivers.SQLite, snapshot.Driver)
+	require.Empty(t, snapshot.Schema)
+	require.Len(t, snapshot.Tables, 2)
+	require.Equal(t, "accounts", snapshot.Tables[0].Name)
+	require.Equal(t, TableSnapshot{
+		Name: "users",
+		Columns: []Column{
+			{Name: "id", DataType: "INTEGER", Nullable: true, PrimaryKey: true},
+			{Name: "email", DataType: "TEXT"},
+		},
+		Indexes: []Index{{Name: "users_email_idx", Unique: true, Columns: []string{"email"}}},
+	}, snapshot.Tables[1])
+	require.Len(t, snapshot.Views, 1)
+	require.Equal(t, "emails", snapshot.Views[0].Name)
+	require.Contains(t, snapshot.Views[0].Definition, "CREATE VIEW emails AS SELECT email FROM users")
+}
+
+func TestConstraintsFromRows(t *testing.T) {
+	var tests = []struct {
+		name    string
+		rows    [][]string
+		headers []string
+		want    []Constraint
+	}{
+		{
+			name:    "Information schema",
+			rows:    [][]string{{"users_pkey", "users", "PRIMARY KEY"}, {"users_account_fkey", "users", "FOREIGN KEY"}},
+			headers: []string{"constraint_name", "table_name", "constraint_type"},
+			want:    []Constraint{{Name: "users_account_fkey", Type: "FOREIGN KEY"}, {Name: "users_pkey", Type: "PRIMARY KEY"}},
+		},
+		{
+			name:    "Oracle codes",
+			rows:    [][]string{{"SYS_C001", "P"}, {"USERS_EMAIL_UK", "U"}},
+			headers: []string{"CONSTRAINT_NAME", "CONSTRAINT_TYPE"},
+			want:    []Constraint{{Name: "SYS_C001", Type: "PRIMARY KEY"}, {Name: "USERS_EMAIL_UK", Type: "UNIQUE"}},
+		},
+		{
+			name:    "NOT NULL checks",
+			rows:    [][]string{{"users_pkey", "PRIMARY KEY"}, {"2200_16390_1_not_null", "CHECK"}, {"SYS_C002", "C"}, {"users_age_check", "CHECK"}},
+			headers: []string{"constraint_name", "constraint_type"},
+			want:    []Constraint{{Name: "users_age_check", Type: "CHECK"}, {Name: "users_pkey", Type: "PRIMARY KEY"}},
+		},
+		{
+			name:    "No constraint columns",
+			rows:    [][]string{{"table", "users", "users", "2", "CREATE TABLE users (id int)"}},
+			headers: []string{"type", "name", "tbl_name", "rootpage", "sql"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.want, constraintsFromRows(test.rows, test.headers))
+		})
+	}
+}
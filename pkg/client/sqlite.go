@@ -3,9 +3,13 @@ package client
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/jmoiron/sqlx"
+
+	sqlitedialect "github.com/danvergara/dblab/pkg/drivers/sqlite"
 )
 
 // sqlite  is in charge of perform all the sqlite related queries,
@@ -30,7 +34,7 @@ func newSQLite(dbName string, db *sqlx.DB) *sqlite {
 
 // TableStructure returns a query string to retrieve all the relevant information of a given table.
 func (s *sqlite) TableStructure(table TableRef) (string, []any, error) {
-	query := fmt.Sprintf("PRAGMA table_info(%s);", table.Name)
+	query := fmt.Sprintf("PRAGMA table_info(%s);", sqlitedialect.QuoteIdentifier(table.Name))
 	return query, nil, nil
 }
 
@@ -56,57 +60,41 @@ func (s *sqlite) Constraints(table TableRef) (string, []any, error) {
 
 // Indexes returns a query to get all the indexes of a table.
 func (s *sqlite) Indexes(table TableRef) (string, []any, error) {
-	query := fmt.Sprintf(`PRAGMA index_list(%s);`, table.Name)
+	query := fmt.Sprintf(`PRAGMA index_list(%s);`, sqlitedialect.QuoteIdentifier(table.Name))
 
 	return query, nil, nil
 }
 
-// Catalog returns a the pointer to a DBNode instance,
-// which is the root of the current SQLite database graph.
-// It starts with the database itself and a list of tables a views.
+// Children returns the nodes right under the given node of the SQLite database graph.
+// The database holds the tables and views, followed by the triggers.
+// SQLite has no stored routines, sequences nor user-defined types.
 // SQLite topography:
 //
 //			     [Database]
 //			      /     \
 //			     v       v
-//	 			[Tables] 	[Views]
-func (s *sqlite) Catalog(ctx context.Context) (*DBNode, error) {
-	rootID := fmt.Sprintf("db:%s", s.dbName)
-	root := &DBNode{ID: rootID, Name: s.dbName, Type: "database"}
-
-	queue := []*DBNode{root}
-
-	for len(queue) > 0 {
-		current := queue[0]
-		queue = queue[1:]
-
-		var children []*DBNode
-		var err error
-		switch current.Type {
-		case "database":
-			tables, err := s.fetchTables(ctx, current.Name, current.ID)
-			if err != nil {
-				return nil, err
-			}
-			children = append(children, tables...)
-
-			views, err := s.fetchViews(ctx, current.Name, current.ID)
-			if err != nil {
-				return nil, err
-			}
-			children = append(children, views...)
-		}
-		if err != nil {
-			return nil, err
-		}
+//	 			[Tables] 	[Views]	[Triggers]
+func (s *sqlite) Children(ctx context.Context, node *DBNode) ([]*DBNode, error) {
+	if node.Type != "database" {
+		return nil, nil
+	}
 
-		for _, child := range children {
-			current.Children = append(current.Children, child)
-			queue = append(queue, child)
-		}
+	tables, err := s.fetchTables(ctx, node.Name, node.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	views, err := s.fetchViews(ctx, node.Name, node.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	triggers, err := s.fetchTriggers(ctx, node.Name, node.ID)
+	if err != nil {
+		return nil, err
 	}
 
-	return root, nil
+	return slices.Concat(tables, views, triggers), nil
 }
 
 // GetViewDefinition method returns the SQL definition of a given view.
@@ -127,6 +115,170 @@ func (s *sqlite) GetViewDefinition(view ViewRef) (string, []any, error) {
 	return query, args, nil
 }
 
+// GetObjectDefinition method returns the SQL definition of a given trigger.
+func (s *sqlite) GetObjectDefinition(obj ObjectRef) (string, []any, error) {
+	if obj.Type != triggerKind.Type {
+		return "", nil, fmt.Errorf("%s objects not supported", obj.Type)
+	}
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Question)
+	query, args, err := psql.
+		Select("sql AS definition").
+		From("sqlite_master").
+		Where(sq.Eq{
+			"type": "trigger",
+			"name": obj.Name,
+		}).
+		ToSql()
+	if err != nil {
+		return "", nil, err
+	}
+
+	return query, args, nil
+}
+
+// CatalogColumns returns a query to list the columns of a table, flagging nullable, primary and foreign key columns.
+func (s *sqlite) CatalogColumns(table TableRef) (string, []any, error) {
+	query := `
+		SELECT
+			c.name,
+			c.type,
+			CASE WHEN c."notnull" = 1 THEN 'NO' ELSE 'YES' END,
+			CASE WHEN c.pk > 0 THEN 'YES' ELSE 'NO' END,
+			CASE WHEN EXISTS (
+				SELECT 1 FROM pragma_foreign_key_list(?) AS f WHERE f."from" = c.name
+			) THEN 'YES' ELSE 'NO' END
+		FROM
+			pragma_table_info(?) AS c
+		ORDER BY
+			c.cid;`
+
+	return query, []any{table.Name, table.Name}, nil
+}
+
+// CatalogIndexes returns a query to list the indexes of a table, whether they are unique and their columns.
+// The expressions an index is built on have no column name, they're left empty.
+func (s *sqlite) CatalogIndexes(table TableRef) (string, []any, error) {
+	query := `
+		SELECT
+			l.name,
+			CASE WHEN l."unique" = 1 THEN 'YES' ELSE 'NO' END,
+			(SELECT group_concat(COALESCE(i.name, ''), ',') FROM (SELECT name FROM pragma_index_info(l.name) ORDER BY seqno) AS i)
+		FROM
+			pragma_index_list(?) AS l
+		ORDER BY
+			l.name;`
+
+	return query, []any{table.Name}, nil
+}
+
+// CatalogForeignKeys returns a query to list the columns of the foreign keys of the database,
+// along with the columns they reference.
+// SQLite doesn't keep the names of the foreign keys, so they are named after the table and their position.
+// The schema is ignored, since SQLite has none.
+func (s *sqlite) CatalogForeignKeys(schema string) (string, []any, error) {
+	query := `
+		SELECT
+			t.name || '_fk_' || f.id,
+			t.name,
+			f."from",
+			'',
+			f."table",
+			COALESCE(f."to", '')
+		FROM
+			sqlite_master AS t
+			JOIN pragma_foreign_key_list(t.name) AS f
+		WHERE
+			t.type = 'table'
+		ORDER BY
+			t.name, f.id, f.seq;`
+
+	return query, nil, nil
+}
+
+// CatalogRowCounts returns a query to list the estimated row count of the tables of the database,
+// as kept in sqlite_stat1 by ANALYZE. The first number of a stat is the row count of the index.
+// The schema is ignored, since SQLite has none.
+func (s *sqlite) CatalogRowCounts(schema string) (string, []any, error) {
+	query := `
+		SELECT
+			tbl,
+			MAX(CAST(stat AS INTEGER))
+		FROM
+			sqlite_stat1
+		GROUP BY
+			tbl
+		ORDER BY
+			tbl;`
+
+	return query, nil, nil
+}
+
+// TableStats returns the statistics of a table: the size of its data and indexes, read from the dbstat table.
+// SQLite builds with no dbstat table have no statistics other than the row count.
+func (s *sqlite) TableStats(ctx context.Context, table TableRef) ([]Stat, error) {
+	stats, err := queryStats(ctx, s.db, `
+		SELECT
+			COALESCE(SUM(CASE WHEN d.name = ? THEN d.pgsize END), 0) AS table_size_bytes,
+			COALESCE(SUM(CASE WHEN d.name <> ? THEN d.pgsize END), 0) AS indexes_size_bytes,
+			COUNT(*) AS pages
+		FROM dbstat AS d
+		WHERE d.name = ? OR d.name IN (SELECT name FROM pragma_index_list(?))`, table.Name, table.Name, table.Name, table.Name)
+	if err != nil && strings.Contains(err.Error(), "no such table") {
+		return nil, nil
+	}
+
+	return stats, err
+}
+
+// Activity is not supported, since SQLite is embedded and has no server sessions.
+func (s *sqlite) Activity() (string, []any, error) {
+	return "", nil, fmt.Errorf("sqlite has no server sessions to monitor")
+}
+
+// CancelSession is not supported, since SQLite has no server sessions.
+func (s *sqlite) CancelSession(id string) (string, []any, error) {
+	return "", nil, fmt.Errorf("sqlite has no server sessions to cancel")
+}
+
+// TerminateSession is not supported, since SQLite has no server sessions.
+func (s *sqlite) TerminateSession(id string) (string, []any, error) {
+	return "", nil, fmt.Errorf("sqlite has no server sessions to terminate")
+}
+
+// Locks is not supported, since SQLite locks the whole database file and has no server sessions.
+func (s *sqlite) Locks() (string, []any, error) {
+	return "", nil, fmt.Errorf("sqlite has no server sessions holding locks")
+}
+
+// Roles is not supported, since SQLite has no users nor roles.
+func (s *sqlite) Roles() (string, []any, error) {
+	return "", nil, fmt.Errorf("sqlite has no users nor roles")
+}
+
+// TableGrants is not supported, since SQLite has no privileges, the access is the one to the database file.
+func (s *sqlite) TableGrants(table TableRef) (string, []any, error) {
+	return "", nil, fmt.Errorf("sqlite has no privileges, the access to a table is the one to the database file")
+}
+
+// fetchTriggers method lists all the triggers of the current database.
+func (s *sqlite) fetchTriggers(ctx context.Context, parentName, parentID string) ([]*DBNode, error) {
+	query, args, err := sq.
+		Select("name").
+		From("sqlite_master").
+		Where(sq.Eq{
+			"type": "trigger",
+		}).
+		OrderBy("name").
+		PlaceholderFormat(sq.Question).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	return queryNodes(ctx, s.db, triggerKind, parentName, parentID, query, args...)
+}
+
 // fetchTables method lists all the tables of the current database.
 func (s *sqlite) fetchTables(ctx context.Context, parentName, parentID string) ([]*DBNode, error) {
 	query := `
@@ -211,3 +363,32 @@ func (s *sqlite) fetchViews(ctx context.Context, parentName, parentID string) ([
 
 	return views, nil
 }
+
+// TableDDL returns the statements stored in sqlite_master that create a table, its indexes and its triggers.
+func (s *sqlite) TableDDL(ctx context.Context, table TableRef) (string, error) {
+	query := `
+		SELECT
+			sql
+		FROM
+			sqlite_master
+		WHERE
+			tbl_name = ? AND sql IS NOT NULL
+		ORDER BY
+			CASE type WHEN 'table' THEN 0 WHEN 'index' THEN 1 ELSE 2 END, name;`
+
+	rows, err := queryStrings(ctx, s.db, query, table.Name)
+	if err != nil {
+		return "", err
+	}
+
+	if len(rows) == 0 {
+		return "", fmt.Errorf("definition of table %s not found", table.Name)
+	}
+
+	statements := make([]string, 0, len(rows))
+	for _, row := range rows {
+		statements = append(statements, row[0])
+	}
+
+	return joinStatements(statements), nil
+}
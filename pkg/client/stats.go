@@ -0,0 +1,172 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/danvergara/dblab/pkg/drivers"
+)
+
+// exactCountTimeout bounds the time spent counting the rows of a table, which takes a full scan on most databases.
+const exactCountTimeout = 5 * time.Second
+
+// Stat is a statistic of a table, e.g. its size or the last time it was analyzed.
+type Stat struct {
+	Name  string
+	Value string
+}
+
+// TableStats returns the statistics kept by the database for a table, like its estimated row count,
+// the size of the table and its indexes, and the last maintenance runs, so the table is not scanned.
+func (c *Client) TableStats(ctx context.Context, table TableRef) ([]Stat, error) {
+	return c.databaseQuerier.TableStats(ctx, table)
+}
+
+// ExactRowCount returns the number of rows of a table as a statistic, counted rather than estimated,
+// which takes a full scan on most databases.
+// The count gives up after a few seconds, the statistic tells why the count is not available then.
+func (c *Client) ExactRowCount(ctx context.Context, table TableRef) Stat {
+	ctx, cancel := context.WithTimeout(ctx, exactCountTimeout)
+	defer cancel()
+
+	exact := Stat{Name: "exact rows"}
+
+	var count int64
+	if err := c.db.QueryRowContext(ctx, fmt.Sprintf("SELECT COUNT(*) FROM %s", c.fromTable(table))).Scan(&count); err != nil {
+		exact.Value = fmt.Sprintf("not available: %s", err)
+	} else {
+		exact.Value = strconv.FormatInt(count, 10)
+	}
+
+	return exact
+}
+
+// RowCounts returns the approximate row count of the tables of a schema, by table name,
+// as estimated by the database, so the tables are not scanned.
+// The tables the database has no estimate for are left out, e.g. the SQLite tables never analyzed.
+func (c *Client) RowCounts(ctx context.Context, schema string) (map[string]int64, error) {
+	query, args, err := c.databaseQuerier.CatalogRowCounts(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := queryStrings(ctx, c.db, query, args...)
+	if err != nil {
+		// SQLite keeps the estimates in sqlite_stat1, which is created by the first ANALYZE.
+		if c.driver == drivers.SQLite && strings.Contains(err.Error(), "no such table") {
+			return map[string]int64{}, nil
+		}
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		if len(row) < 2 {
+			continue
+		}
+
+		count, err := strconv.ParseInt(strings.TrimSpace(row[1]), 10, 64)
+		if err != nil || count < 0 {
+			continue
+		}
+		counts[row[0]] = count
+	}
+
+	return counts, nil
+}
+
+// fromTable method returns the name of a table as it's written in the FROM clause of the database,
+// quoted, and qualified by its schema on the databases organized in schemas.
+func (c *Client) fromTable(table TableRef) string {
+	d := c.dialect()
+	if d.HasSchemas() && table.Schema != "" {
+		return fmt.Sprintf("%s.%s", d.QuoteIdentifier(table.Schema), d.QuoteIdentifier(table.Name))
+	}
+
+	return d.QuoteIdentifier(table.Name)
+}
+
+// queryStats runs a query that returns the statistics of a table as the columns of a single row.
+// The columns are named after the statistics, the ones ending in _bytes are shown as sizes.
+// NULL values are shown as a dash.
+func queryStats(ctx context.Context, db *sqlx.DB, query string, args ...any) ([]Stat, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("statistics not found")
+	}
+
+	values := make([]sql.NullString, len(columns))
+	dest := make([]any, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+
+	if err := rows.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	stats := make([]Stat, 0, len(columns))
+	for i, column := range columns {
+		stats = append(stats, newStat(column, values[i]))
+	}
+
+	return stats, rows.Err()
+}
+
+// newStat function names a statistic after the column it was read from, e.g. table_size_bytes becomes table size.
+func newStat(column string, value sql.NullString) Stat {
+	name := strings.ToLower(column)
+	isSize := strings.HasSuffix(name, "_bytes")
+	name = strings.ReplaceAll(strings.TrimSuffix(name, "_bytes"), "_", " ")
+
+	if !value.Valid || value.String == "" {
+		return Stat{Name: name, Value: "-"}
+	}
+
+	if isSize {
+		if size, err := strconv.ParseFloat(value.String, 64); err == nil {
+			return Stat{Name: name, Value: formatSize(int64(size))}
+		}
+	}
+
+	return Stat{Name: name, Value: value.String}
+}
+
+// formatSize function returns a size in bytes in a readable unit, along with the exact number of bytes.
+func formatSize(size int64) string {
+	if size < 1024 {
+		return fmt.Sprintf("%d bytes", size)
+	}
+
+	units := []string{"KB", "MB", "GB", "TB", "PB"}
+	value := float64(size) / 1024
+	unit := units[0]
+	for _, u := range units[1:] {
+		if value < 1024 {
+			break
+		}
+		value /= 1024
+		unit = u
+	}
+
+	return fmt.Sprintf("%.1f %s (%d bytes)", value, unit, size)
+}
@@ -0,0 +1,101 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+
+	"github.com/danvergara/dblab/pkg/drivers"
+)
+
+func TestSQLiteTableStats(t *testing.T) {
+	sandboxDir := t.TempDir()
+	dbName := filepath.Join(sandboxDir, "stats.db")
+
+	db, err := sqlx.Open("sqlite", dbName)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec(`
+		CREATE TABLE customers (id INTEGER PRIMARY KEY, email TEXT UNIQUE);
+		INSERT INTO customers (email) VALUES ('a@example.com'), ('b@example.com'), ('c@example.com');
+		CREATE TABLE products (sku TEXT PRIMARY KEY);`)
+	require.NoError(t, err)
+
+	c := &Client{db: db, driver: drivers.SQLite, databaseQuerier: newSQLite(dbName, db)}
+
+	stats, err := c.TableStats(context.Background(), TableRef{Name: "customers"})
+	require.NoError(t, err)
+	require.NotEmpty(t, stats)
+
+	names := make([]string, 0, len(stats))
+	for _, stat := range stats {
+		names = append(names, stat.Name)
+	}
+	require.Contains(t, names, "table size")
+	require.Contains(t, names, "indexes size")
+	require.NotContains(t, names, "exact rows")
+
+	require.Equal(t, Stat{Name: "exact rows", Value: "3"}, c.ExactRowCount(context.Background(), TableRef{Name: "customers"}))
+
+	// the estimates only exist once the database is analyzed.
+	counts, err := c.RowCounts(context.Background(), "")
+	require.NoError(t, err)
+	require.Empty(t, counts)
+
+	_, err = db.Exec("ANALYZE")
+	require.NoError(t, err)
+
+	counts, err = c.RowCounts(context.Background(), "")
+	require.NoError(t, err)
+	require.Equal(t, int64(3), counts["customers"])
+}
+
+func TestNewStat(t *testing.T) {
+	type given struct {
+		column string
+		value  sql.NullString
+	}
+
+	var tests = []struct {
+		name  string
+		given given
+		want  Stat
+	}{
+		{
+			name:  "plain value",
+			given: given{column: "LIVE_TUPLES", value: sql.NullString{String: "42", Valid: true}},
+			want:  Stat{Name: "live tuples", Value: "42"},
+		},
+		{
+			name:  "null value",
+			given: given{column: "last_vacuum", value: sql.NullString{}},
+			want:  Stat{Name: "last vacuum", Value: "-"},
+		},
+		{
+			name:  "size in bytes",
+			given: given{column: "total_size_bytes", value: sql.NullString{String: "512", Valid: true}},
+			want:  Stat{Name: "total size", Value: "512 bytes"},
+		},
+		{
+			name:  "size in kilobytes",
+			given: given{column: "table_size_bytes", value: sql.NullString{String: "16384", Valid: true}},
+			want:  Stat{Name: "table size", Value: "16.0 KB (16384 bytes)"},
+		},
+		{
+			name:  "size in megabytes",
+			given: given{column: "indexes_size_bytes", value: sql.NullString{String: "3145728", Valid: true}},
+			want:  Stat{Name: "indexes size", Value: "3.0 MB (3145728 bytes)"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, newStat(tc.given.column, tc.given.value))
+		})
+	}
+}
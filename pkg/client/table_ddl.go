@@ -0,0 +1,126 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// tableDefinition holds the parts of a table read from the catalog,
+// for the databases with no native source of its CREATE TABLE statement, like Postgres and SQL Server.
+type tableDefinition struct {
+	// Name is the name of the table, qualified by its schema.
+	Name        string
+	Columns     []columnDefinition
+	Constraints []constraintDefinition
+	// Indexes holds the CREATE INDEX statements of the indexes that don't back a constraint.
+	Indexes []string
+	// Comments holds the statements that set the comments of the table and its columns.
+	Comments []string
+}
+
+// columnDefinition describes a column of a table whose CREATE TABLE statement is reconstructed.
+type columnDefinition struct {
+	Name     string
+	DataType string
+	Nullable bool
+	Default  string
+	// Identity is the identity clause of the column, e.g. GENERATED ALWAYS AS IDENTITY or IDENTITY(1,1).
+	Identity string
+}
+
+// constraintDefinition describes a constraint of a table, e.g. PRIMARY KEY (id).
+type constraintDefinition struct {
+	// Name is the name of the constraint, quoted as the database does if needed, like the names of the columns.
+	Name       string
+	Definition string
+}
+
+// String method returns the CREATE TABLE statement of the table,
+// followed by the statements that create its indexes and set its comments.
+func (t tableDefinition) String() string {
+	lines := make([]string, 0, len(t.Columns)+len(t.Constraints))
+	for _, column := range t.Columns {
+		line := strings.TrimSpace(column.Name + " " + column.DataType)
+		if column.Identity != "" {
+			line += " " + column.Identity
+		}
+		if column.Default != "" {
+			line += " DEFAULT " + column.Default
+		}
+		if !column.Nullable {
+			line += " NOT NULL"
+		}
+		lines = append(lines, "    "+line)
+	}
+
+	for _, constraint := range t.Constraints {
+		lines = append(lines, fmt.Sprintf("    CONSTRAINT %s %s", constraint.Name, constraint.Definition))
+	}
+
+	statements := []string{fmt.Sprintf("CREATE TABLE %s (\n%s\n)", t.Name, strings.Join(lines, ",\n"))}
+	statements = append(statements, t.Indexes...)
+	statements = append(statements, t.Comments...)
+
+	return joinStatements(statements)
+}
+
+// joinStatements function puts the given statements together, one after another, ended by a semicolon.
+func joinStatements(statements []string) string {
+	cleaned := make([]string, 0, len(statements))
+	for _, statement := range statements {
+		statement = strings.TrimSuffix(strings.TrimSpace(statement), ";")
+		if statement != "" {
+			cleaned = append(cleaned, statement+";")
+		}
+	}
+
+	return strings.Join(cleaned, "\n\n")
+}
+
+// quoteLiteral function returns the given text as a SQL string literal.
+func quoteLiteral(text string) string {
+	return "'" + strings.ReplaceAll(text, "'", "''") + "'"
+}
+
+// queryStrings runs a query and returns its rows as strings, NULL values are returned as empty strings.
+func queryStrings(ctx context.Context, db *sqlx.DB, query string, args ...any) ([][]string, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([][]string, 0)
+	for rows.Next() {
+		values := make([]sql.NullString, len(columns))
+		dest := make([]any, len(columns))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+
+		row := make([]string, len(columns))
+		for i, value := range values {
+			row[i] = value.String
+		}
+		result = append(result, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTableDefinitionString(t *testing.T) {
+	definition := tableDefinition{
+		Name: "public.users",
+		Columns: []columnDefinition{
+			{Name: "id", DataType: "integer", Identity: "GENERATED ALWAYS AS IDENTITY"},
+			{Name: "email", DataType: "text"},
+			{Name: "active", DataType: "boolean", Nullable: true, Default: "true"},
+		},
+		Constraints: []constraintDefinition{
+			{Name: "users_pkey", Definition: "PRIMARY KEY (id)"},
+			{Name: "users_email_key", Definition: "UNIQUE (email)"},
+		},
+		Indexes:  []string{"CREATE INDEX users_active_idx ON public.users USING btree (active)"},
+		Comments: []string{"COMMENT ON COLUMN public.users.email IS 'the user''s email'"},
+	}
+
+	require.Equal(t, `CREATE TABLE public.users (
+    id integer GENERATED ALWAYS AS IDENTITY NOT NULL,
+    email text NOT NULL,
+    active boolean DEFAULT true,
+    CONSTRAINT users_pkey PRIMARY KEY (id),
+    CONSTRAINT users_email_key UNIQUE (email)
+);
+
+CREATE INDEX users_active_idx ON public.users USING btree (active);
+
+COMMENT ON COLUMN public.users.email IS 'the user''s email';`, definition.String())
+}
+
+func TestQuoteLiteral(t *testing.T) {
+	require.Equal(t, "'plain'", quoteLiteral("plain"))
+	require.Equal(t, "'it''s'", quoteLiteral("it's"))
+}
+
+func TestSQLiteTableDDL(t *testing.T) {
+	sandboxDir := t.TempDir()
+	dbName := filepath.Join(sandboxDir, "ddl.db")
+
+	db, err := sqlx.Open("sqlite", dbName)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec(`
+		CREATE TABLE users (id INTEGER PRIMARY KEY, email TEXT NOT NULL UNIQUE);
+		CREATE INDEX users_email_idx ON users (email);
+		CREATE TRIGGER touch_users AFTER UPDATE ON users BEGIN SELECT 1; END;`)
+	require.NoError(t, err)
+
+	c := &Client{db: db, databaseQuerier: newSQLite(dbName, db)}
+
+	ddl, err := c.TableDDL(context.Background(), TableRef{Name: "users"})
+	require.NoError(t, err)
+	require.Equal(t, `CREATE TABLE users (id INTEGER PRIMARY KEY, email TEXT NOT NULL UNIQUE);
+
+CREATE INDEX users_email_idx ON users (email);
+
+CREATE TRIGGER touch_users AFTER UPDATE ON users BEGIN SELECT 1; END;`, ddl)
+
+	_, err = c.TableDDL(context.Background(), TableRef{Name: "missing"})
+	require.Error(t, err)
+}
@@ -0,0 +1,74 @@
+package client
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// formatValue function returns the text of a value read from a column of the given database type.
+// The database/sql drivers mostly return the basic Go types, but some return their own ones,
+// e.g. pgx returns the UUIDs as [16]byte, the arrays as slices and the rest of its types as pgtype values,
+// which are given back by their driver.Valuer implementation.
+func formatValue(v any, databaseType string) string {
+	switch val := v.(type) {
+	case nil:
+		return fmt.Sprint(val)
+	case string:
+		return val
+	case []byte:
+		// Check for both MySQL BLOBs and Postgres BYTEA
+		switch databaseType {
+		case "BLOB", "TINYBLOB", "MEDIUMBLOB", "LONGBLOB", "BYTEA":
+			// Safely represent the BLOB without printing raw binary
+			return fmt.Sprintf("[BLOB - %d bytes]", len(val))
+		default:
+			// It's a normal string/text type returned as []byte, safe to convert
+			return string(val)
+		}
+	case [16]byte:
+		if databaseType == "UUID" {
+			return fmt.Sprintf("%x-%x-%x-%x-%x", val[0:4], val[4:6], val[6:8], val[8:10], val[10:16])
+		}
+	case driver.Valuer:
+		value, err := val.Value()
+		if err != nil {
+			return fmt.Sprintf("%v", val)
+		}
+
+		return formatValue(value, databaseType)
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+		return formatArray(rv, strings.TrimPrefix(databaseType, "_"))
+	}
+
+	return fmt.Sprintf("%v", v)
+}
+
+// formatArray function returns the text of an array as Postgres writes it, e.g. {1,2,NULL},
+// quoting the elements that would be ambiguous otherwise.
+func formatArray(rv reflect.Value, elementType string) string {
+	elements := make([]string, rv.Len())
+	for i := range elements {
+		element := rv.Index(i).Interface()
+		if element == nil {
+			elements[i] = "NULL"
+			continue
+		}
+
+		text := formatValue(element, elementType)
+
+		ek := reflect.ValueOf(element).Kind()
+		if ek != reflect.Slice && ek != reflect.Array &&
+			(text == "" || strings.EqualFold(text, "NULL") || strings.ContainsAny(text, `{},"\ `)) {
+			text = `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(text) + `"`
+		}
+
+		elements[i] = text
+	}
+
+	return "{" + strings.Join(elements, ",") + "}"
+}
@@ -0,0 +1,61 @@
+package client
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatValue(t *testing.T) {
+	var tests = []struct {
+		name         string
+		value        any
+		databaseType string
+		want         string
+	}{
+		{name: "nil", value: nil, want: "<nil>"},
+		{name: "text", value: "hello", databaseType: "TEXT", want: "hello"},
+		{name: "text as bytes", value: []byte("hello"), databaseType: "VARCHAR", want: "hello"},
+		{name: "blob", value: []byte{0, 1, 2}, databaseType: "BYTEA", want: "[BLOB - 3 bytes]"},
+		{name: "integer", value: int64(42), databaseType: "INT8", want: "42"},
+		{
+			name:         "uuid",
+			value:        [16]byte{0x55, 0x0e, 0x84, 0x00, 0xe2, 0x9b, 0x41, 0xd4, 0xa7, 0x16, 0x44, 0x66, 0x55, 0x44, 0x00, 0x00},
+			databaseType: "UUID",
+			want:         "550e8400-e29b-41d4-a716-446655440000",
+		},
+		{
+			name: "pgtype uuid",
+			value: pgtype.UUID{
+				Bytes: [16]byte{0x55, 0x0e, 0x84, 0x00, 0xe2, 0x9b, 0x41, 0xd4, 0xa7, 0x16, 0x44, 0x66, 0x55, 0x44, 0x00, 0x00},
+				Valid: true,
+			},
+			databaseType: "UUID",
+			want:         "550e8400-e29b-41d4-a716-446655440000",
+		},
+		{name: "null pgtype", value: pgtype.Int4{}, databaseType: "INT4", want: "<nil>"},
+		{name: "pgtype numeric", value: pgtype.Numeric{Int: bigInt(12345), Exp: -2, Valid: true}, databaseType: "NUMERIC", want: "123.45"},
+		{name: "array", value: []any{int64(1), nil, int64(3)}, databaseType: "_INT4", want: "{1,NULL,3}"},
+		{name: "text array", value: []string{"a", "b c", `d"e`, ""}, databaseType: "_TEXT", want: `{a,"b c","d\"e",""}`},
+		{name: "nested array", value: [][]int64{{1, 2}, {3, 4}}, databaseType: "_INT8", want: "{{1,2},{3,4}}"},
+		{
+			name:         "timestamp",
+			value:        time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			databaseType: "TIMESTAMP",
+			want:         "2024-01-02 03:04:05 +0000 UTC",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, formatValue(tc.value, tc.databaseType))
+		})
+	}
+}
+
+func bigInt(n int64) *big.Int {
+	return big.NewInt(n)
+}
@@ -0,0 +1,43 @@
+package client
+
+import (
+	"context"
+	"slices"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// yugabyteDB struct is in charge of the queries of YugabyteDB, whose query layer is the one of Postgres.
+// It takes all the postgres queries, adding the tablets the tables are sharded into to their statistics.
+type yugabyteDB struct {
+	*postgres
+}
+
+// a validation to see if yugabyteDB is implementing databaseQuerier.
+var _ databaseQuerier = (*yugabyteDB)(nil)
+
+// returns a pointer to a yugabyteDB, it receives an schema as a parameter.
+func newYugabyteDB(dbName, schema string, db *sqlx.DB) *yugabyteDB {
+	return &yugabyteDB{postgres: newPostgres(dbName, schema, db)}
+}
+
+// TableStats returns the statistics of the postgres querier, followed by the ones of the sharding of the table,
+// as given by yb_table_properties: its tablets, its hash key columns and whether it's colocated.
+func (y *yugabyteDB) TableStats(ctx context.Context, table TableRef) ([]Stat, error) {
+	stats, err := y.postgres.TableStats(ctx, table)
+	if err != nil {
+		return nil, err
+	}
+
+	sharding, err := queryStats(ctx, y.db, `
+		SELECT
+			num_tablets AS tablets,
+			num_hash_key_columns AS hash_key_columns,
+			is_colocated AS colocated
+		FROM yb_table_properties($1::text::regclass)`, pgRelation(table.Schema, table.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.Concat(stats, sharding), nil
+}
@@ -1,7 +1,10 @@
 package command
 
 import (
+	"fmt"
+	"net"
 	"os"
+	"strings"
 
 	"charm.land/bubbles/v2/key"
 )
@@ -26,11 +29,22 @@ type Options struct {
 	SSHPass          string `json:"-"`
 	SSHKeyFile       string `json:"ssh_key_file"`
 	SSHKeyPassphrase string `json:"ssh_key_passphrase"`
+	// SSHKnownHosts is the known_hosts file the key of the ssh host is checked against.
+	SSHKnownHosts string `json:"ssh_known_hosts"`
+	// SSHForward forwards a local port to the database through the ssh tunnel, which works for every driver.
+	SSHForward bool `json:"ssh_forward"`
+	// SSHJumps are the ssh servers the tunnel jumps through, in order, before reaching the ssh host.
+	SSHJumps []SSHHop `json:"ssh_jumps,omitempty"`
+	// ForwardedAddr is the host:port of the database the local port is forwarded to through the ssh tunnel,
+	// set along with the local address in place of the host and port of the connection.
+	ForwardedAddr string `json:"-"`
 	// SSL connection params.
 	SSLCert     string `json:"ssl_cert"`
 	SSLKey      string `json:"ssl_key"`
 	SSLPassword string `json:"-"`
 	SSLRootcert string `json:"ssl_rootcert"`
+	// postgres specific: the database/sql driver, pgx by default or pq for lib/pq.
+	PGDriver string `json:"pg_driver"`
 	// oracle specific.
 	TraceFile string `json:"trace_file"`
 	SSLVerify string `json:"ssl_verify"`
@@ -41,6 +55,53 @@ type Options struct {
 	ConnectionTimeout      string `json:"connection_timeout"`
 	// Read Only mode.
 	ReadOnly bool `json:"read_only"`
+	// Profile is the name of the connection profile in use, if any.
+	// It's not persisted since the name is the key of the profile.
+	Profile string `json:"-"`
+}
+
+// SSHHop is an ssh server the ssh tunnel jumps through, with its own credentials.
+// The host can be an alias of ~/.ssh/config, whose settings are used unless they're given.
+type SSHHop struct {
+	Host          string `json:"host"`
+	Port          string `json:"port"`
+	User          string `json:"user"`
+	Pass          string `json:"-"`
+	KeyFile       string `json:"key_file"`
+	KeyPassphrase string `json:"key_passphrase"`
+	// KnownHosts is the known_hosts file the key of the server is checked against.
+	KnownHosts string `json:"known_hosts"`
+}
+
+// ParseSSHJumps parses the ssh servers to jump through, written as a comma separated list of [user@]host[:port],
+// as given to --ssh-jump.
+func ParseSSHJumps(value string) ([]SSHHop, error) {
+	var jumps []SSHHop
+
+	for _, destination := range strings.Split(value, ",") {
+		destination = strings.TrimSpace(destination)
+		if destination == "" {
+			continue
+		}
+
+		var jump SSHHop
+		if i := strings.LastIndex(destination, "@"); i >= 0 {
+			jump.User, destination = destination[:i], destination[i+1:]
+		}
+
+		jump.Host = destination
+		if host, port, err := net.SplitHostPort(destination); err == nil {
+			jump.Host, jump.Port = host, port
+		}
+
+		if jump.Host == "" {
+			return nil, fmt.Errorf("invalid ssh jump %q, it must be written as [user@]host[:port]", destination)
+		}
+
+		jumps = append(jumps, jump)
+	}
+
+	return jumps, nil
 }
 
 type TUIKeyMap struct {
@@ -52,6 +113,21 @@ type TUIKeyMap struct {
 	BeginningOfLine key.Binding
 	Help            key.Binding
 	Quit            key.Binding
+	Favorite        key.Binding
+	RefreshCatalog  key.Binding
+	Copy            key.Binding
+	Save            key.Binding
+	ExportDiagram   key.Binding
+	RowCounts       key.Binding
+	Activity        key.Binding
+	Locks           key.Binding
+	CountRows       key.Binding
+	NextColumn      key.Binding
+	PrevColumn      key.Binding
+	FollowReference key.Binding
+	ReferencingRows key.Binding
+	Back            key.Binding
+	Forward         key.Binding
 	Navigation      TUINavigationKeyMap
 	Editor          EditorKeyMap
 }
@@ -62,9 +138,10 @@ func (k TUIKeyMap) ShortHelp() []key.Binding {
 
 func (k TUIKeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
-		{k.NextTab, k.PrevTab, k.PageTop, k.PageBottom, k.EndOfLine, k.BeginningOfLine},
+		{k.NextTab, k.PrevTab, k.PageTop, k.PageBottom, k.EndOfLine, k.BeginningOfLine, k.Favorite, k.RefreshCatalog, k.Copy, k.Save, k.ExportDiagram, k.RowCounts, k.Activity, k.Locks, k.CountRows},
+		{k.NextColumn, k.PrevColumn, k.FollowReference, k.ReferencingRows, k.Back, k.Forward},
 		{k.Navigation.Up, k.Navigation.Down, k.Navigation.Left, k.Navigation.Right, k.Help, k.Quit},
-		{k.Editor.Up, k.Editor.Down, k.Editor.Left, k.Editor.Right, k.Editor.Insert, k.Editor.Normal, k.Editor.ExecuteQuery, k.Editor.ExecuteSingleQuery},
+		{k.Editor.Up, k.Editor.Down, k.Editor.Left, k.Editor.Right, k.Editor.Insert, k.Editor.Normal, k.Editor.ExecuteQuery, k.Editor.ExecuteSingleQuery, k.Editor.Complete},
 	}
 }
 
@@ -82,6 +159,7 @@ type EditorKeyMap struct {
 	// Actions.
 	ExecuteQuery       key.Binding
 	ExecuteSingleQuery key.Binding
+	Complete           key.Binding
 }
 
 type TUINavigationKeyMap struct {
@@ -125,6 +203,66 @@ func DefaultKeyMap() *TUIKeyMap {
 			key.WithKeys("ctrl+c"),
 			key.WithHelp("ctrl+c", "quit"),
 		),
+		Favorite: key.NewBinding(
+			key.WithKeys("f"),
+			key.WithHelp("f", "toggle favorite table (sidebar database graph)"),
+		),
+		RefreshCatalog: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "refresh the catalog (sidebar database graph)"),
+		),
+		Copy: key.NewBinding(
+			key.WithKeys("y"),
+			key.WithHelp("y", "copy the text of the tab to the clipboard (result set view)"),
+		),
+		Save: key.NewBinding(
+			key.WithKeys("ctrl+s"),
+			key.WithHelp("ctrl+s", "save the text of the tab to a file (result set view)"),
+		),
+		ExportDiagram: key.NewBinding(
+			key.WithKeys("e"),
+			key.WithHelp("e", "save the ER diagram of the schema or table (sidebar database graph)"),
+		),
+		RowCounts: key.NewBinding(
+			key.WithKeys("#"),
+			key.WithHelp("#", "toggle the approximate row counts of the tables (sidebar database graph)"),
+		),
+		Activity: key.NewBinding(
+			key.WithKeys("f9"),
+			key.WithHelp("f9", "open the activity monitor of the server"),
+		),
+		Locks: key.NewBinding(
+			key.WithKeys("f7"),
+			key.WithHelp("f7", "open the locks and blocking chains of the server"),
+		),
+		CountRows: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "count the exact rows of the table (result set stats)"),
+		),
+		NextColumn: key.NewBinding(
+			key.WithKeys(">"),
+			key.WithHelp(">", "select the next column (result set data)"),
+		),
+		PrevColumn: key.NewBinding(
+			key.WithKeys("<"),
+			key.WithHelp("<", "select the previous column (result set data)"),
+		),
+		FollowReference: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "open the row referenced by the selected column (result set data)"),
+		),
+		ReferencingRows: key.NewBinding(
+			key.WithKeys("R"),
+			key.WithHelp("R", "list the rows referencing the selected row (result set data)"),
+		),
+		Back: key.NewBinding(
+			key.WithKeys("["),
+			key.WithHelp("[", "go back to the previous rows opened (result set data)"),
+		),
+		Forward: key.NewBinding(
+			key.WithKeys("]"),
+			key.WithHelp("]", "go forward to the next rows opened (result set data)"),
+		),
 		Navigation: TUINavigationKeyMap{
 			Up: key.NewBinding(
 				key.WithKeys("ctrl+k"),
@@ -180,6 +318,10 @@ func DefaultKeyMap() *TUIKeyMap {
 				key.WithKeys("ctrl+r"),
 				key.WithHelp("ctrl+r", "execute single query"),
 			),
+			Complete: key.NewBinding(
+				key.WithKeys("tab"),
+				key.WithHelp("tab", "complete the name of a table or column (insert mode)"),
+			),
 		},
 	}
 }
@@ -51,3 +51,42 @@ func TestSetEnvValues(t *testing.T) {
 	assert.Equal(t, "5432", result.Port)
 	assert.Equal(t, "public", result.Schema)
 }
+
+func TestParseSSHJumps(t *testing.T) {
+	var cases = []struct {
+		name  string
+		value string
+		want  []SSHHop
+		err   bool
+	}{
+		{name: "empty", value: ""},
+		{
+			name:  "single alias",
+			value: "bastion-prod",
+			want:  []SSHHop{{Host: "bastion-prod"}},
+		},
+		{
+			name:  "chain",
+			value: "edge@bastion-edge.example.com:2222, core@10.0.0.5,[fd00::1]:22",
+			want: []SSHHop{
+				{Host: "bastion-edge.example.com", Port: "2222", User: "edge"},
+				{Host: "10.0.0.5", User: "core"},
+				{Host: "fd00::1", Port: "22"},
+			},
+		},
+		{name: "missing host", value: "edge@:22", err: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			jumps, err := ParseSSHJumps(tc.value)
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, jumps)
+		})
+	}
+}
@@ -30,6 +30,18 @@ type Config struct {
 	Limit    uint `fig:"limit" default:"100"`
 }
 
+// SSHJump is an ssh server the ssh tunnel jumps through, with its own credentials.
+type SSHJump struct {
+	Host    string
+	Port    string
+	User    string
+	Pass    string `fig:"pass"`
+	KeyFile string `fig:"key-file"`
+	KeyPass string `fig:"key-pass"`
+	// KnownHosts is the known_hosts file the key of the server is checked against.
+	KnownHosts string `fig:"known-hosts"`
+}
+
 type KeyMapConfig struct {
 	KeyBindings KeyBindings
 }
@@ -52,6 +64,10 @@ type Profile struct {
 	SSHPass          string `fig:"ssh-pass"`
 	SSHKeyFile       string `fig:"ssh-key-file"`
 	SSHKeyPassphrase string `fig:"ssh-key-pass"`
+	SSHKnownHosts    string `fig:"ssh-known-hosts"`
+	SSHForward       bool   `fig:"ssh-forward"`
+	// SSHJumps are the ssh servers the tunnel jumps through, in order, before the ssh host.
+	SSHJumps []SSHJump `fig:"ssh-jumps"`
 
 	// SSL connection params.
 	SSL string `default:"disable"`
@@ -71,6 +87,9 @@ type Profile struct {
 	TrustServerCertificate string `fig:"trust-server-certificate"`
 	ConnectionTimeout      string `fig:"connection-timeout"`
 
+	// postgres specific.
+	PGDriver string `fig:"pg-driver"`
+
 	// Read Only mode.
 	ReadOnly bool `fig:"readonly"`
 }
@@ -84,6 +103,21 @@ type KeyBindings struct {
 	BeginningOfLine string `fig:"beginning-of-line"   default:"0"`
 	Help            string `fig:"help"   default:"?"`
 	Quit            string `fig:"quit"   default:"ctrl+c"`
+	Favorite        string `fig:"favorite"   default:"f"`
+	RefreshCatalog  string `fig:"refresh-catalog"   default:"r"`
+	Copy            string `fig:"copy"   default:"y"`
+	Save            string `fig:"save"   default:"ctrl+s"`
+	ExportDiagram   string `fig:"export-diagram"   default:"e"`
+	RowCounts       string `fig:"row-counts"   default:"#"`
+	Activity        string `fig:"activity"   default:"f9"`
+	Locks           string `fig:"locks"   default:"f7"`
+	CountRows       string `fig:"count-rows"   default:"c"`
+	NextColumn      string `fig:"next-column"   default:">"`
+	PrevColumn      string `fig:"prev-column"   default:"<"`
+	FollowReference string `fig:"follow-reference"   default:"enter"`
+	ReferencingRows string `fig:"referencing-rows"   default:"R"`
+	Back            string `fig:"back"   default:"["`
+	Forward         string `fig:"forward"   default:"]"`
 	Navigation      NavigationBindgins
 	Editor          EditorKeyMap
 }
@@ -102,6 +136,7 @@ type EditorKeyMap struct {
 	// Actions.
 	ExecuteQuery       string `fig:"execute-query" default:"ctrl+e"`
 	ExecuteSingleQuery string `fig:"execute-single-query" default:"ctrl+r"`
+	Complete           string `fig:"complete" default:"tab"`
 }
 
 type NavigationBindgins struct {
@@ -186,12 +221,35 @@ func Init(configName string) (command.Options, error) {
 		SSHPass:                db.SSHPass,
 		SSHKeyFile:             db.SSHKeyFile,
 		SSHKeyPassphrase:       db.SSHKeyPassphrase,
+		SSHKnownHosts:          db.SSHKnownHosts,
+		SSHForward:             db.SSHForward,
+		SSHJumps:               sshHops(db.SSHJumps),
 		ReadOnly:               db.ReadOnly,
+		PGDriver:               db.PGDriver,
+		Profile:                db.Name,
 	}
 
 	return opts, nil
 }
 
+// sshHops function returns the ssh jumps of a profile as the hops of the connection options.
+func sshHops(jumps []SSHJump) []command.SSHHop {
+	var hops []command.SSHHop
+	for _, jump := range jumps {
+		hops = append(hops, command.SSHHop{
+			Host:          jump.Host,
+			Port:          jump.Port,
+			User:          jump.User,
+			Pass:          jump.Pass,
+			KeyFile:       jump.KeyFile,
+			KeyPassphrase: jump.KeyPass,
+			KnownHosts:    jump.KnownHosts,
+		})
+	}
+
+	return hops
+}
+
 func SetupKeyMap() (*command.TUIKeyMap, error) {
 	var kbc KeyMapConfig
 	var tkb command.TUIKeyMap
@@ -219,6 +277,21 @@ func SetupKeyMap() (*command.TUIKeyMap, error) {
 		BeginningOfLine: key.NewBinding(key.WithKeys(kbc.KeyBindings.BeginningOfLine), key.WithHelp(kbc.KeyBindings.BeginningOfLine, "navigate all the way to the left of the table")),
 		Help:            key.NewBinding(key.WithKeys(kbc.KeyBindings.Help), key.WithHelp(kbc.KeyBindings.Help, "toggle help")),
 		Quit:            key.NewBinding(key.WithKeys(kbc.KeyBindings.Quit), key.WithHelp(kbc.KeyBindings.Quit, "quit")),
+		Favorite:        key.NewBinding(key.WithKeys(kbc.KeyBindings.Favorite), key.WithHelp(kbc.KeyBindings.Favorite, "toggle favorite table (sidebar database graph)")),
+		RefreshCatalog:  key.NewBinding(key.WithKeys(kbc.KeyBindings.RefreshCatalog), key.WithHelp(kbc.KeyBindings.RefreshCatalog, "refresh the catalog (sidebar database graph)")),
+		Copy:            key.NewBinding(key.WithKeys(kbc.KeyBindings.Copy), key.WithHelp(kbc.KeyBindings.Copy, "copy the text of the tab to the clipboard (result set view)")),
+		Save:            key.NewBinding(key.WithKeys(kbc.KeyBindings.Save), key.WithHelp(kbc.KeyBindings.Save, "save the text of the tab to a file (result set view)")),
+		ExportDiagram:   key.NewBinding(key.WithKeys(kbc.KeyBindings.ExportDiagram), key.WithHelp(kbc.KeyBindings.ExportDiagram, "save the ER diagram of the schema or table (sidebar database graph)")),
+		RowCounts:       key.NewBinding(key.WithKeys(kbc.KeyBindings.RowCounts), key.WithHelp(kbc.KeyBindings.RowCounts, "toggle the approximate row counts of the tables (sidebar database graph)")),
+		Activity:        key.NewBinding(key.WithKeys(kbc.KeyBindings.Activity), key.WithHelp(kbc.KeyBindings.Activity, "open the activity monitor of the server")),
+		Locks:           key.NewBinding(key.WithKeys(kbc.KeyBindings.Locks), key.WithHelp(kbc.KeyBindings.Locks, "open the locks and blocking chains of the server")),
+		CountRows:       key.NewBinding(key.WithKeys(kbc.KeyBindings.CountRows), key.WithHelp(kbc.KeyBindings.CountRows, "count the exact rows of the table (result set stats)")),
+		NextColumn:      key.NewBinding(key.WithKeys(kbc.KeyBindings.NextColumn), key.WithHelp(kbc.KeyBindings.NextColumn, "select the next column (result set data)")),
+		PrevColumn:      key.NewBinding(key.WithKeys(kbc.KeyBindings.PrevColumn), key.WithHelp(kbc.KeyBindings.PrevColumn, "select the previous column (result set data)")),
+		FollowReference: key.NewBinding(key.WithKeys(kbc.KeyBindings.FollowReference), key.WithHelp(kbc.KeyBindings.FollowReference, "open the row referenced by the selected column (result set data)")),
+		ReferencingRows: key.NewBinding(key.WithKeys(kbc.KeyBindings.ReferencingRows), key.WithHelp(kbc.KeyBindings.ReferencingRows, "list the rows referencing the selected row (result set data)")),
+		Back:            key.NewBinding(key.WithKeys(kbc.KeyBindings.Back), key.WithHelp(kbc.KeyBindings.Back, "go back to the previous rows opened (result set data)")),
+		Forward:         key.NewBinding(key.WithKeys(kbc.KeyBindings.Forward), key.WithHelp(kbc.KeyBindings.Forward, "go forward to the next rows opened (result set data)")),
 		Navigation: command.TUINavigationKeyMap{
 			Up:    key.NewBinding(key.WithKeys(kbc.KeyBindings.Navigation.Up), key.WithHelp(kbc.KeyBindings.Navigation.Up, "Toggle to the panel above")),
 			Down:  key.NewBinding(key.WithKeys(kbc.KeyBindings.Navigation.Down), key.WithHelp(kbc.KeyBindings.Navigation.Down, "Toggle to the panel below")),
@@ -234,6 +307,7 @@ func SetupKeyMap() (*command.TUIKeyMap, error) {
 			Normal:             key.NewBinding(key.WithKeys(kbc.KeyBindings.Editor.Normal), key.WithHelp(kbc.KeyBindings.Editor.Normal, "normal mode")),
 			ExecuteQuery:       key.NewBinding(key.WithKeys(kbc.KeyBindings.Editor.ExecuteQuery), key.WithHelp(kbc.KeyBindings.Editor.ExecuteQuery, "execute queries in the editor")),
 			ExecuteSingleQuery: key.NewBinding(key.WithKeys(kbc.KeyBindings.Editor.ExecuteSingleQuery), key.WithHelp(kbc.KeyBindings.Editor.ExecuteSingleQuery, "execute single query")),
+			Complete:           key.NewBinding(key.WithKeys(kbc.KeyBindings.Editor.Complete), key.WithHelp(kbc.KeyBindings.Editor.Complete, "complete the name of a table or column (insert mode)")),
 		},
 	}
 
@@ -5,6 +5,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/danvergara/dblab/pkg/command"
 	"github.com/danvergara/dblab/pkg/config"
 )
 
@@ -177,6 +178,17 @@ func TestInit(t *testing.T) {
 	}
 }
 
+func TestInitSSHJumps(t *testing.T) {
+	opts, err := config.Init("ssh-jumps")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "bastion-db", opts.SSHHost)
+	assert.Equal(t, []command.SSHHop{
+		{Host: "bastion-edge.example.com", Port: "2222", User: "edge", KeyFile: "~/.ssh/edge.pem", KnownHosts: "~/.ssh/known_hosts_edge"},
+		{Host: "bastion-core", User: "core", Pass: "password"},
+	}, opts.SSHJumps)
+}
+
 func TestSetupKeybindings(t *testing.T) {
 	kb, err := config.SetupKeyMap()
 	assert.NoError(t, err)
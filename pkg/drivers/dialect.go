@@ -0,0 +1,125 @@
+package drivers
+
+import (
+	"errors"
+	"net"
+	"slices"
+	"sync"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/danvergara/dblab/pkg/command"
+)
+
+// ErrSSHNotSupported is returned by the dialects of the databases the database/sql driver can't be reached
+// through an ssh tunnel with.
+var ErrSSHNotSupported = errors.New("connecting through an ssh tunnel is not supported")
+
+// DialFunc opens a connection to the given address, e.g. through an ssh tunnel.
+type DialFunc func(network, address string) (net.Conn, error)
+
+// Dialect holds everything dblab does differently for a database: how the connection string is built,
+// how the session is set up, how the rows are paginated and how the catalog is organized.
+// Each database registers its dialect under its driver name, so adding a database means adding its dialect package.
+type Dialect interface {
+	// Name is the driver name of the database, as given to --driver and in the profiles.
+	Name() string
+	// Aliases are the other driver names the database is known by, e.g. postgresql.
+	Aliases() []string
+	// HandlesURL tells whether a connection URL given to --url is for the database.
+	HandlesURL(url string) bool
+	// DSNFromURL returns the connection string for the database/sql driver out of the URL given to --url,
+	// along with the options updated with the driver to open it with.
+	DSNFromURL(opts command.Options) (string, command.Options, error)
+	// DSN returns the connection string for the database/sql driver out of the connection flags,
+	// along with the options updated with the driver to open it with.
+	DSN(opts command.Options) (string, command.Options, error)
+	// SessionSetup returns the statements run right after connecting, to set the schema in use
+	// and to make the session read-only, if asked to.
+	SessionSetup(schema string, readOnly bool) []string
+	// SelectPage returns the query that reads a page of the rows of a table or view,
+	// given as it's written in the FROM clause, along with its WHERE clause, if any.
+	SelectPage(from, where string, limit uint, offset int) string
+	// HasSchemas tells whether the catalog of the database is organized in schemas.
+	HasSchemas() bool
+	// QuoteIdentifier returns a name of a table, schema, view or column quoted as the database does,
+	// e.g. "name" on Postgres or [name] on SQL Server, so names with mixed case, spaces, quotes or reserved words
+	// can be written in the statements generated by dblab.
+	QuoteIdentifier(name string) string
+	// Placeholders is the format of the placeholders of the parameters of a query.
+	Placeholders() sq.PlaceholderFormat
+	// CurrentSchemaQuery returns the query that reads the schema the unqualified names resolve to,
+	// or an empty string if the database has no schemas.
+	CurrentSchemaQuery() string
+	// RegisterSSHDialer makes the database/sql driver reach the database through the given dialer,
+	// e.g. an ssh tunnel. It returns ErrSSHNotSupported if the driver can't be given a dialer.
+	RegisterSSHDialer(dial DialFunc) error
+}
+
+// ValueFormatter is implemented by the dialects whose database/sql driver returns values
+// that don't print as the database shows them, e.g. the decimals of DuckDB.
+type ValueFormatter interface {
+	// FormatValue returns the text of a value read from a column of the given database type,
+	// and whether the value was formatted, otherwise it's printed as it comes.
+	FormatValue(value any, databaseType string) (string, bool)
+}
+
+// Flavored is implemented by the dialects of a wire protocol spoken by other servers too,
+// e.g. the one of Postgres, spoken by CockroachDB, YugabyteDB and Redshift.
+// The server is told apart right after connecting, before the session is set up.
+type Flavored interface {
+	// FlavorQuery returns the query whose single value tells the servers apart, e.g. SELECT version().
+	FlavorQuery() string
+	// Flavor returns the name of the server out of the value read by FlavorQuery,
+	// or an empty string if it's the database of the dialect itself.
+	Flavor(value string) string
+	// FlavorSessionSetup returns the statements run right after connecting to the given flavor,
+	// in place of the ones of SessionSetup. It returns an error if the flavor can't set up the session as asked.
+	FlavorSessionSetup(flavor, schema string, readOnly bool) ([]string, error)
+}
+
+var (
+	dialectsMu sync.RWMutex
+	dialects   []Dialect
+)
+
+// Register makes a dialect available by its name and aliases.
+// It's meant to be called from the init function of the dialect package.
+func Register(d Dialect) {
+	dialectsMu.Lock()
+	defer dialectsMu.Unlock()
+
+	if slices.ContainsFunc(dialects, func(registered Dialect) bool { return registered.Name() == d.Name() }) {
+		panic("drivers: Register called twice for dialect " + d.Name())
+	}
+
+	dialects = append(dialects, d)
+}
+
+// Lookup returns the dialect of a driver, given its name or one of its aliases.
+func Lookup(driver string) (Dialect, bool) {
+	dialectsMu.RLock()
+	defer dialectsMu.RUnlock()
+
+	for _, d := range dialects {
+		if d.Name() == driver || slices.Contains(d.Aliases(), driver) {
+			return d, true
+		}
+	}
+
+	return nil, false
+}
+
+// ForURL returns the dialect of the database a connection URL is for.
+func ForURL(url string) (Dialect, bool) {
+	dialectsMu.RLock()
+	defer dialectsMu.RUnlock()
+
+	for _, d := range dialects {
+		if d.HandlesURL(url) {
+			return d, true
+		}
+	}
+
+	return nil, false
+}
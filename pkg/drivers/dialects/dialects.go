@@ -0,0 +1,13 @@
+// Package dialects registers the dialects of every database dblab supports.
+// It's imported for its side effects by the packages looking the dialects up.
+package dialects
+
+import (
+	_ "github.com/danvergara/dblab/pkg/drivers/clickhouse"
+	_ "github.com/danvergara/dblab/pkg/drivers/duckdb"
+	_ "github.com/danvergara/dblab/pkg/drivers/mysql"
+	_ "github.com/danvergara/dblab/pkg/drivers/oracle"
+	_ "github.com/danvergara/dblab/pkg/drivers/postgres"
+	_ "github.com/danvergara/dblab/pkg/drivers/sqlite"
+	_ "github.com/danvergara/dblab/pkg/drivers/sqlserver"
+)
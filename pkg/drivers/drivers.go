@@ -1,11 +1,17 @@
 package drivers
 
 const (
-	Postgres    string = "postgres"
-	PostgresSSH string = "postgres+ssh"
-	PostgreSQL  string = "postgresql"
-	MySQL       string = "mysql"
-	SQLite      string = "sqlite"
-	Oracle      string = "oracle"
-	SQLServer   string = "sqlserver"
+	Postgres      string = "postgres"
+	PostgresSSH   string = "postgres+ssh"
+	PostgreSQL    string = "postgresql"
+	Pgx           string = "pgx"
+	PgxSSH        string = "pgx+ssh"
+	MySQL         string = "mysql"
+	MariaDB       string = "mariadb"
+	SQLite        string = "sqlite"
+	Oracle        string = "oracle"
+	SQLServer     string = "sqlserver"
+	DuckDB        string = "duckdb"
+	ClickHouse    string = "clickhouse"
+	ClickHouseSSH string = "clickhouse+ssh"
 )
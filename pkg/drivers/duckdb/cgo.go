@@ -0,0 +1,31 @@
+//go:build cgo
+
+package duckdb
+
+import (
+	"fmt"
+
+	gduckdb "github.com/marcboeker/go-duckdb"
+)
+
+// checkDriver tells the DuckDB driver is available, since dblab was built with cgo.
+func checkDriver() error {
+	return nil
+}
+
+// FormatValue returns the text of the decimals, intervals and UUIDs,
+// which the driver returns as structs and raw bytes.
+func (dialect) FormatValue(value any, databaseType string) (string, bool) {
+	switch v := value.(type) {
+	case gduckdb.Decimal:
+		return formatDecimal(v.Value, int(v.Scale)), true
+	case gduckdb.Interval:
+		return formatInterval(v.Months, v.Days, v.Micros), true
+	case []byte:
+		if databaseType == "UUID" && len(v) == 16 {
+			return fmt.Sprintf("%x-%x-%x-%x-%x", v[0:4], v[4:6], v[6:8], v[8:10], v[10:16]), true
+		}
+	}
+
+	return "", false
+}
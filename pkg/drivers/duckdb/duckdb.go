@@ -0,0 +1,207 @@
+// Package duckdb is the dialect of DuckDB.
+// The driver is github.com/marcboeker/go-duckdb, which embeds DuckDB through cgo,
+// so the dialect can only connect if dblab was built with cgo enabled.
+package duckdb
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/danvergara/dblab/pkg/command"
+	"github.com/danvergara/dblab/pkg/drivers"
+)
+
+const urlPrefix = "duckdb:"
+
+var (
+	// ErrCgoRequired is returned when connecting to DuckDB with a dblab built without cgo,
+	// which the DuckDB driver needs.
+	ErrCgoRequired = errors.New("dblab was built without cgo, which the duckdb driver requires: build it with CGO_ENABLED=1")
+	// ErrReadOnlyInMemory is returned when an in-memory database is asked to be opened read-only,
+	// which DuckDB refuses, since it would be empty.
+	ErrReadOnlyInMemory = errors.New("an in-memory duckdb database can't be opened read-only")
+)
+
+func init() {
+	drivers.Register(dialect{})
+}
+
+type dialect struct{}
+
+func (dialect) Name() string {
+	return drivers.DuckDB
+}
+
+func (dialect) Aliases() []string {
+	return nil
+}
+
+// HandlesURL tells whether the URL is a duckdb: URL, e.g. duckdb:analytics.duckdb or duckdb:///data/analytics.duckdb.
+func (dialect) HandlesURL(url string) bool {
+	return strings.HasPrefix(url, urlPrefix)
+}
+
+// DSNFromURL returns the path to the database file given in the URL, along with its query parameters,
+// which are the configuration options of DuckDB, e.g. duckdb:analytics.duckdb?threads=4.
+// A URL with no path opens an in-memory database, e.g. to query Parquet or CSV files.
+func (dialect) DSNFromURL(opts command.Options) (string, command.Options, error) {
+	if err := checkDriver(); err != nil {
+		return "", opts, err
+	}
+
+	return dsnFromURL(opts)
+}
+
+// DSN returns the path to the database file given as the database name.
+func (dialect) DSN(opts command.Options) (string, command.Options, error) {
+	if err := checkDriver(); err != nil {
+		return "", opts, err
+	}
+
+	conn, err := dsn(opts.DBName, "", opts.ReadOnly)
+	return conn, opts, err
+}
+
+// dsnFromURL builds the DSN out of a duckdb: URL, see DSNFromURL.
+func dsnFromURL(opts command.Options) (string, command.Options, error) {
+	opts.Driver = drivers.DuckDB
+
+	path, query, _ := strings.Cut(strings.TrimPrefix(opts.URL, urlPrefix), "?")
+	// duckdb:///data/analytics.duckdb is an absolute path, duckdb://analytics.duckdb a relative one.
+	path = strings.TrimPrefix(path, "//")
+
+	if opts.DBName == "" {
+		opts.DBName = path
+	}
+
+	conn, err := dsn(path, query, opts.ReadOnly)
+
+	return conn, opts, err
+}
+
+func (dialect) SessionSetup(schema string, _ bool) []string {
+	// the read-only mode is an option of the database, set when opening it, see dsn.
+	if schema != "" {
+		return []string{fmt.Sprintf("SET search_path = '%s'", strings.ReplaceAll(schema, "'", "''"))}
+	}
+
+	return nil
+}
+
+func (dialect) SelectPage(from, where string, limit uint, offset int) string {
+	return fmt.Sprintf("SELECT * FROM %s%s LIMIT %d OFFSET %d;", from, where, limit, offset)
+}
+
+func (dialect) HasSchemas() bool {
+	return true
+}
+
+func (dialect) QuoteIdentifier(name string) string {
+	return QuoteIdentifier(name)
+}
+
+func (dialect) Placeholders() sq.PlaceholderFormat {
+	return sq.Question
+}
+
+func (dialect) CurrentSchemaQuery() string {
+	return "SELECT current_schema()"
+}
+
+// RegisterSSHDialer is not supported, since DuckDB is embedded, there's no server to reach through a tunnel.
+func (dialect) RegisterSSHDialer(drivers.DialFunc) error {
+	return drivers.ErrSSHNotSupported
+}
+
+// QuoteIdentifier returns a name between double quotes, with the double quotes in it doubled.
+func QuoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// dsn returns the DSN of the go-duckdb driver: the path to the database file, empty for an in-memory database,
+// followed by the configuration options as query parameters.
+// The read-only mode is the access_mode option, which also lets other processes open the file for reading.
+func dsn(path, rawQuery string, readOnly bool) (string, error) {
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return "", fmt.Errorf("invalid duckdb url parameters: %w", err)
+	}
+
+	if readOnly {
+		if path == "" || path == ":memory:" {
+			return "", ErrReadOnlyInMemory
+		}
+		query.Set("access_mode", "read_only")
+	}
+
+	if len(query) == 0 {
+		return path, nil
+	}
+
+	return path + "?" + query.Encode(), nil
+}
+
+// formatDecimal returns a decimal, given as its unscaled value and its scale, e.g. 12345 and 2 are 123.45.
+func formatDecimal(value *big.Int, scale int) string {
+	if value == nil {
+		return ""
+	}
+
+	digits := new(big.Int).Abs(value).String()
+	if scale > 0 {
+		if len(digits) <= scale {
+			digits = strings.Repeat("0", scale-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-scale] + "." + digits[len(digits)-scale:]
+	}
+
+	if value.Sign() < 0 {
+		return "-" + digits
+	}
+
+	return digits
+}
+
+// formatInterval returns an interval as DuckDB prints it, e.g. 1 month 2 days 03:00:00.
+func formatInterval(months, days int32, micros int64) string {
+	var parts []string
+
+	plural := func(n int64, unit string) string {
+		if n == 1 || n == -1 {
+			return fmt.Sprintf("%d %s", n, unit)
+		}
+		return fmt.Sprintf("%d %ss", n, unit)
+	}
+
+	if years := months / 12; years != 0 {
+		parts = append(parts, plural(int64(years), "year"))
+	}
+	if months%12 != 0 {
+		parts = append(parts, plural(int64(months%12), "month"))
+	}
+	if days != 0 {
+		parts = append(parts, plural(int64(days), "day"))
+	}
+
+	if micros != 0 || len(parts) == 0 {
+		sign := ""
+		if micros < 0 {
+			sign, micros = "-", -micros
+		}
+
+		d := time.Duration(micros) * time.Microsecond
+		clock := fmt.Sprintf("%s%02d:%02d:%02d", sign, int64(d.Hours()), int64(d.Minutes())%60, int64(d.Seconds())%60)
+		if fraction := micros % 1e6; fraction != 0 {
+			clock += strings.TrimRight(fmt.Sprintf(".%06d", fraction), "0")
+		}
+		parts = append(parts, clock)
+	}
+
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,56 @@
+package duckdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/danvergara/dblab/pkg/command"
+	"github.com/danvergara/dblab/pkg/drivers"
+)
+
+func TestDSNFromURL(t *testing.T) {
+	var cases = []struct {
+		name     string
+		url      string
+		readOnly bool
+		want     string
+		dbName   string
+	}{
+		{name: "relative path", url: "duckdb:analytics.duckdb", want: "analytics.duckdb", dbName: "analytics.duckdb"},
+		{name: "absolute path", url: "duckdb:///data/analytics.duckdb", want: "/data/analytics.duckdb", dbName: "/data/analytics.duckdb"},
+		{name: "options", url: "duckdb:analytics.duckdb?threads=4", want: "analytics.duckdb?threads=4", dbName: "analytics.duckdb"},
+		{
+			name:     "read-only",
+			url:      "duckdb:analytics.duckdb",
+			readOnly: true,
+			want:     "analytics.duckdb?access_mode=read_only",
+			dbName:   "analytics.duckdb",
+		},
+		{name: "in-memory", url: "duckdb:", want: "", dbName: ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dsn, opts, err := dsnFromURL(command.Options{URL: tc.url, ReadOnly: tc.readOnly})
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, dsn)
+			assert.Equal(t, drivers.DuckDB, opts.Driver)
+			assert.Equal(t, tc.dbName, opts.DBName)
+		})
+	}
+}
+
+func TestDSN(t *testing.T) {
+	conn, err := dsn("exports/sales.duckdb", "", true)
+	assert.NoError(t, err)
+	assert.Equal(t, "exports/sales.duckdb?access_mode=read_only", conn)
+
+	_, err = dsn("", "", true)
+	assert.ErrorIs(t, err, ErrReadOnlyInMemory)
+}
+
+func TestSessionSetup(t *testing.T) {
+	assert.Equal(t, []string{"SET search_path = 'sales'"}, dialect{}.SessionSetup("sales", true))
+	assert.Empty(t, dialect{}.SessionSetup("", true))
+}
@@ -0,0 +1,9 @@
+//go:build !cgo
+
+package duckdb
+
+// checkDriver tells the DuckDB driver is left out of the builds of dblab made without cgo,
+// so connecting to DuckDB fails with an explicit error rather than an unknown driver.
+func checkDriver() error {
+	return ErrCgoRequired
+}
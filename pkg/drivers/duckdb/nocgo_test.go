@@ -0,0 +1,21 @@
+//go:build !cgo
+
+package duckdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/danvergara/dblab/pkg/command"
+	"github.com/danvergara/dblab/pkg/drivers"
+)
+
+func TestDSNWithoutCgo(t *testing.T) {
+	_, _, err := dialect{}.DSN(command.Options{Driver: drivers.DuckDB, DBName: "exports/sales.duckdb"})
+	assert.ErrorIs(t, err, ErrCgoRequired)
+	assert.ErrorContains(t, err, "built without cgo")
+
+	_, _, err = dialect{}.DSNFromURL(command.Options{URL: "duckdb:analytics.duckdb"})
+	assert.ErrorIs(t, err, ErrCgoRequired)
+}
@@ -0,0 +1,67 @@
+package oracle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/danvergara/dblab/pkg/command"
+)
+
+func TestDSNInvalidPort(t *testing.T) {
+	_, _, err := dialect{}.DSN(command.Options{Host: "localhost", Port: "port", DBName: "db"})
+	assert.ErrorIs(t, err, ErrInvalidPort)
+}
+
+func TestSessionSetup(t *testing.T) {
+	assert.Empty(t, dialect{}.SessionSetup("", false))
+	assert.Equal(t, []string{
+		`ALTER SESSION SET CURRENT_SCHEMA = "APP"`,
+		"ALTER SESSION SET READ_ONLY = TRUE;",
+	}, dialect{}.SessionSetup("app", true))
+}
+
+func TestSelectPage(t *testing.T) {
+	assert.Equal(t,
+		"SELECT * FROM APP.USERS WHERE ID = :1 OFFSET 100 ROWS FETCH NEXT 50 ROWS ONLY",
+		dialect{}.SelectPage("APP.USERS", " WHERE ID = :1", 50, 100),
+	)
+}
+
+func TestQuoteIdentifier(t *testing.T) {
+	var cases = []struct {
+		name string
+		want string
+	}{
+		{name: "users", want: `"users"`},
+		{name: "USERS", want: `"USERS"`},
+		{name: "OrderItems", want: `"OrderItems"`},
+		{name: "order items", want: `"order items"`},
+		{name: `a"b`, want: `"a""b"`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, QuoteIdentifier(tc.name))
+		})
+	}
+}
+
+func TestNormalizeName(t *testing.T) {
+	var cases = []struct {
+		name string
+		want string
+	}{
+		{name: "users", want: "USERS"},
+		{name: "USERS", want: "USERS"},
+		{name: "OrderItems", want: "OrderItems"},
+		{name: `"lower"`, want: "lower"},
+		{name: `"a""b"`, want: `a"b`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, NormalizeName(tc.name))
+		})
+	}
+}
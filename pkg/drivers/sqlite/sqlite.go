@@ -0,0 +1,81 @@
+// Package sqlite is the dialect of SQLite.
+package sqlite
+
+import (
+	"fmt"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+	_ "modernc.org/sqlite"
+
+	"github.com/danvergara/dblab/pkg/command"
+	"github.com/danvergara/dblab/pkg/drivers"
+)
+
+func init() {
+	drivers.Register(dialect{})
+}
+
+type dialect struct{}
+
+func (dialect) Name() string {
+	return drivers.SQLite
+}
+
+func (dialect) Aliases() []string {
+	return nil
+}
+
+// HandlesURL tells whether the URL is a file URI.
+// The driver is modernc.org/sqlite, whose query parameters differ from the ones of mattn/go-sqlite3.
+// To know more about the connection and query parameters see: https://pkg.go.dev/modernc.org/sqlite#Driver.Open
+func (dialect) HandlesURL(url string) bool {
+	return strings.HasPrefix(url, "file:")
+}
+
+func (dialect) DSNFromURL(opts command.Options) (string, command.Options, error) {
+	opts.Driver = drivers.SQLite
+	return opts.URL, opts, nil
+}
+
+func (dialect) DSN(opts command.Options) (string, command.Options, error) {
+	return opts.DBName, opts, nil
+}
+
+func (dialect) SessionSetup(_ string, readOnly bool) []string {
+	if readOnly {
+		return []string{"PRAGMA query_only = true"}
+	}
+
+	return nil
+}
+
+func (dialect) SelectPage(from, where string, limit uint, offset int) string {
+	return fmt.Sprintf("SELECT * FROM %s%s LIMIT %d OFFSET %d;", from, where, limit, offset)
+}
+
+func (dialect) HasSchemas() bool {
+	return false
+}
+
+func (dialect) QuoteIdentifier(name string) string {
+	return QuoteIdentifier(name)
+}
+
+func (dialect) Placeholders() sq.PlaceholderFormat {
+	return sq.Question
+}
+
+func (dialect) CurrentSchemaQuery() string {
+	return ""
+}
+
+// RegisterSSHDialer is not supported, since SQLite databases are files, there's no server to reach through a tunnel.
+func (dialect) RegisterSSHDialer(drivers.DialFunc) error {
+	return drivers.ErrSSHNotSupported
+}
+
+// QuoteIdentifier returns a name between double quotes, with the double quotes in it doubled.
+func QuoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
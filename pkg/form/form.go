@@ -60,6 +60,10 @@ type Model struct {
 	filePathInput textinput.Model
 	limitInput    textinput.Model
 
+	// ssh tunnel.
+	sshHostInput textinput.Model
+	sshJumpInput textinput.Model
+
 	// ssl.
 	postgreSQLSSLModes []string
 	mySQLSSLModes      []string
@@ -178,6 +182,16 @@ func (m *Model) TrustServerCertificate() string {
 	return m.trustServerCertificateInput.Value()
 }
 
+// SSHHost returns the ssh host value, which can be an alias of ~/.ssh/config.
+func (m *Model) SSHHost() string {
+	return m.sshHostInput.Value()
+}
+
+// SSHJumps returns the ssh servers to jump through before the ssh host, out of the ssh jump value.
+func (m *Model) SSHJumps() ([]command.SSHHop, error) {
+	return command.ParseSSHJumps(m.sshJumpInput.Value())
+}
+
 // Limit returns the limit input value from the user.
 func (m *Model) Limit() (uint, error) {
 	// if the user skipped the question, resort to default value
@@ -196,7 +210,7 @@ func (m *Model) Limit() (uint, error) {
 	return uint(limit), nil
 }
 
-// FilePath returns the path to the database file (just in sqlite3) value.
+// FilePath returns the path to the database file (just in sqlite3 and duckdb) value.
 func (m *Model) FilePath() string {
 	return m.filePathInput.Value()
 }
@@ -252,6 +266,16 @@ func initModel() Model {
 	limit.CharLimit = 200
 	limit.SetWidth(20)
 
+	sshHost := textinput.New()
+	sshHost.Placeholder = "SSH host or ~/.ssh/config alias (optional)"
+	sshHost.CharLimit = 200
+	sshHost.SetWidth(20)
+
+	sshJump := textinput.New()
+	sshJump.Placeholder = "SSH jump hosts, user@host:port,... (optional)"
+	sshJump.CharLimit = 1000
+	sshJump.SetWidth(20)
+
 	filePath := textinput.New()
 	filePath.Placeholder = "File Path"
 	filePath.CharLimit = 1000
@@ -302,7 +326,7 @@ func initModel() Model {
 
 	m := Model{
 		// the supported drivers by the client.
-		drivers: []string{"postgres", "mysql", "sqlite", "oracle", "sqlserver"},
+		drivers: []string{"postgres", "mysql", "mariadb", "sqlite", "oracle", "sqlserver", "duckdb", "clickhouse"},
 		// our default value.
 		driver: "postgres",
 
@@ -320,6 +344,8 @@ func initModel() Model {
 		databaseInput:               database,
 		limitInput:                  limit,
 		filePathInput:               filePath,
+		sshHostInput:                sshHost,
+		sshJumpInput:                sshJump,
 		sslCertInput:                sslCert,
 		sslKeyInput:                 sslKey,
 		sslPasswordInput:            sslPassword,
@@ -345,6 +371,11 @@ func Run() (command.Options, error) {
 		return command.Options{}, err
 	}
 
+	jumps, err := m.SSHJumps()
+	if err != nil {
+		return command.Options{}, err
+	}
+
 	opts := command.Options{
 		Driver:                 m.driver,
 		Host:                   m.Host(),
@@ -361,6 +392,8 @@ func Run() (command.Options, error) {
 		TraceFile:              m.TraceFile(),
 		Wallet:                 m.Wallet(),
 		TrustServerCertificate: m.TrustServerCertificate(),
+		SSHHost:                m.SSHHost(),
+		SSHJumps:               jumps,
 		Limit:                  limit,
 	}
 
@@ -368,13 +401,21 @@ func Run() (command.Options, error) {
 		opts.Encrypt = m.SSLMode()
 	}
 
-	if m.driver == "sqlite" {
+	switch m.driver {
+	case drivers.SQLite:
 		opts.URL = fmt.Sprintf("file:%s", m.FilePath())
+	case drivers.DuckDB:
+		opts.URL = fmt.Sprintf("duckdb:%s", m.FilePath())
 	}
 
 	return opts, nil
 }
 
+// fileBased tells whether the database of a driver is a file, asked for by its path rather than by a server to connect to.
+func fileBased(driver string) bool {
+	return driver == drivers.SQLite || driver == drivers.DuckDB
+}
+
 // IsEmpty checks if the given options objects is empty.
 func IsEmpty(opts command.Options) bool {
 	return cmp.Equal(
@@ -394,6 +435,7 @@ func IsEmpty(opts command.Options) bool {
 			"SSLVerify",
 			"Wallet",
 			"TrustServerCertificate",
+			"PGDriver",
 		),
 	)
 }
@@ -52,7 +52,8 @@ func updateStd(msg tea.Msg, m *Model) (tea.Model, tea.Cmd) {
 				m.steps = 2
 				m.cursor = 0
 
-				if m.driver == drivers.SQLite {
+				// ClickHouse is asked for TLS by the port, 8443 for HTTPS or 9440 for the native protocol.
+				if fileBased(m.driver) || m.driver == drivers.ClickHouse {
 					return m, tea.Quit
 				}
 
@@ -102,7 +103,7 @@ func updateStd(msg tea.Msg, m *Model) (tea.Model, tea.Cmd) {
 func stdInputs(m *Model) []textinput.Model {
 	var inputs []textinput.Model
 
-	if m.driver == drivers.SQLite {
+	if fileBased(m.driver) {
 		inputs = []textinput.Model{
 			m.filePathInput,
 		}
@@ -113,6 +114,8 @@ func stdInputs(m *Model) []textinput.Model {
 			m.userInput,
 			m.passwordInput,
 			m.databaseInput,
+			m.sshHostInput,
+			m.sshJumpInput,
 		}
 	}
 
@@ -122,17 +125,19 @@ func stdInputs(m *Model) []textinput.Model {
 }
 
 func assignStdInputValues(m *Model, inputs []textinput.Model) {
-	if m.driver == drivers.SQLite && len(inputs) == 2 {
+	if fileBased(m.driver) && len(inputs) == 2 {
 		m.filePathInput = inputs[0]
 		m.limitInput = inputs[1]
-	} else if len(inputs) == 6 {
+	} else if len(inputs) == 8 {
 		{
 			m.hostInput = inputs[0]
 			m.portInput = inputs[1]
 			m.userInput = inputs[2]
 			m.passwordInput = inputs[3]
 			m.databaseInput = inputs[4]
-			m.limitInput = inputs[5]
+			m.sshHostInput = inputs[5]
+			m.sshJumpInput = inputs[6]
+			m.limitInput = inputs[7]
 		}
 	}
 }
@@ -143,7 +148,7 @@ func updateInputs(msg tea.Msg, m *Model) (*Model, tea.Cmd) {
 		cmds []tea.Cmd
 	)
 
-	if m.driver == drivers.SQLite {
+	if fileBased(m.driver) {
 		m.filePathInput, cmd = m.filePathInput.Update(msg)
 		cmds = append(cmds, cmd)
 	} else {
@@ -161,6 +166,12 @@ func updateInputs(msg tea.Msg, m *Model) (*Model, tea.Cmd) {
 
 		m.databaseInput, cmd = m.databaseInput.Update(msg)
 		cmds = append(cmds, cmd)
+
+		m.sshHostInput, cmd = m.sshHostInput.Update(msg)
+		cmds = append(cmds, cmd)
+
+		m.sshJumpInput, cmd = m.sshJumpInput.Update(msg)
+		cmds = append(cmds, cmd)
 	}
 
 	m.limitInput, cmd = m.limitInput.Update(msg)
@@ -195,7 +206,7 @@ func updateSSLMode(msg tea.Msg, m *Model) (tea.Model, tea.Cmd) {
 				if m.cursor < len(m.sqlServerSSLModes)-1 {
 					m.cursor++
 				}
-			case drivers.MySQL:
+			case drivers.MySQL, drivers.MariaDB:
 				if m.cursor < len(m.mySQLSSLModes)-1 {
 					m.cursor++
 				}
@@ -204,7 +215,7 @@ func updateSSLMode(msg tea.Msg, m *Model) (tea.Model, tea.Cmd) {
 			switch m.driver {
 			case drivers.Postgres:
 				m.sslMode = m.postgreSQLSSLModes[m.cursor]
-			case drivers.MySQL:
+			case drivers.MySQL, drivers.MariaDB:
 				m.sslMode = m.mySQLSSLModes[m.cursor]
 			case drivers.Oracle:
 				m.sslMode = m.oracleSSLModes[m.cursor]
@@ -213,7 +224,7 @@ func updateSSLMode(msg tea.Msg, m *Model) (tea.Model, tea.Cmd) {
 			}
 
 			switch m.driver {
-			case drivers.Postgres, drivers.Oracle, drivers.SQLServer, drivers.MySQL:
+			case drivers.Postgres, drivers.Oracle, drivers.SQLServer, drivers.MySQL, drivers.MariaDB:
 				if m.sslMode == "disable" || m.sslMode == "false" {
 					return m, tea.Quit
 				}
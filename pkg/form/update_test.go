@@ -0,0 +1,29 @@
+package form
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/danvergara/dblab/pkg/command"
+	"github.com/danvergara/dblab/pkg/drivers"
+)
+
+func TestSSHInputs(t *testing.T) {
+	m := initModel()
+	assert.Len(t, stdInputs(&m), 8)
+
+	m.sshHostInput.SetValue("bastion-db")
+	m.sshJumpInput.SetValue("edge@bastion-edge:2222,bastion-core")
+
+	jumps, err := m.SSHJumps()
+	assert.NoError(t, err)
+	assert.Equal(t, "bastion-db", m.SSHHost())
+	assert.Equal(t, []command.SSHHop{
+		{Host: "bastion-edge", Port: "2222", User: "edge"},
+		{Host: "bastion-core"},
+	}, jumps)
+
+	m.driver = drivers.SQLite
+	assert.Len(t, stdInputs(&m), 2)
+}
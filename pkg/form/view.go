@@ -39,7 +39,7 @@ func standardView(m *Model) string {
 func viewInputs(m *Model) []string {
 	var inputs []string
 
-	if m.driver == drivers.SQLite {
+	if fileBased(m.driver) {
 		inputs = []string{
 			m.filePathInput.View(),
 			m.limitInput.View(),
@@ -51,6 +51,8 @@ func viewInputs(m *Model) []string {
 			m.userInput.View(),
 			m.passwordInput.View(),
 			m.databaseInput.View(),
+			m.sshHostInput.View(),
+			m.sshJumpInput.View(),
 			m.limitInput.View(),
 		}
 	}
@@ -64,7 +66,7 @@ func sslView(m *Model) string {
 	switch m.driver {
 	case drivers.Postgres:
 		sslModes = m.postgreSQLSSLModes
-	case drivers.MySQL:
+	case drivers.MySQL, drivers.MariaDB:
 		sslModes = m.mySQLSSLModes
 	case drivers.Oracle:
 		sslModes = m.oracleSSLModes
@@ -0,0 +1,194 @@
+package sshdb
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+
+	"github.com/kevinburke/ssh_config"
+
+	"github.com/danvergara/dblab/pkg/command"
+)
+
+// default path to the ssh config file.
+var defaultSSHConfigPath = filepath.Join(os.Getenv("HOME"), ".ssh", "config")
+
+// defaultIdentityFiles are the private keys tried when no identity file is given for a host, as ssh does.
+var defaultIdentityFiles = []string{"~/.ssh/id_ed25519", "~/.ssh/id_ecdsa", "~/.ssh/id_rsa"}
+
+// hop is an ssh server the tunnel goes through, along with the settings it's connected with.
+// The password, key file and passphrase are the ones given to dblab for the server, if any.
+// The known_hosts files are the ones the key of the server is checked against, the first one is written to.
+type hop struct {
+	alias         string
+	host          string
+	port          string
+	user          string
+	pass          string
+	keyFile       string
+	keyPass       string
+	identityFiles []string
+	proxyJump     string
+	knownHosts    []string
+}
+
+// addr returns the address the ssh server is dialed at.
+func (h hop) addr() string {
+	return net.JoinHostPort(h.host, h.port)
+}
+
+// loadSSHConfig function reads the ssh config file, it returns nil if there's none.
+func loadSSHConfig(path string) (*ssh_config.Config, error) {
+	if path == "" {
+		path = defaultSSHConfigPath
+	}
+
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	cfg, err := ssh_config.Decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing %s: %w", path, err)
+	}
+
+	return cfg, nil
+}
+
+// resolveHop function returns the settings of an ssh server out of a destination written as [user@]host[:port],
+// where host can be an alias of the ssh config, whose HostName, User, Port, IdentityFile, ProxyJump
+// and UserKnownHostsFile are read.
+// The user and port written in the destination take precedence over the ones of the ssh config,
+// and the port defaults to 22 and the user to the local one, as ssh does.
+func resolveHop(cfg *ssh_config.Config, destination string) hop {
+	var h hop
+
+	if i := strings.LastIndex(destination, "@"); i >= 0 {
+		h.user, destination = destination[:i], destination[i+1:]
+	}
+
+	if host, port, err := net.SplitHostPort(destination); err == nil {
+		destination, h.port = host, port
+	}
+
+	h.alias = destination
+	h.host = destination
+
+	if cfg != nil {
+		get := func(key string) string {
+			value, _ := cfg.Get(destination, key)
+			return value
+		}
+
+		if hostName := get("HostName"); hostName != "" {
+			h.host = strings.ReplaceAll(hostName, "%h", destination)
+		}
+
+		if h.user == "" {
+			h.user = get("User")
+		}
+
+		if h.port == "" {
+			h.port = get("Port")
+		}
+
+		h.identityFiles, _ = cfg.GetAll(destination, "IdentityFile")
+		h.proxyJump = get("ProxyJump")
+		if knownHosts := get("UserKnownHostsFile"); knownHosts != "" {
+			h.knownHosts = strings.Fields(knownHosts)
+		}
+	}
+
+	if h.port == "" {
+		h.port = "22"
+	}
+
+	if h.user == "" {
+		h.user = localUser()
+	}
+
+	return h
+}
+
+// resolveJumps function returns the ssh servers of a ProxyJump, a comma separated list of destinations
+// the connection jumps through in order, each one resolved through the ssh config.
+func resolveJumps(cfg *ssh_config.Config, proxyJump string) []hop {
+	if proxyJump == "" || strings.EqualFold(proxyJump, "none") {
+		return nil
+	}
+
+	var jumps []hop
+	for _, destination := range strings.Split(proxyJump, ",") {
+		destination = strings.TrimPrefix(strings.TrimSpace(destination), "ssh://")
+		if destination == "" {
+			continue
+		}
+
+		jumps = append(jumps, resolveHop(cfg, destination))
+	}
+
+	return jumps
+}
+
+// resolveSSHJumps function returns the ssh servers of the jumps given to dblab, in order,
+// each one resolved through the ssh config and connected with its own credentials.
+func resolveSSHJumps(cfg *ssh_config.Config, jumps []command.SSHHop) []hop {
+	hops := make([]hop, 0, len(jumps))
+	for _, jump := range jumps {
+		h := resolveHop(cfg, jump.Host)
+		if jump.User != "" {
+			h.user = jump.User
+		}
+		if jump.Port != "" {
+			h.port = jump.Port
+		}
+
+		h.pass = jump.Pass
+		h.keyFile = jump.KeyFile
+		h.keyPass = jump.KeyPassphrase
+		if jump.KnownHosts != "" {
+			h.knownHosts = []string{jump.KnownHosts}
+		}
+
+		hops = append(hops, h)
+	}
+
+	return hops
+}
+
+// expandPath function expands the ~ and the tokens ssh takes in the path of an identity file:
+// %d for the home directory, %h for the host name, %r for the remote user, %u for the local user and %% for %.
+func expandPath(path string, h hop) string {
+	home, _ := os.UserHomeDir()
+
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		path = home + path[1:]
+	}
+
+	return strings.NewReplacer(
+		"%%", "%",
+		"%d", home,
+		"%h", h.host,
+		"%r", h.user,
+		"%u", localUser(),
+	).Replace(path)
+}
+
+// localUser function returns the name of the user running dblab.
+func localUser() string {
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+
+	return os.Getenv("USER")
+}
@@ -0,0 +1,147 @@
+package sshdb
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kevinburke/ssh_config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/danvergara/dblab/pkg/command"
+)
+
+const testSSHConfig = `
+Host bastion-prod
+    HostName 10.0.0.1
+    User deploy
+    Port 2222
+    IdentityFile ~/.ssh/prod
+    IdentityFile ~/.ssh/%r@%h
+    ProxyJump jump1,admin@jump2:2200
+
+Host jump1
+    HostName jump1.example.com
+    UserKnownHostsFile ~/.ssh/known_hosts_jump ~/.ssh/known_hosts_jump2
+
+Host *.internal
+    HostName %h.example.com
+    Port 2022
+
+Host *
+    User fallback
+`
+
+func TestResolveHop(t *testing.T) {
+	cfg, err := ssh_config.Decode(strings.NewReader(testSSHConfig))
+	require.NoError(t, err)
+
+	var cases = []struct {
+		name        string
+		destination string
+		want        hop
+	}{
+		{
+			name:        "alias",
+			destination: "bastion-prod",
+			want: hop{
+				alias:         "bastion-prod",
+				host:          "10.0.0.1",
+				port:          "2222",
+				user:          "deploy",
+				identityFiles: []string{"~/.ssh/prod", "~/.ssh/%r@%h"},
+				proxyJump:     "jump1,admin@jump2:2200",
+			},
+		},
+		{
+			name:        "user and port of the destination take precedence",
+			destination: "root@bastion-prod:22",
+			want: hop{
+				alias:         "bastion-prod",
+				host:          "10.0.0.1",
+				port:          "22",
+				user:          "root",
+				identityFiles: []string{"~/.ssh/prod", "~/.ssh/%r@%h"},
+				proxyJump:     "jump1,admin@jump2:2200",
+			},
+		},
+		{
+			name:        "pattern",
+			destination: "db.internal",
+			want:        hop{alias: "db.internal", host: "db.internal.example.com", port: "2022", user: "fallback"},
+		},
+		{
+			name:        "unknown host",
+			destination: "example.com",
+			want:        hop{alias: "example.com", host: "example.com", port: "22", user: "fallback"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, resolveHop(cfg, tc.destination))
+		})
+	}
+}
+
+func TestResolveHopWithoutSSHConfig(t *testing.T) {
+	h := resolveHop(nil, "example.com")
+	assert.Equal(t, "example.com", h.host)
+	assert.Equal(t, "22", h.port)
+	assert.Equal(t, localUser(), h.user)
+}
+
+func TestResolveJumps(t *testing.T) {
+	cfg, err := ssh_config.Decode(strings.NewReader(testSSHConfig))
+	require.NoError(t, err)
+
+	jumps := resolveJumps(cfg, "jump1,admin@jump2:2200")
+	require.Len(t, jumps, 2)
+	assert.Equal(t, "jump1.example.com:22", jumps[0].addr())
+	assert.Equal(t, "fallback", jumps[0].user)
+	assert.Equal(t, []string{"~/.ssh/known_hosts_jump", "~/.ssh/known_hosts_jump2"}, jumps[0].knownHosts)
+	assert.Equal(t, "jump2:2200", jumps[1].addr())
+	assert.Equal(t, "admin", jumps[1].user)
+
+	assert.Empty(t, resolveJumps(cfg, "none"))
+	assert.Empty(t, resolveJumps(cfg, ""))
+}
+
+func TestResolveSSHJumps(t *testing.T) {
+	cfg, err := ssh_config.Decode(strings.NewReader(testSSHConfig))
+	require.NoError(t, err)
+
+	jumps := resolveSSHJumps(cfg, []command.SSHHop{
+		{Host: "jump1", User: "edge", KnownHosts: "/etc/ssh/known_hosts_edge"},
+		{Host: "jump1"},
+	})
+	require.Len(t, jumps, 2)
+	assert.Equal(t, "edge", jumps[0].user)
+	assert.Equal(t, []string{"/etc/ssh/known_hosts_edge"}, jumps[0].knownHosts)
+	assert.Equal(t, []string{"~/.ssh/known_hosts_jump", "~/.ssh/known_hosts_jump2"}, jumps[1].knownHosts)
+}
+
+func TestExpandPath(t *testing.T) {
+	home, err := os.UserHomeDir()
+	require.NoError(t, err)
+
+	h := hop{host: "10.0.0.1", user: "deploy"}
+	assert.Equal(t, filepath.Join(home, ".ssh", "deploy@10.0.0.1"), expandPath("~/.ssh/%r@%h", h))
+	assert.Equal(t, home+"/keys/100%", expandPath("%d/keys/100%%", h))
+	assert.Equal(t, "/etc/ssh/key", expandPath("/etc/ssh/key", h))
+}
+
+func TestLoadSSHConfig(t *testing.T) {
+	cfg, err := loadSSHConfig(filepath.Join(t.TempDir(), "config"))
+	assert.NoError(t, err)
+	assert.Nil(t, cfg)
+
+	path := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, os.WriteFile(path, []byte(testSSHConfig), 0600))
+
+	cfg, err = loadSSHConfig(path)
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.1", resolveHop(cfg, "bastion-prod").host)
+}
@@ -1,25 +1,26 @@
 package sshdb
 
 import (
-	"context"
-	"database/sql"
-	"database/sql/driver"
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"log"
 	"net"
+	"net/url"
 	"os"
 	"path/filepath"
-	"strings"
-	"time"
+	"slices"
+	"sync"
 
-	"github.com/go-sql-driver/mysql"
-	"github.com/lib/pq"
 	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
 	"golang.org/x/crypto/ssh/knownhosts"
 
+	"github.com/danvergara/dblab/pkg/command"
 	"github.com/danvergara/dblab/pkg/drivers"
+	_ "github.com/danvergara/dblab/pkg/drivers/dialects"
 )
 
 // default path to the known_hosts file.
@@ -30,9 +31,9 @@ var defaultKnownHostsPath = filepath.Join(os.Getenv("HOME"), ".ssh")
 // 1. the file path
 // 2. the flag (e.g. os.O_CREATE|os.O_APPEND creates the file if not exists, if exists, appends to the file)
 // 3. the last argument is the permission.
-func createKnownHosts(knownHostsPath string) (err error) {
+func createKnownHosts(khFilePath string) (err error) {
 	f, err := os.OpenFile(
-		filepath.Join(knownHostsPath, "known_hosts"),
+		khFilePath,
 		os.O_CREATE,
 		0600,
 	)
@@ -48,17 +49,21 @@ func createKnownHosts(knownHostsPath string) (err error) {
 }
 
 // checkKnownHosts fucntion creates a know_hosts callback function with the New function.
-// This callback function can be used to check if the host exists in the known_hosts file.
-func checkKnownHosts(knownHostsPath string) (ssh.HostKeyCallback, error) {
-	if knownHostsPath == "" {
-		knownHostsPath = defaultKnownHostsPath
+// This callback function can be used to check if the host exists in the known_hosts files.
+// The first file is created if it does not exist, since unknown keys are added to it, the others are skipped.
+func checkKnownHosts(khFilePaths []string) (ssh.HostKeyCallback, error) {
+	if err := createKnownHosts(khFilePaths[0]); err != nil {
+		return nil, err
 	}
 
-	if err := createKnownHosts(knownHostsPath); err != nil {
-		return nil, err
+	files := khFilePaths[:1]
+	for _, path := range khFilePaths[1:] {
+		if _, err := os.Stat(path); err == nil {
+			files = append(files, path)
+		}
 	}
 
-	kh, err := knownhosts.New(filepath.Join(knownHostsPath, "known_hosts"))
+	kh, err := knownhosts.New(files...)
 	if err != nil {
 		return nil, err
 	}
@@ -76,51 +81,23 @@ func keyString(k ssh.PublicKey) string {
 // addHostKey adds the host key to known_hosts file by using Normalize and Line functions of knownhosts package.
 // This functions implements the ssh.HostKeyCallback type wiich is a function type which signature goes like this:
 // type HostKeyCallback func(hostname string, remote net.Addr, key PublicKey) error.
-func addHostKey(_ string, remote net.Addr, pubKey ssh.PublicKey, knownHostsPath string) error {
-	if knownHostsPath == "" {
-		knownHostsPath = defaultKnownHostsPath
-	}
-
-	khFilePath := filepath.Join(knownHostsPath, "known_hosts")
-
+// The key is stored under the host name the server was dialed at, the one known_hosts is checked with,
+// since the remote address of a server reached through a jump is not the one of the server.
+func addHostKey(host string, _ net.Addr, pubKey ssh.PublicKey, khFilePath string) error {
 	f, err := os.OpenFile(khFilePath, os.O_APPEND|os.O_WRONLY, 0600)
 	if err != nil {
 		return err
 	}
 	defer f.Close()
 
-	knownHosts := knownhosts.Normalize(remote.String())
-	_, err = f.WriteString(knownhosts.Line([]string{knownHosts}, pubKey))
-	return err
-}
-
-// PostgresViaSSHDialer implements the driver.Driver interface to register the connection to the database via the ssh tunnel.
-type PostgresViaSSHDialer struct {
-	client *ssh.Client
-}
-
-func (sd *PostgresViaSSHDialer) Open(s string) (_ driver.Conn, err error) {
-	return pq.DialOpen(sd, s)
-}
-
-func (sd *PostgresViaSSHDialer) Dial(network, address string) (net.Conn, error) {
-	return sd.client.Dial(network, address)
-}
-
-func (sd *PostgresViaSSHDialer) DialTimeout(
-	network, address string,
-	timeout time.Duration,
-) (net.Conn, error) {
-	return sd.client.Dial(network, address)
-}
-
-// MySQLViaSSHDialer used to register the database connection via the ssh tunnel.
-type MySQLViaSSHDialer struct {
-	client *ssh.Client
-}
+	// each key goes on its own line, the last line may lack its line break, e.g. if written by an older version.
+	line := knownhosts.Line([]string{knownhosts.Normalize(host)}, pubKey) + "\n"
+	if content, err := os.ReadFile(khFilePath); err == nil && len(content) > 0 && content[len(content)-1] != '\n' {
+		line = "\n" + line
+	}
 
-func (m *MySQLViaSSHDialer) Dial(addr string) (net.Conn, error) {
-	return m.client.Dial("tcp", addr)
+	_, err = f.WriteString(line)
+	return err
 }
 
 // SSHConfig struct setup the ssh tunnel to connect with a given database.
@@ -129,12 +106,29 @@ type SSHConfig struct {
 	sshPass        string
 	sshKeyFile     string
 	sshKeyPass     string
+	sshKnownHosts  string
 	sshHost        string
 	sshPort        string
 	sshClient      *ssh.Client
 	dbDriver       string
 	dbURL          string
 	knownHostsPath string
+	sshConfigPath  string
+	// sshJumps are the ssh servers the tunnel jumps through, in order, before the ssh host.
+	sshJumps []command.SSHHop
+	// sshClients are the ssh connections to the jumps along the way, if any, and to the ssh server, the last one.
+	sshClients []*ssh.Client
+	// forward tells whether the database is reached through a local port forwarded to it,
+	// rather than by giving the ssh dialer to the database/sql driver.
+	forward bool
+
+	mu        sync.Mutex
+	listeners []net.Listener
+	conns     map[net.Conn]struct{}
+	closed    bool
+	// forwardErr is the last error forwarding a connection through the tunnel.
+	forwardErr error
+	wg         sync.WaitGroup
 }
 
 type Option func(*SSHConfig)
@@ -173,6 +167,14 @@ func WithSSHKeyPass(sshKeyPass string) Option {
 	}
 }
 
+// WithSSHKnownHosts sets the known_hosts file the key of the ssh host is checked against,
+// which takes precedence over the UserKnownHostsFile of the ssh config.
+func WithSSHKnownHosts(sshKnownHosts string) Option {
+	return func(c *SSHConfig) {
+		c.sshKnownHosts = sshKnownHosts
+	}
+}
+
 func WithSShHost(sshHost string) Option {
 	return func(c *SSHConfig) {
 		c.sshHost = sshHost
@@ -203,120 +205,487 @@ func WithKnownHostsPath(knownHostsPath string) Option {
 	}
 }
 
+// WithSSHConfigPath sets the path to the ssh config file the ssh host is resolved through,
+// which is ~/.ssh/config by default.
+func WithSSHConfigPath(sshConfigPath string) Option {
+	return func(c *SSHConfig) {
+		c.sshConfigPath = sshConfigPath
+	}
+}
+
+// WithSSHJumps sets the ssh servers the tunnel jumps through, in order, before reaching the ssh host,
+// each one with its own credentials. They take the place of the ProxyJump of the ssh config.
+func WithSSHJumps(jumps []command.SSHHop) Option {
+	return func(c *SSHConfig) {
+		c.sshJumps = jumps
+	}
+}
+
+// WithLocalForwarding makes the database be reached through a local port forwarded to it over the ssh connection,
+// which works for every driver, rather than by giving the ssh dialer to the database/sql driver.
+func WithLocalForwarding(forward bool) Option {
+	return func(c *SSHConfig) {
+		c.forward = forward
+	}
+}
+
 // SSHTunnel method sets up the ssh tunnel and does a number of things:
-// Create a ssh client config object that witht he user.
+// Resolve the ssh host through the ssh config, which may give its host name, user, port, identity files and the jumps to it,
+// unless the jumps are given to dblab.
+// Create a ssh client config object for each server along the way, with the user.
 // Define a HostKeyCallback to ensures known ssh server is the actual server.
 // If host key checking is ignore then any server that has the same FQDN or IP address can impersonate the actual ssh server.
-// Define the authentication method to perform the ssh tunnel (passsword or private key).
-// Register the ViaSSHDialer with the ssh connection as a parameter.
+// Define the authentication methods to perform the ssh tunnel (password, ssh agent or private keys).
+// Dial the jumps, if any, each one through the previous, and the ssh server through the last.
+// Register the ViaSSHDialer with the ssh connection as a parameter,
+// unless local forwarding is asked for or the driver can't be given a dialer, see ForwardOptions.
 func (c *SSHConfig) SSHTunnel() error {
+	cfg, err := loadSSHConfig(c.sshConfigPath)
+	if err != nil {
+		return err
+	}
+
+	// the flags take precedence over the ssh config.
+	target := resolveHop(cfg, c.sshHost)
+	if c.sshUser != "" {
+		target.user = c.sshUser
+	}
+	if c.sshPort != "" {
+		target.port = c.sshPort
+	}
+
+	target.pass = c.sshPass
+	target.keyFile = c.sshKeyFile
+	target.keyPass = c.sshKeyPass
+	if c.sshKnownHosts != "" {
+		target.knownHosts = []string{c.sshKnownHosts}
+	}
+
+	// the jumps given to dblab take the place of the ProxyJump of the ssh config.
+	jumps := resolveSSHJumps(cfg, c.sshJumps)
+	if len(jumps) == 0 {
+		jumps = resolveJumps(cfg, target.proxyJump)
+	}
+
+	hops := append(jumps, target)
+
+	// the keys of the ssh agent are tried on every server, the agent is only needed while authenticating.
+	var agentClient agent.ExtendedAgent
+	if socket := os.Getenv("SSH_AUTH_SOCK"); socket != "" {
+		conn, err := net.Dial("unix", socket)
+		if err != nil {
+			log.Printf("failed to reach the ssh agent, skipping it: %v", err)
+		} else {
+			defer conn.Close()
+			agentClient = agent.NewClient(conn)
+		}
+	}
+
+	var client *ssh.Client
+	for _, h := range hops {
+		config, err := c.clientConfig(h, agentClient)
+		if err != nil {
+			c.closeClients()
+			return err
+		}
+
+		if client == nil {
+			client, err = ssh.Dial("tcp", h.addr(), config)
+		} else {
+			client, err = dialThrough(client, h.addr(), config)
+		}
+		if err != nil {
+			c.closeClients()
+			return fmt.Errorf("failed to connect to the ssh server %s: %w", h.alias, err)
+		}
+
+		c.sshClients = append(c.sshClients, client)
+	}
+
+	c.sshClient = client
+
+	if c.forward {
+		return nil
+	}
+
+	// the dialect of the database is picked by its driver, or by the scheme of its URL.
+	dialect, ok := drivers.Lookup(c.dbDriver)
+	if !ok && c.dbURL != "" {
+		dialect, ok = drivers.ForURL(c.dbURL)
+	}
+	if !ok {
+		return nil
+	}
+
+	if err := dialect.RegisterSSHDialer(c.sshClient.Dial); err != nil {
+		if errors.Is(err, drivers.ErrSSHNotSupported) {
+			log.Printf("%s can't be given the ssh dialer, forwarding a local port through the tunnel instead.", dialect.Name())
+			c.forward = true
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
+
+// clientConfig method returns the ssh client config of a server along the way to the database,
+// which is authenticated with its own password and key file, if given, the keys of the ssh agent and its identity files.
+func (c *SSHConfig) clientConfig(h hop, agentClient agent.ExtendedAgent) (*ssh.ClientConfig, error) {
+	config := &ssh.ClientConfig{
+		User:            h.user,
+		HostKeyCallback: c.hostKeyCallback(c.knownHostsFiles(h)),
+	}
+
+	if h.pass != "" {
+		config.Auth = append(config.Auth, ssh.Password(h.pass))
+	}
+
+	// the key file given to dblab must be read, the ones of the ssh config are skipped if they can't, as ssh does.
+	var keyFileSigners, signers []ssh.Signer
+	identityFiles := h.identityFiles
+	if h.keyFile != "" {
+		signer, err := readKey(h.keyFile, h.keyPass)
+		if err != nil {
+			return nil, err
+		}
+		keyFileSigners = append(keyFileSigners, signer)
+	} else if len(identityFiles) == 0 {
+		identityFiles = defaultIdentityFiles
+	}
+
+	for _, file := range identityFiles {
+		signer, err := readKey(expandPath(file, h), h.keyPass)
+		if err != nil {
+			if !errors.Is(err, fs.ErrNotExist) {
+				log.Printf("skipping identity file %s: %v", file, err)
+			}
+			continue
+		}
+		signers = append(signers, signer)
+	}
+
+	// the keys are given as a single method, since the ssh client tries each method once.
+	// The given key file goes first, since servers only take a few attempts.
+	config.Auth = append(config.Auth, ssh.PublicKeysCallback(func() ([]ssh.Signer, error) {
+		var agentSigners []ssh.Signer
+		if agentClient != nil {
+			var err error
+			agentSigners, err = agentClient.Signers()
+			if err != nil {
+				log.Printf("failed to list the keys of the ssh agent: %v", err)
+			}
+		}
+
+		return slices.Concat(keyFileSigners, agentSigners, signers), nil
+	}))
+
+	return config, nil
+}
+
+// readKey function reads and parses a private key, with the given passphrase, if any.
+func readKey(path, passphrase string) (ssh.Signer, error) {
+	key, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading private key: %w", err)
+	}
+
+	var signer ssh.Signer
+	if passphrase != "" {
+		signer, err = ssh.ParsePrivateKeyWithPassphrase(key, []byte(passphrase))
+	} else {
+		signer, err = ssh.ParsePrivateKey(key)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error parsing private key: %w", err)
+	}
+
+	return signer, nil
+}
+
+// knownHostsFiles method returns the known_hosts files the key of a server is checked against,
+// the ones given for the server, if any, or the known_hosts file of the known hosts path.
+func (c *SSHConfig) knownHostsFiles(h hop) []string {
+	if len(h.knownHosts) == 0 {
+		knownHostsPath := c.knownHostsPath
+		if knownHostsPath == "" {
+			knownHostsPath = defaultKnownHostsPath
+		}
+
+		return []string{filepath.Join(knownHostsPath, "known_hosts")}
+	}
+
+	files := make([]string, 0, len(h.knownHosts))
+	for _, file := range h.knownHosts {
+		files = append(files, expandPath(file, h))
+	}
+
+	return files
+}
+
+// hostKeyCallback method returns the callback checking the key of a server against the given known_hosts files,
+// adding the key of the servers unknown so far to the first one.
+func (c *SSHConfig) hostKeyCallback(khFilePaths []string) ssh.HostKeyCallback {
 	// Reference: https://github.com/melbahja/goph/blob/master/client.go
 	// Reference: https://github.com/melbahja/goph/blob/master/hosts.go
 	// Study the client.go and hosts.go to understand how to write host key call back.
-	var (
-		keyErr      *knownhosts.KeyError
-		signer      ssh.Signer
-		parseKeyErr error
-	)
-	config := &ssh.ClientConfig{
-		User: c.sshUser,
-		HostKeyCallback: ssh.HostKeyCallback(
-			func(host string, remote net.Addr, pubKey ssh.PublicKey) error {
-				kh, err := checkKnownHosts(c.knownHostsPath)
-				if err != nil {
-					return err
-				}
-
-				hErr := kh(host, remote, pubKey)
-				if errors.As(hErr, &keyErr) && len(keyErr.Want) > 0 {
-					// Reference: https://www.godoc.org/golang.org/x/crypto/ssh/knownhosts#KeyError
-					// if keyErr.Want slice is empty then host is unknown, if keyErr.Want is not empty
-					// and if host is known then there is key mismatch the connection is then rejected.
-					log.Printf(
-						"%v is not a key of %s, either a MiTM attack or %s has reconfigured the host pub key.",
-						keyString(pubKey),
-						host,
-						host,
-					)
-					return keyErr
-				} else if errors.As(hErr, &keyErr) && len(keyErr.Want) == 0 {
-					// host key not found in known_hosts then give a warning and continue to connect.
-					log.Printf("%s is not trusted, adding this key: %q to known_hosts file.", host, keyString(pubKey))
-					return addHostKey(host, remote, pubKey, c.knownHostsPath)
-				}
-
-				log.Printf("pubkey exists for %s.", host)
-				return nil
-			},
-		),
-	}
-
-	if c.sshPass != "" {
-		config.Auth = []ssh.AuthMethod{ssh.Password(c.sshPass)}
-	} else if c.sshKeyFile != "" {
-		// Load the private key for SSH authentication.
-		key, err := os.ReadFile(c.sshKeyFile)
+	var keyErr *knownhosts.KeyError
+
+	return func(host string, remote net.Addr, pubKey ssh.PublicKey) error {
+		kh, err := checkKnownHosts(khFilePaths)
 		if err != nil {
-			return fmt.Errorf("error reading private key: %w", err)
+			return err
 		}
 
-		// Parse the private using a passphrase if required.
-		if c.sshKeyPass != "" {
-			signer, parseKeyErr = ssh.ParsePrivateKeyWithPassphrase(key, []byte(c.sshKeyPass))
-		} else {
-			signer, parseKeyErr = ssh.ParsePrivateKey(key)
+		hErr := kh(host, remote, pubKey)
+		if errors.As(hErr, &keyErr) && len(keyErr.Want) > 0 {
+			// Reference: https://www.godoc.org/golang.org/x/crypto/ssh/knownhosts#KeyError
+			// if keyErr.Want slice is empty then host is unknown, if keyErr.Want is not empty
+			// and if host is known then there is key mismatch the connection is then rejected.
+			log.Printf(
+				"%v is not a key of %s, either a MiTM attack or %s has reconfigured the host pub key.",
+				keyString(pubKey),
+				host,
+				host,
+			)
+			return keyErr
+		} else if errors.As(hErr, &keyErr) && len(keyErr.Want) == 0 {
+			// host key not found in known_hosts then give a warning and continue to connect.
+			log.Printf("%s is not trusted, adding this key: %q to known_hosts file.", host, keyString(pubKey))
+			return addHostKey(host, remote, pubKey, khFilePaths[0])
+		}
+
+		log.Printf("pubkey exists for %s.", host)
+		return nil
+	}
+}
+
+// dialThrough function opens an ssh connection to the given address through the connection to a jump server.
+func dialThrough(jump *ssh.Client, addr string, config *ssh.ClientConfig) (*ssh.Client, error) {
+	conn, err := jump.Dial("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	clientConn, chans, reqs, err := ssh.NewClientConn(conn, addr, config)
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	return ssh.NewClient(clientConn, chans, reqs), nil
+}
+
+// closeClients method closes the ssh connections, from the ssh server back to the first jump.
+func (c *SSHConfig) closeClients() error {
+	var errs []error
+	for i := len(c.sshClients) - 1; i >= 0; i-- {
+		errs = append(errs, c.sshClients[i].Close())
+	}
+	c.sshClients = nil
+
+	return errors.Join(errs...)
+}
+
+// ForwardOptions method returns the options of the database connection rewritten to reach the database
+// through a local port forwarded to it, if the tunnel is in local forwarding mode, otherwise they are returned as they are.
+// The host and port of the connection, or the ones of its URL, are replaced with the local address,
+// and the ssh host is cleared, since the driver connects to the local port as if there were no tunnel.
+// Connections with no host, e.g. to SQLite files, are returned as they are.
+func (c *SSHConfig) ForwardOptions(opts command.Options) (command.Options, error) {
+	if !c.forward {
+		return opts, nil
+	}
+
+	if opts.URL != "" {
+		u, err := url.Parse(opts.URL)
+		if err != nil || u.Host == "" {
+			return opts, nil
 		}
-		if parseKeyErr != nil {
-			return fmt.Errorf("error parsing private key: %w", parseKeyErr)
+
+		if u.Port() == "" {
+			return opts, fmt.Errorf("the port of %s is needed to forward it through the ssh tunnel", u.Hostname())
 		}
 
-		config.Auth = []ssh.AuthMethod{
-			ssh.PublicKeys(signer),
+		local, err := c.Forward(u.Host)
+		if err != nil {
+			return opts, err
 		}
+
+		opts.ForwardedAddr = u.Host
+		u.Host = local
+		opts.URL = u.String()
+		opts.SSHHost = ""
+
+		return opts, nil
+	}
+
+	if opts.Host == "" || opts.Socket != "" {
+		return opts, nil
 	}
 
-	client, err := ssh.Dial("tcp", fmt.Sprintf("%s:%s", c.sshHost, c.sshPort), config)
+	if opts.Port == "" {
+		return opts, fmt.Errorf("the port of %s is needed to forward it through the ssh tunnel", opts.Host)
+	}
+
+	remote := net.JoinHostPort(opts.Host, opts.Port)
+	local, err := c.Forward(remote)
 	if err != nil {
-		return fmt.Errorf("failed to connect to the ssh server: %w", err)
+		return opts, err
 	}
 
-	c.sshClient = client
+	host, port, err := net.SplitHostPort(local)
+	if err != nil {
+		return opts, err
+	}
 
-	switch c.dbDriver {
-	case drivers.PostgreSQL, drivers.Postgres:
-		sql.Register("postgres+ssh", &PostgresViaSSHDialer{c.sshClient})
-	case drivers.MySQL:
-		mysql.RegisterDialContext(
-			"mysql+tcp",
-			func(_ context.Context, addr string) (net.Conn, error) {
-				dialer := &MySQLViaSSHDialer{c.sshClient}
-				return dialer.Dial(addr)
-			},
-		)
-	}
-
-	if c.dbURL != "" {
-		switch {
-		case strings.Contains(c.dbURL, drivers.Postgres):
-			fallthrough
-		case strings.Contains(c.dbURL, drivers.PostgreSQL):
-			sql.Register("postgres+ssh", &PostgresViaSSHDialer{c.sshClient})
-		case strings.Contains(c.dbURL, drivers.MySQL):
-			mysql.RegisterDialContext(
-				"mysql+tcp",
-				func(_ context.Context, addr string) (net.Conn, error) {
-					dialer := &MySQLViaSSHDialer{c.sshClient}
-					return dialer.Dial(addr)
-				},
-			)
+	opts.Host = host
+	opts.Port = port
+	opts.SSHHost = ""
+	opts.ForwardedAddr = remote
+
+	return opts, nil
+}
+
+// Forward method opens a listener on a local port, whose connections are forwarded to the given address
+// over the ssh connection, and returns the address of the listener.
+// The listener is closed along with the tunnel.
+func (c *SSHConfig) Forward(remoteAddr string) (string, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", fmt.Errorf("failed to open the local port forwarded to %s: %w", remoteAddr, err)
+	}
+
+	c.mu.Lock()
+	c.listeners = append(c.listeners, listener)
+	c.mu.Unlock()
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+
+		for {
+			local, err := listener.Accept()
+			if err != nil {
+				// the listener is closed.
+				return
+			}
+
+			c.wg.Add(1)
+			go func() {
+				defer c.wg.Done()
+				c.forwardConn(local, remoteAddr)
+			}()
 		}
+	}()
+
+	return listener.Addr().String(), nil
+}
 
+// forwardConn copies the data of a local connection to a connection to the remote address opened over ssh,
+// and the other way around, until either of them is closed.
+func (c *SSHConfig) forwardConn(local net.Conn, remoteAddr string) {
+	if !c.track(local) {
+		return
 	}
+	defer c.untrack(local)
 
-	return nil
+	remote, err := c.sshClient.Dial("tcp", remoteAddr)
+	if err != nil {
+		c.setErr(fmt.Errorf("failed to reach %s through the ssh tunnel: %w", remoteAddr, err))
+		_ = local.Close()
+		return
+	}
+
+	if !c.track(remote) {
+		_ = local.Close()
+		return
+	}
+	defer c.untrack(remote)
+
+	done := make(chan struct{}, 2)
+	pipe := func(dst, src net.Conn) {
+		_, _ = io.Copy(dst, src)
+		done <- struct{}{}
+	}
+
+	go pipe(remote, local)
+	go pipe(local, remote)
+
+	// closing both ends once either is done makes the other copy return.
+	<-done
+	_ = local.Close()
+	_ = remote.Close()
+	<-done
 }
 
-// Close method closes the tcp connection.
+// track method keeps a forwarded connection to be closed along with the tunnel.
+// It closes the connection and returns false if the tunnel is already closed.
+func (c *SSHConfig) track(conn net.Conn) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		_ = conn.Close()
+		return false
+	}
+
+	if c.conns == nil {
+		c.conns = make(map[net.Conn]struct{})
+	}
+	c.conns[conn] = struct{}{}
+
+	return true
+}
+
+// setErr method keeps the error forwarding a connection, unless the tunnel is closed, which makes the pending ones fail.
+func (c *SSHConfig) setErr(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.closed {
+		c.forwardErr = err
+	}
+}
+
+// Err method returns the last error forwarding a connection through the tunnel, if any.
+// The errors are kept rather than printed, since the TUI has the terminal while the tunnel is up,
+// so they're reported along with the error of the database driver, which only sees the connection closed.
+func (c *SSHConfig) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.forwardErr
+}
+
+// untrack method forgets a forwarded connection once it's done.
+func (c *SSHConfig) untrack(conn net.Conn) {
+	c.mu.Lock()
+	delete(c.conns, conn)
+	c.mu.Unlock()
+}
+
+// Close method closes the local ports forwarded to the database along with their connections
+// and the tcp connection, and waits for the forwarding to be done.
 func (c *SSHConfig) Close() error {
-	return c.sshClient.Close()
+	c.mu.Lock()
+	c.closed = true
+
+	var errs []error
+	for _, l := range c.listeners {
+		errs = append(errs, l.Close())
+	}
+	c.listeners = nil
+
+	for conn := range c.conns {
+		_ = conn.Close()
+	}
+	c.mu.Unlock()
+
+	// closing the ssh connections makes the pending dials of the forwarded connections fail.
+	errs = append(errs, c.closeClients())
+
+	c.wg.Wait()
+
+	return errors.Join(errs...)
 }
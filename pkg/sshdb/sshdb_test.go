@@ -1,14 +1,27 @@
 package sshdb_test
 
 import (
+	"crypto/ed25519"
+	"crypto/rand"
 	"fmt"
+	"io"
 	"log"
 	"net"
+	"net/url"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
 
+	"github.com/danvergara/dblab/pkg/command"
 	"github.com/danvergara/dblab/pkg/drivers"
 	"github.com/danvergara/dblab/pkg/sshdb"
 )
@@ -26,6 +39,14 @@ func mockSSHServer(t *testing.T, privateKeyPath, authorizedKeyPath string) (net.
 		return nil, fmt.Errorf("failed to parse private key: %w", err)
 	}
 
+	return mockSSHServerWithHostKey(t, func() ssh.Signer { return privateKey }, authorizedKeyPath)
+}
+
+// mockSSHServerWithHostKey starts a mock ssh server that presents the host key returned by hostKey
+// to each new connection, so a test can change the key the server is known by.
+func mockSSHServerWithHostKey(t *testing.T, hostKey func() ssh.Signer, authorizedKeyPath string) (net.Listener, error) {
+	t.Helper()
+
 	// Load the client's public key
 	authorizedKeyBytes, err := os.ReadFile(authorizedKeyPath)
 	if err != nil {
@@ -36,17 +57,6 @@ func mockSSHServer(t *testing.T, privateKeyPath, authorizedKeyPath string) (net.
 		return nil, fmt.Errorf("failed to parse authorized key: %w", err)
 	}
 
-	// Configure the server to require the correct public key for authentication
-	config := &ssh.ServerConfig{
-		PublicKeyCallback: func(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
-			if string(key.Marshal()) == string(authorizedKey.Marshal()) {
-				return nil, nil // Authentication successful
-			}
-			return nil, fmt.Errorf("unauthorized key")
-		},
-	}
-	config.AddHostKey(privateKey)
-
 	// Start the server
 	listener, err := net.Listen("tcp", "127.0.0.1:0") // Bind to a random port
 	if err != nil {
@@ -57,13 +67,32 @@ func mockSSHServer(t *testing.T, privateKeyPath, authorizedKeyPath string) (net.
 		for {
 			conn, err := listener.Accept()
 			if err != nil {
-				continue
+				// the listener is closed.
+				return
 			}
+
+			// Configure the server to require the correct public key for authentication
+			config := &ssh.ServerConfig{
+				PublicKeyCallback: func(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+					if string(key.Marshal()) == string(authorizedKey.Marshal()) {
+						return nil, nil // Authentication successful
+					}
+					return nil, fmt.Errorf("unauthorized key")
+				},
+			}
+			config.AddHostKey(hostKey())
+
 			go func() {
-				_, _, _, err := ssh.NewServerConn(conn, config)
+				sconn, chans, reqs, err := ssh.NewServerConn(conn, config)
 				if err != nil {
 					log.Printf("failed to establish server connection: %v", err)
+					conn.Close()
+					return
 				}
+				go ssh.DiscardRequests(reqs)
+				go serveDirectTCPIP(chans)
+
+				_ = sconn.Wait()
 				conn.Close()
 			}()
 		}
@@ -72,6 +101,118 @@ func mockSSHServer(t *testing.T, privateKeyPath, authorizedKeyPath string) (net.
 	return listener, nil
 }
 
+// serveDirectTCPIP connects the direct-tcpip channels opened by the client, the ones of the forwarded ports,
+// to the address they are opened to.
+func serveDirectTCPIP(chans <-chan ssh.NewChannel) {
+	for newChannel := range chans {
+		if newChannel.ChannelType() != "direct-tcpip" {
+			_ = newChannel.Reject(ssh.UnknownChannelType, "unknown channel type")
+			continue
+		}
+
+		var payload struct {
+			Host     string
+			Port     uint32
+			OrigHost string
+			OrigPort uint32
+		}
+		if err := ssh.Unmarshal(newChannel.ExtraData(), &payload); err != nil {
+			_ = newChannel.Reject(ssh.ConnectionFailed, err.Error())
+			continue
+		}
+
+		target, err := net.Dial("tcp", net.JoinHostPort(payload.Host, strconv.Itoa(int(payload.Port))))
+		if err != nil {
+			_ = newChannel.Reject(ssh.ConnectionFailed, err.Error())
+			continue
+		}
+
+		channel, requests, err := newChannel.Accept()
+		if err != nil {
+			target.Close()
+			continue
+		}
+		go ssh.DiscardRequests(requests)
+
+		go func() {
+			_, _ = io.Copy(channel, target)
+			channel.Close()
+		}()
+		go func() {
+			_, _ = io.Copy(target, channel)
+			target.Close()
+		}()
+	}
+}
+
+// echoServer starts a tcp server that writes back what it reads, standing for the database.
+func echoServer(t *testing.T) net.Listener {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				_, _ = io.Copy(conn, conn)
+				conn.Close()
+			}()
+		}
+	}()
+
+	return listener
+}
+
+// startTunnel starts the mock ssh server and opens a tunnel to it with the given options.
+func startTunnel(t *testing.T, opts ...sshdb.Option) *sshdb.SSHConfig {
+	t.Helper()
+
+	t.Cleanup(func() {
+		os.Remove("testdata/known_hosts")
+	})
+
+	listener, err := mockSSHServer(t, "testdata/test_host_key", "testdata/test_client_key.pub")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	host, port, _ := net.SplitHostPort(listener.Addr().String())
+
+	sc := sshdb.New(append([]sshdb.Option{
+		sshdb.WithSShHost(host),
+		sshdb.WithSShPort(port),
+		sshdb.WithSSHUser("testuser"),
+		sshdb.WithSSHKeyFile("testdata/test_client_key"),
+		sshdb.WithKnownHostsPath("testdata"),
+		sshdb.WithSSHConfigPath(filepath.Join(t.TempDir(), "config")),
+	}, opts...)...)
+	require.NoError(t, sc.SSHTunnel())
+
+	return sc
+}
+
+// assertEcho writes to the given address and checks the same is read back.
+func assertEcho(t *testing.T, addr string) {
+	t.Helper()
+
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = conn.Write([]byte("ping"))
+	require.NoError(t, err)
+
+	buf := make([]byte, 4)
+	_, err = io.ReadFull(conn, buf)
+	require.NoError(t, err)
+	assert.Equal(t, "ping", string(buf))
+}
+
 func TestSSHKeyFileAuthentication(t *testing.T) {
 	var (
 		privateKeyPath    = "testdata/test_host_key"
@@ -102,3 +243,415 @@ func TestSSHKeyFileAuthentication(t *testing.T) {
 		t.Fatalf("failed to connect to the ssh server %v", err)
 	}
 }
+
+func TestForwardOptions(t *testing.T) {
+	db := echoServer(t)
+	dbHost, dbPort, _ := net.SplitHostPort(db.Addr().String())
+
+	sc := startTunnel(t, sshdb.WithDBDriver(drivers.MySQL), sshdb.WithLocalForwarding(true))
+
+	opts, err := sc.ForwardOptions(command.Options{
+		Driver:  drivers.MySQL,
+		Host:    dbHost,
+		Port:    dbPort,
+		SSHHost: "bastion",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "127.0.0.1", opts.Host)
+	assert.NotEqual(t, dbPort, opts.Port)
+	assert.Empty(t, opts.SSHHost)
+	assert.Equal(t, db.Addr().String(), opts.ForwardedAddr)
+
+	assertEcho(t, net.JoinHostPort(opts.Host, opts.Port))
+
+	opts, err = sc.ForwardOptions(command.Options{
+		Driver:  drivers.SQLServer,
+		URL:     fmt.Sprintf("sqlserver://user:password@%s?database=db", db.Addr()),
+		SSHHost: "bastion",
+	})
+	require.NoError(t, err)
+
+	u, err := url.Parse(opts.URL)
+	require.NoError(t, err)
+	assert.Equal(t, "127.0.0.1", u.Hostname())
+	assert.Equal(t, "database=db", u.RawQuery)
+	assert.Equal(t, "user", u.User.Username())
+	assert.Equal(t, db.Addr().String(), opts.ForwardedAddr)
+
+	assertEcho(t, u.Host)
+
+	_, err = sc.ForwardOptions(command.Options{Driver: drivers.MySQL, Host: dbHost, SSHHost: "bastion"})
+	assert.Error(t, err)
+
+	opts, err = sc.ForwardOptions(command.Options{Driver: drivers.SQLite, DBName: "db.sqlite3", SSHHost: "bastion"})
+	require.NoError(t, err)
+	assert.Equal(t, "bastion", opts.SSHHost)
+
+	require.NoError(t, sc.Close())
+
+	_, err = net.Dial("tcp", u.Host)
+	assert.Error(t, err)
+}
+
+func TestForwardErr(t *testing.T) {
+	// a port nothing listens on, once the listener is closed.
+	db := echoServer(t)
+	addr := db.Addr().String()
+	require.NoError(t, db.Close())
+
+	sc := startTunnel(t, sshdb.WithDBDriver(drivers.MySQL), sshdb.WithLocalForwarding(true))
+	t.Cleanup(func() { sc.Close() })
+	require.NoError(t, sc.Err())
+
+	local, err := sc.Forward(addr)
+	require.NoError(t, err)
+
+	conn, err := net.Dial("tcp", local)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	// the local connection is closed once the database can't be reached.
+	_, err = io.ReadAll(conn)
+	require.NoError(t, err)
+
+	assert.ErrorContains(t, sc.Err(), "failed to reach "+addr+" through the ssh tunnel")
+}
+
+func TestForwardOptionsFallback(t *testing.T) {
+	db := echoServer(t)
+	dbHost, dbPort, _ := net.SplitHostPort(db.Addr().String())
+	given := command.Options{Host: dbHost, Port: dbPort, SSHHost: "bastion"}
+
+	// the postgres driver is given the ssh dialer, the options are left as they are.
+	sc := startTunnel(t, sshdb.WithDBDriver(drivers.Postgres))
+	t.Cleanup(func() { sc.Close() })
+
+	given.Driver = drivers.Postgres
+	opts, err := sc.ForwardOptions(given)
+	require.NoError(t, err)
+	assert.Equal(t, given, opts)
+
+	// the oracle driver dials the server on its own, so a local port is forwarded instead.
+	sc = startTunnel(t, sshdb.WithDBDriver(drivers.Oracle))
+	t.Cleanup(func() { sc.Close() })
+
+	given.Driver = drivers.Oracle
+	opts, err = sc.ForwardOptions(given)
+	require.NoError(t, err)
+	assert.Equal(t, "127.0.0.1", opts.Host)
+
+	assertEcho(t, net.JoinHostPort(opts.Host, opts.Port))
+}
+
+// writeSSHConfig writes an ssh config file with the given hosts, pointing at the given mock ssh servers.
+func writeSSHConfig(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+
+	return path
+}
+
+// startSSHServer starts the mock ssh server and returns its host and port.
+func startSSHServer(t *testing.T) (string, string) {
+	t.Helper()
+
+	t.Cleanup(func() {
+		os.Remove("testdata/known_hosts")
+	})
+
+	listener, err := mockSSHServer(t, "testdata/test_host_key", "testdata/test_client_key.pub")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	host, port, _ := net.SplitHostPort(listener.Addr().String())
+
+	return host, port
+}
+
+func TestSSHConfigHostAlias(t *testing.T) {
+	t.Setenv("SSH_AUTH_SOCK", "")
+
+	key, err := filepath.Abs("testdata/test_client_key")
+	require.NoError(t, err)
+
+	host, port := startSSHServer(t)
+	path := writeSSHConfig(t, fmt.Sprintf(`
+Host bastion-prod
+    HostName %s
+    Port %s
+    User testuser
+    IdentityFile /nonexistent/key
+    IdentityFile %s
+`, host, port, key))
+
+	sc := sshdb.New(
+		sshdb.WithDBDriver(drivers.Postgres),
+		sshdb.WithSShHost("bastion-prod"),
+		sshdb.WithKnownHostsPath("testdata"),
+		sshdb.WithSSHConfigPath(path),
+	)
+	require.NoError(t, sc.SSHTunnel())
+	require.NoError(t, sc.Close())
+}
+
+func TestSSHAgentAuthentication(t *testing.T) {
+	keyBytes, err := os.ReadFile("testdata/test_client_key")
+	require.NoError(t, err)
+	key, err := ssh.ParseRawPrivateKey(keyBytes)
+	require.NoError(t, err)
+
+	keyring := agent.NewKeyring()
+	require.NoError(t, keyring.Add(agent.AddedKey{PrivateKey: key}))
+
+	// the path of a unix socket is limited in length, so it's not made in the test directory.
+	dir, err := os.MkdirTemp("", "dblab-agent")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	socket := filepath.Join(dir, "agent.sock")
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				_ = agent.ServeAgent(keyring, conn)
+				conn.Close()
+			}()
+		}
+	}()
+
+	t.Setenv("SSH_AUTH_SOCK", socket)
+
+	host, port := startSSHServer(t)
+
+	sc := sshdb.New(
+		sshdb.WithDBDriver(drivers.Postgres),
+		sshdb.WithSShHost(host),
+		sshdb.WithSShPort(port),
+		sshdb.WithSSHUser("testuser"),
+		sshdb.WithKnownHostsPath("testdata"),
+		sshdb.WithSSHConfigPath(filepath.Join(t.TempDir(), "config")),
+	)
+	require.NoError(t, sc.SSHTunnel())
+	require.NoError(t, sc.Close())
+}
+
+func TestSSHConfigProxyJump(t *testing.T) {
+	t.Setenv("SSH_AUTH_SOCK", "")
+
+	key, err := filepath.Abs("testdata/test_client_key")
+	require.NoError(t, err)
+
+	db := echoServer(t)
+	dbHost, dbPort, _ := net.SplitHostPort(db.Addr().String())
+
+	jumpHost, jumpPort := startSSHServer(t)
+	host, port := startSSHServer(t)
+	path := writeSSHConfig(t, fmt.Sprintf(`
+Host bastion-prod
+    HostName %s
+    Port %s
+    ProxyJump jump
+
+Host jump
+    HostName %s
+    Port %s
+
+Host *
+    User testuser
+    IdentityFile %s
+`, host, port, jumpHost, jumpPort, key))
+
+	sc := sshdb.New(
+		sshdb.WithDBDriver(drivers.Oracle),
+		sshdb.WithSShHost("bastion-prod"),
+		sshdb.WithKnownHostsPath("testdata"),
+		sshdb.WithSSHConfigPath(path),
+	)
+	require.NoError(t, sc.SSHTunnel())
+	t.Cleanup(func() { sc.Close() })
+
+	opts, err := sc.ForwardOptions(command.Options{Driver: drivers.Oracle, Host: dbHost, Port: dbPort, SSHHost: "bastion-prod"})
+	require.NoError(t, err)
+
+	assertEcho(t, net.JoinHostPort(opts.Host, opts.Port))
+}
+
+func TestSSHJumps(t *testing.T) {
+	t.Setenv("SSH_AUTH_SOCK", "")
+
+	db := echoServer(t)
+	dbHost, dbPort, _ := net.SplitHostPort(db.Addr().String())
+
+	edgeHost, edgePort := startSSHServer(t)
+	coreHost, corePort := startSSHServer(t)
+	host, port := startSSHServer(t)
+
+	// the jumps given take the place of the ProxyJump of the ssh config.
+	path := writeSSHConfig(t, `
+Host *
+    ProxyJump nowhere.invalid
+`)
+
+	sc := sshdb.New(
+		sshdb.WithDBDriver(drivers.SQLServer),
+		sshdb.WithSShHost(host),
+		sshdb.WithSShPort(port),
+		sshdb.WithSSHUser("testuser"),
+		sshdb.WithSSHKeyFile("testdata/test_client_key"),
+		sshdb.WithKnownHostsPath("testdata"),
+		sshdb.WithSSHConfigPath(path),
+		sshdb.WithSSHJumps([]command.SSHHop{
+			{Host: edgeHost, Port: edgePort, User: "edge", KeyFile: "testdata/test_client_key"},
+			{Host: "core@" + net.JoinHostPort(coreHost, corePort), KeyFile: "testdata/test_client_key"},
+		}),
+	)
+	require.NoError(t, sc.SSHTunnel())
+	t.Cleanup(func() { sc.Close() })
+
+	opts, err := sc.ForwardOptions(command.Options{Driver: drivers.SQLServer, Host: dbHost, Port: dbPort, SSHHost: host})
+	require.NoError(t, err)
+
+	assertEcho(t, net.JoinHostPort(opts.Host, opts.Port))
+}
+
+func TestSSHJumpUnreachable(t *testing.T) {
+	t.Setenv("SSH_AUTH_SOCK", "")
+
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	closedHost, closedPort, _ := net.SplitHostPort(closed.Addr().String())
+	closed.Close()
+
+	host, port := startSSHServer(t)
+
+	sc := sshdb.New(
+		sshdb.WithDBDriver(drivers.Postgres),
+		sshdb.WithSShHost(host),
+		sshdb.WithSShPort(port),
+		sshdb.WithSSHUser("testuser"),
+		sshdb.WithSSHKeyFile("testdata/test_client_key"),
+		sshdb.WithKnownHostsPath("testdata"),
+		sshdb.WithSSHConfigPath(filepath.Join(t.TempDir(), "config")),
+		sshdb.WithSSHJumps([]command.SSHHop{
+			{Host: closedHost, Port: closedPort, User: "edge", KeyFile: "testdata/test_client_key"},
+		}),
+	)
+
+	err = sc.SSHTunnel()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), closedHost)
+}
+
+func TestSSHJumpHostKeyChanged(t *testing.T) {
+	t.Setenv("SSH_AUTH_SOCK", "")
+
+	knownHostsPath := t.TempDir()
+
+	privateBytes, err := os.ReadFile("testdata/test_host_key")
+	require.NoError(t, err)
+	hostKey, err := ssh.ParsePrivateKey(privateBytes)
+	require.NoError(t, err)
+
+	var current atomic.Pointer[ssh.Signer]
+	current.Store(&hostKey)
+
+	jumpHost, jumpPort := startSSHServer(t)
+	listener, err := mockSSHServerWithHostKey(t, func() ssh.Signer { return *current.Load() }, "testdata/test_client_key.pub")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+	host, port, _ := net.SplitHostPort(listener.Addr().String())
+
+	tunnel := func() error {
+		sc := sshdb.New(
+			sshdb.WithDBDriver(drivers.Postgres),
+			sshdb.WithSShHost(host),
+			sshdb.WithSShPort(port),
+			sshdb.WithSSHUser("testuser"),
+			sshdb.WithSSHKeyFile("testdata/test_client_key"),
+			sshdb.WithKnownHostsPath(knownHostsPath),
+			sshdb.WithSSHConfigPath(filepath.Join(t.TempDir(), "config")),
+			sshdb.WithSSHJumps([]command.SSHHop{
+				{Host: jumpHost, Port: jumpPort, User: "edge", KeyFile: "testdata/test_client_key"},
+			}),
+		)
+		if err := sc.SSHTunnel(); err != nil {
+			return err
+		}
+
+		return sc.Close()
+	}
+
+	require.NoError(t, tunnel())
+
+	// the key of the server behind the jump is stored under the address it was dialed at.
+	knownHosts, err := os.ReadFile(filepath.Join(knownHostsPath, "known_hosts"))
+	require.NoError(t, err)
+	assert.Contains(t, string(knownHosts), knownhosts.Normalize(net.JoinHostPort(host, port)))
+	assert.NotContains(t, string(knownHosts), "0.0.0.0")
+
+	_, otherKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	otherSigner, err := ssh.NewSignerFromKey(otherKey)
+	require.NoError(t, err)
+	current.Store(&otherSigner)
+
+	err = tunnel()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "key mismatch")
+}
+
+func TestSSHJumpKnownHosts(t *testing.T) {
+	t.Setenv("SSH_AUTH_SOCK", "")
+
+	knownHostsPath := t.TempDir()
+	edgeKnownHosts := filepath.Join(t.TempDir(), "known_hosts_edge")
+	coreKnownHosts := filepath.Join(t.TempDir(), "known_hosts_core")
+
+	edgeHost, edgePort := startSSHServer(t)
+	coreHost, corePort := startSSHServer(t)
+	host, port := startSSHServer(t)
+
+	path := writeSSHConfig(t, fmt.Sprintf(`
+Host core
+    HostName %s
+    Port %s
+    UserKnownHostsFile %s
+`, coreHost, corePort, coreKnownHosts))
+
+	sc := sshdb.New(
+		sshdb.WithDBDriver(drivers.Postgres),
+		sshdb.WithSShHost(host),
+		sshdb.WithSShPort(port),
+		sshdb.WithSSHUser("testuser"),
+		sshdb.WithSSHKeyFile("testdata/test_client_key"),
+		sshdb.WithKnownHostsPath(knownHostsPath),
+		sshdb.WithSSHConfigPath(path),
+		sshdb.WithSSHJumps([]command.SSHHop{
+			{Host: edgeHost, Port: edgePort, User: "edge", KeyFile: "testdata/test_client_key", KnownHosts: edgeKnownHosts},
+			{Host: "core", User: "core", KeyFile: "testdata/test_client_key"},
+		}),
+	)
+	require.NoError(t, sc.SSHTunnel())
+	require.NoError(t, sc.Close())
+
+	// each key is written to the known_hosts file of its own server only.
+	for file, addr := range map[string]string{
+		edgeKnownHosts: net.JoinHostPort(edgeHost, edgePort),
+		coreKnownHosts: net.JoinHostPort(coreHost, corePort),
+		filepath.Join(knownHostsPath, "known_hosts"): net.JoinHostPort(host, port),
+	} {
+		content, err := os.ReadFile(file)
+		require.NoError(t, err)
+		assert.Equal(t, 1, strings.Count(string(content), "\n"), file)
+		assert.Contains(t, string(content), knownhosts.Normalize(addr), file)
+	}
+}